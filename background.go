@@ -0,0 +1,83 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+)
+
+// Backdrop 窗口背景材质 (需要 Windows 11 22H2 及以上)
+type Backdrop int
+
+const (
+	BackdropNone    Backdrop = iota // 无背景材质
+	BackdropMica                    // 云母
+	BackdropAcrylic                 // 亚克力
+	BackdropTabbed                  // 标签页云母
+)
+
+// dwmBackdropType 返回 DWMWA_SYSTEMBACKDROP_TYPE 对应的值
+func (b Backdrop) dwmBackdropType() int32 {
+	switch b {
+	case BackdropMica:
+		return 2 // DWMSBT_MAINWINDOW
+	case BackdropAcrylic:
+		return 3 // DWMSBT_TRANSIENTWINDOW
+	case BackdropTabbed:
+		return 4 // DWMSBT_TABBEDWINDOW
+	default:
+		return 1 // DWMSBT_NONE
+	}
+}
+
+// SetBackgroundColor 设置 WebView 的默认背景色 (CSS 格式，如 "#1e1e1e"、"rgb(0 0 0 / 0)"、"transparent")。
+// 该颜色在页面绘制前显示，并在后续导航中保持不变。
+func (w *webview) SetBackgroundColor(color string) error {
+	c, err := edge.ParseColor(color)
+	if err != nil {
+		return err
+	}
+	var setErr error
+	w.runOnMain(func() {
+		setErr = w.browser.SetBackgroundColor(c)
+	})
+	return setErr
+}
+
+// SetBackdrop 设置窗口背景材质，需要配合透明背景色使用
+func (w *webview) SetBackdrop(backdrop Backdrop) error {
+	var err error
+	w.runOnMain(func() {
+		if backdrop != BackdropNone {
+			if err = w.extendFrameIntoClientArea(); err != nil {
+				return
+			}
+		}
+		value := backdrop.dwmBackdropType()
+		hr, _, _ := w32.DwmSetWindowAttribute.Call(
+			w.hwnd,
+			w32.DWMWA_SYSTEMBACKDROP_TYPE,
+			uintptr(unsafe.Pointer(&value)),
+			unsafe.Sizeof(value),
+		)
+		if int32(hr) < 0 {
+			err = fmt.Errorf("DwmSetWindowAttribute failed with %08x", uint32(hr))
+		}
+	})
+	return err
+}
+
+// extendFrameIntoClientArea 将 DWM 边框扩展到整个客户区，使透明的 WebView 可以透出桌面或背景材质
+func (w *webview) extendFrameIntoClientArea() error {
+	margins := w32.Margins{CxLeftWidth: -1, CxRightWidth: -1, CyTopHeight: -1, CyBottomHeight: -1}
+	hr, _, _ := w32.DwmExtendFrameIntoClientArea.Call(w.hwnd, uintptr(unsafe.Pointer(&margins)))
+	if int32(hr) < 0 {
+		return fmt.Errorf("DwmExtendFrameIntoClientArea failed with %08x", uint32(hr))
+	}
+	return nil
+}
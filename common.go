@@ -6,12 +6,11 @@ package webview2
 import (
 	"context"
 	"errors"
-	"fmt"
-	"strings"
 	"unsafe"
 
 	"github.com/gorilla/websocket"
-	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/hotkey"
+	"github.com/yuaotian/go-win-webview2/pkg/tracing"
 )
 
 // 错误定义
@@ -64,7 +63,7 @@ type WebView interface {
 	Window() unsafe.Pointer
 	// 设置标题
 	SetTitle(title string)
-	// 设置大小
+	// 设置大小 (设备无关像素)
 	SetSize(w int, h int, hint Hint)
 	// 导航
 	Navigate(url string)
@@ -83,6 +82,16 @@ type WebView interface {
 	UnregisterHotKey(modifiers int, keyCode int)
 	// 注册热键字符串
 	RegisterHotKeyString(hotkey string, handler HotKeyHandler) error
+	// 按作用范围注册热键，返回用于注销的句柄
+	AddHotKey(hotkey HotKey, scope HotKeyScope, handler HotKeyHandler) (*HotKeyRegistration, error)
+	// 按作用范围注册热键字符串
+	AddHotKeyString(hotkey string, scope HotKeyScope, handler HotKeyHandler) (*HotKeyRegistration, error)
+	// 按 Keymap 匹配快捷键和组合键序列
+	EnableShortcuts(opts ShortcutOptions) error
+	// 停止匹配快捷键
+	DisableShortcuts()
+	// 注册 Keymap 中命令的处理函数
+	HandleShortcut(command string, handler HotKeyHandler) error
 
 	// 窗口状态
 	// 设置全屏
@@ -98,8 +107,32 @@ type WebView interface {
 	Minimize()                  // 最小化窗口
 	Maximize()                  // 最大化窗口
 	Restore()                   // 还原窗口
+	Show()                      // 显示窗口并置于前台
+	Hide()                      // 隐藏窗口
 	Center()                    // 居中窗口
 	SetOpacity(opacity float64) // 设置窗口透明度 (0.0-1.0)
+	SaveWindowState() error     // 保存窗口状态到 StateFile
+
+	// 显示器
+	CurrentMonitor() (Monitor, bool) // 窗口当前所在的显示器
+	MoveToMonitor(m Monitor) error   // 移动窗口到指定显示器
+
+	// DPI
+	DPI() int                   // 窗口所在显示器的 DPI (96 表示 100%)
+	OnDPIChanged(func(dpi int)) // DPI 变化
+
+	// 背景
+	SetBackgroundColor(color string) error // 设置 WebView 默认背景色 (CSS 格式)
+	SetBackdrop(backdrop Backdrop) error   // 设置窗口背景材质
+
+	// 缩放控制
+	SetZoom(factor float64)             // 设置缩放比例 (1.0 为 100%)
+	Zoom() float64                      // 获取当前缩放比例
+	SetZoomLimits(min, max float64)     // 设置缩放比例范围
+	ZoomIn()                            // 放大一级
+	ZoomOut()                           // 缩小一级
+	ResetZoom()                         // 恢复为 100%
+	OnZoomChanged(func(factor float64)) // 缩放比例变化
 
 	// 浏览器功能
 	Reload()       // 刷新页面
@@ -121,143 +154,71 @@ type WebView interface {
 	OnNavigationStarting(func())                 // 导航开始
 
 	// 打印相关方法
-	Print()                 // 直接打印
-	PrintToPDF(path string) // 打印到 PDF 文件
-	ShowPrintDialog()       // 显示打印对话框
+	Print(ctx context.Context, settings PrintSettings) (PrintStatus, error) // 使用指定打印机静默打印
+	ShowPrintUI(kind PrintDialogKind) error                                 // 显示浏览器或系统打印对话框
+	PrintToPDF(path string)                                                 // 打印到 PDF 文件
+	ShowPrintDialog()                                                       // 显示打印对话框
 
 	// 右键菜单控制
-	DisableContextMenu() error // 禁用右键菜单
-	EnableContextMenu() error  // 启用右键菜单
+	DisableContextMenu() error                // 禁用右键菜单
+	EnableContextMenu() error                 // 启用右键菜单
+	OnContextMenu(handler ContextMenuHandler) // 自定义右键菜单
+
+	// 菜单栏
+	SetMenu(menu *Menu) error // 设置窗口菜单栏，nil 表示移除
+
+	// 系统托盘
+	AddTray(opts TrayOptions) (*Tray, error) // 添加托盘图标
+
+	// 原生对话框
+	Dialogs() *Dialogs                                                                          // 文件/文件夹对话框
+	MessageBox(title, text string, buttons MessageBoxButtons, icon MessageBoxIcon) DialogResult // 消息框
+	TaskDialog(opts TaskDialogOptions) (TaskDialogResult, error)                                // 任务对话框
 
 	// JavaScript Hook 相关方法
 	AddJSHook(hook JSHook)    // 添加 JS Hook
 	RemoveJSHook(hook JSHook) // 移除 JS Hook
 	ClearJSHooks()            // 清除所有 JS Hook
 
+	// 跟踪记录，见 pkg/tracing
+	SetObserver(observer tracing.Observer) // 接收脚本执行、绑定调用、Web 消息和导航的跟踪记录
+
+	// 脚本策略，钩子见 NewSignedScriptHook 和 NewDenyPatternHook
+	EnableCSP(opts CSPOptions) error // 通过资源拦截为页面添加 Content-Security-Policy 头
+	DisableCSP()                     // 停止添加 CSP 头
+
 	// WebSocket 相关方法
-	EnableWebSocket(port int) error              // 启用 WebSocket 服务
-	DisableWebSocket()                           // 禁用 WebSocket 服务
-	OnWebSocketMessage(handler WebSocketHandler) // 设置 WebSocket 消息处理器
-	SendWebSocketMessage(message string)         // 发送 WebSocket 消息
+	EnableWebSocket(port int) error                                    // 在 127.0.0.1 上启用 WebSocket 服务
+	EnableWebSocketWithOptions(opts WebSocketOptions) error            // 按选项启用 WebSocket 服务
+	DisableWebSocket()                                                 // 禁用 WebSocket 服务
+	OnWebSocketMessage(handler WebSocketHandler)                       // 设置 WebSocket 消息处理器
+	SendWebSocketMessage(message string)                               // 发送 WebSocket 消息
+	WebSocketToken() string                                            // 当前会话的 WebSocket 令牌
+	WebSocketAddr() string                                             // WebSocket 服务实际监听的地址
+	OnWebSocketConnect(handler func(conn *WSConn))                     // 设置连接建立回调
+	OnWebSocketDisconnect(handler func(conn *WSConn))                  // 设置连接断开回调
+	OnWebSocketConnMessage(handler func(conn *WSConn, message string)) // 设置带连接信息的消息处理器
+	HandleWebSocketRequest(method string, handler WSRequestHandler)    // 注册客户端请求处理器
+	WebSocketConns() []*WSConn                                         // 当前所有连接
+	WebSocketConn(id string) *WSConn                                   // 按 ID 查找连接
+	PublishWebSocket(room string, data interface{}) (int, error)       // 向房间广播
+
+	// 远程控制
+	EnableRemoteControl(opts RemoteOptions) error // 在 WebSocket 服务上启用远程控制协议
+	DisableRemoteControl()                        // 禁用远程控制
+	RemoteControlToken() string                   // 远程控制令牌
 
 	// 添加 Browser 方法
 	Browser() interface{}
 }
 
-// HotKey 表示一个键组合
-type HotKey struct {
-	Modifiers int // 修饰符
-	KeyCode   int // 键码
-}
+// HotKey 表示一个键组合，字符串语法见 hotkey 包
+type HotKey = hotkey.HotKey
 
 // ParseHotKey 将热键字符串解析为 HotKey 结构
-// 例如: "Ctrl+Alt+Q" -> HotKey{MOD_CONTROL|MOD_ALT, 'Q'}
+// 例如: "Ctrl+Alt+Q" -> HotKey{MOD_CONTROL|MOD_ALT, 'Q'}，"F5" -> HotKey{0, VK_F5}
 func ParseHotKey(s string) (HotKey, error) {
-	parts := strings.Split(s, "+")
-	if len(parts) < 2 {
-		return HotKey{}, errors.New("invalid hotkey format")
-	}
-
-	var modifiers int
-	key := strings.ToUpper(parts[len(parts)-1])
-
-	// 解析修饰符
-	for _, mod := range parts[:len(parts)-1] {
-		switch strings.ToLower(strings.TrimSpace(mod)) {
-		case "ctrl":
-			modifiers |= w32.MOD_CONTROL
-		case "alt":
-			modifiers |= w32.MOD_ALT
-		case "shift":
-			modifiers |= w32.MOD_SHIFT
-		case "win":
-			modifiers |= w32.MOD_WIN
-		default:
-			return HotKey{}, fmt.Errorf("unknown modifier: %s", mod)
-		}
-	}
-
-	// 解析键码
-	var keyCode int
-	if len(key) == 1 {
-		// 字母和数字键
-		keyCode = int(key[0])
-	} else {
-		// 特殊键
-		switch key {
-		case "f1":
-			keyCode = w32.VK_F1
-		case "f2":
-			keyCode = w32.VK_F2
-		case "f3":
-			keyCode = w32.VK_F3
-		case "f4":
-			keyCode = w32.VK_F4
-		case "f5":
-			keyCode = w32.VK_F5
-		case "f6":
-			keyCode = w32.VK_F6
-		case "f7":
-			keyCode = w32.VK_F7
-		case "f8":
-			keyCode = w32.VK_F8
-		case "f9":
-			keyCode = w32.VK_F9
-		case "f10":
-			keyCode = w32.VK_F10
-		case "f11":
-			keyCode = w32.VK_F11
-		case "f12":
-			keyCode = w32.VK_F12
-		case "esc":
-			keyCode = w32.VK_ESCAPE
-		case "tab":
-			keyCode = w32.VK_TAB
-		case "space":
-			keyCode = w32.VK_SPACE
-		default:
-			return HotKey{}, fmt.Errorf("unknown key: %s", key)
-		}
-	}
-
-	return HotKey{Modifiers: modifiers, KeyCode: keyCode}, nil
-}
-
-// JSHookType 定义 Hook 的类型
-type JSHookType int
-
-const (
-	JSHookBefore JSHookType = iota // JS 执行前
-	JSHookAfter                    // JS 执行后
-)
-
-// JSHook 定义 JavaScript 钩子接口
-type JSHook interface {
-	Type() JSHookType            // 获取 Hook 类型
-	Handle(script string) string // 处理脚本
-	Priority() int               // Hook 优先级，数字越小优先级越高
-}
-
-// BaseJSHook 提供基本的 JSHook 实现
-type BaseJSHook struct {
-	HookType     JSHookType
-	Handler      func(script string) string
-	HookPriority int
-}
-
-func (h *BaseJSHook) Type() JSHookType {
-	return h.HookType
-}
-
-func (h *BaseJSHook) Handle(script string) string {
-	if h.Handler != nil {
-		return h.Handler(script)
-	}
-	return script
-}
-
-func (h *BaseJSHook) Priority() int {
-	return h.HookPriority
+	return hotkey.Parse(s)
 }
 
 // WebSocketHandler 定义 WebSocket 消息处理函数
@@ -0,0 +1,312 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"github.com/yuaotian/go-win-webview2/pkg/scriptpolicy"
+)
+
+// maxCSPBody 是 CSP 拦截时读取的响应内容上限
+const maxCSPBody = 64 << 20
+
+// CSPOptions 是 EnableCSP 的选项
+type CSPOptions struct {
+	// Policy 是 Content-Security-Policy 头的值，可用 scriptpolicy.CSP 构造
+	Policy string
+	// ReportOnly 为 true 时使用 Content-Security-Policy-Report-Only，只报告违规
+	ReportOnly bool
+	// Filter 是拦截的 URL 模式，如 "https://app.example/*"，不能为空
+	Filter string
+
+	// Handler 不为 nil 时在进程内提供匹配 Filter 的所有资源 (如嵌入的前端文件)，
+	// 请求不带正文和页面的请求头。为 nil 时用 Client 重新请求匹配 Filter 的 GET 文档，
+	// 重新请求不带页面的 Cookie，只适合不需要登录的页面；
+	// 重新请求无法带上表单正文，匹配 Filter 的 POST 等其他方法的文档返回 405 而不会在没有 CSP 的情况下加载
+	Handler http.Handler
+	// Client 是重新请求文档时使用的客户端，为 nil 时使用 http.DefaultClient
+	Client *http.Client
+}
+
+// cspState 是启用的 CSP 拦截，只在主线程访问
+type cspState struct {
+	options CSPOptions
+	context edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT
+	// next 是启用 CSP 之前的 WebResourceRequestedCallback，不属于 CSP 的请求交给它处理
+	next func(request *edge.ICoreWebView2WebResourceRequest, args *edge.ICoreWebView2WebResourceRequestedEventArgs)
+}
+
+// cspResponse 是拦截的请求的响应
+type cspResponse struct {
+	status int
+	reason string
+	header http.Header
+	body   []byte
+}
+
+// EnableCSP 通过资源拦截为匹配 opts.Filter 的页面添加 Content-Security-Policy 响应头，
+// 限制页面自身可以加载和执行的脚本。Eval、Init 等由程序注入的脚本不受 CSP 限制，
+// 需要同时使用 NewSignedScriptHook 或 NewDenyPatternHook。
+// 再次调用时替换之前的选项。启用时保留浏览器原有的 WebResourceRequestedCallback，
+// 不匹配 Filter 的请求仍交给它处理，DisableCSP 时恢复
+func (w *webview) EnableCSP(opts CSPOptions) error {
+	if opts.Policy == "" {
+		return errors.New("csp policy is required")
+	}
+	if opts.Filter == "" {
+		return errors.New("csp filter is required")
+	}
+	chromium, ok := w.browser.(*edge.Chromium)
+	if !ok {
+		return errors.New("csp is not supported by this browser")
+	}
+
+	// 进程内提供时拦截所有资源，重新请求时只拦截文档
+	state := &cspState{options: opts, context: edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT_DOCUMENT}
+	if opts.Handler != nil {
+		state.context = edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT_ALL
+	}
+
+	var err error
+	w.runOnMain(func() {
+		w.removeCSPFilter(chromium)
+		state.next = chromium.WebResourceRequestedCallback
+		if w.csp != nil {
+			state.next = w.csp.next
+		}
+		if err = chromium.AddWebResourceRequestedFilter(opts.Filter, state.context); err != nil {
+			// 之前的过滤器已移除，恢复原有的回调
+			w.csp = nil
+			chromium.WebResourceRequestedCallback = state.next
+			return
+		}
+		w.csp = state
+		chromium.WebResourceRequestedCallback = func(request *edge.ICoreWebView2WebResourceRequest, args *edge.ICoreWebView2WebResourceRequestedEventArgs) {
+			if w.cspRequested(chromium, request, args) {
+				return
+			}
+			if next := state.next; next != nil {
+				next(request, args)
+			}
+		}
+	})
+	return err
+}
+
+// DisableCSP 停止拦截并恢复 EnableCSP 之前的 WebResourceRequestedCallback，之后加载的页面不再添加 CSP 头
+func (w *webview) DisableCSP() {
+	chromium, ok := w.browser.(*edge.Chromium)
+	if !ok {
+		return
+	}
+	w.runOnMain(func() {
+		if w.csp == nil {
+			return
+		}
+		w.removeCSPFilter(chromium)
+		chromium.WebResourceRequestedCallback = w.csp.next
+		w.csp = nil
+	})
+}
+
+func (w *webview) removeCSPFilter(chromium *edge.Chromium) {
+	if w.csp == nil {
+		return
+	}
+	if err := chromium.RemoveWebResourceRequestedFilter(w.csp.options.Filter, w.csp.context); err != nil {
+		log.Printf("Warning: Failed to remove CSP filter: %v", err)
+	}
+}
+
+// cspRequested 在主线程处理拦截的请求：推迟事件，在其他 goroutine 中取得响应后回到主线程设置响应。
+// 取得响应失败时返回 502，不会在没有 CSP 的情况下加载页面。
+// 请求不匹配 CSP 的过滤器 (来自其他过滤器) 时返回 false
+func (w *webview) cspRequested(chromium *edge.Chromium, request *edge.ICoreWebView2WebResourceRequest, args *edge.ICoreWebView2WebResourceRequestedEventArgs) bool {
+	state := w.csp
+	if state == nil {
+		return false
+	}
+	uri, err := request.GetUri()
+	if err != nil {
+		log.Printf("Warning: Failed to get request uri: %v", err)
+		return false
+	}
+	if !matchResourceFilter(state.options.Filter, uri) {
+		return false
+	}
+	if state.context != edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT_ALL {
+		if context, err := args.GetResourceContext(); err != nil || context != state.context {
+			return false
+		}
+	}
+	method, err := request.GetMethod()
+	if err != nil {
+		method = http.MethodGet
+	}
+
+	deferral, err := args.GetDeferral()
+	if err != nil {
+		log.Printf("Warning: Failed to defer web resource request: %v", err)
+		return true
+	}
+	args.AddRef()
+
+	go func() {
+		var resp cspResponse
+		if state.options.Handler == nil && method != http.MethodGet {
+			// 重新请求无法带上表单等请求正文，拒绝而不是在没有 CSP 的情况下加载
+			resp = cspError(state.options, uri, http.StatusMethodNotAllowed, fmt.Errorf("%s requests cannot be reloaded with CSP without a Handler", method))
+		} else {
+			resp = fetchCSP(state.options, method, uri)
+		}
+		w.Dispatch(func() {
+			defer args.Release()
+			defer deferral.Release()
+
+			var headers strings.Builder
+			for name, values := range resp.header {
+				for _, v := range values {
+					fmt.Fprintf(&headers, "%s: %s\r\n", name, v)
+				}
+			}
+			response, err := chromium.Environment().CreateWebResourceResponse(resp.body, resp.status, resp.reason, headers.String())
+			if err != nil {
+				log.Printf("Warning: Failed to create CSP response for %s: %v", uri, err)
+			} else if err := args.PutResponse(response); err != nil {
+				log.Printf("Warning: Failed to set CSP response for %s: %v", uri, err)
+			}
+			if err := deferral.Complete(); err != nil {
+				log.Printf("Warning: Failed to complete web resource request: %v", err)
+			}
+		})
+	}()
+	return true
+}
+
+// fetchCSP 由 Handler 或 Client 取得响应并添加 CSP 头
+func fetchCSP(opts CSPOptions, method, uri string) cspResponse {
+	resp, err := serveCSP(opts, method, uri)
+	if err != nil {
+		return cspError(opts, uri, http.StatusBadGateway, err)
+	}
+	return withCSPHeader(opts, resp)
+}
+
+// cspError 返回带 CSP 头的错误响应
+func cspError(opts CSPOptions, uri string, status int, err error) cspResponse {
+	log.Printf("Warning: Failed to load %s with CSP: %v", uri, err)
+	return withCSPHeader(opts, cspResponse{
+		status: status,
+		header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		body:   []byte(err.Error()),
+	})
+}
+
+// withCSPHeader 补全状态说明并添加 CSP 头
+func withCSPHeader(opts CSPOptions, resp cspResponse) cspResponse {
+	if resp.reason == "" {
+		resp.reason = http.StatusText(resp.status)
+	}
+	// 内容已完整读取并解压，这些头不再适用
+	for _, name := range []string{"Content-Length", "Content-Encoding", "Transfer-Encoding", "Connection"} {
+		resp.header.Del(name)
+	}
+	scriptpolicy.AddHeader(resp.header, opts.Policy, opts.ReportOnly)
+	return resp
+}
+
+func serveCSP(opts CSPOptions, method, uri string) (cspResponse, error) {
+	req, err := http.NewRequest(method, uri, nil)
+	if err != nil {
+		return cspResponse{}, err
+	}
+
+	if opts.Handler != nil {
+		rec := &cspRecorder{header: http.Header{}}
+		opts.Handler.ServeHTTP(rec, req)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		if rec.header.Get("Content-Type") == "" {
+			rec.header.Set("Content-Type", http.DetectContentType(rec.body.Bytes()))
+		}
+		return cspResponse{status: rec.status, header: rec.header, body: rec.body.Bytes()}, nil
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return cspResponse{}, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxCSPBody))
+	if err != nil {
+		return cspResponse{}, err
+	}
+	reason := strings.TrimSpace(strings.TrimPrefix(res.Status, fmt.Sprint(res.StatusCode)))
+	return cspResponse{status: res.StatusCode, reason: reason, header: res.Header, body: body}, nil
+}
+
+// matchResourceFilter 按 AddWebResourceRequestedFilter 的规则匹配 URL：* 匹配任意多个字符，? 匹配一个字符
+func matchResourceFilter(pattern, uri string) bool {
+	// 回溯到上一个 * 重新匹配
+	p, u, star, mark := 0, 0, -1, 0
+	for u < len(uri) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, u
+			p++
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == uri[u]):
+			p++
+			u++
+		case star >= 0:
+			mark++
+			p, u = star+1, mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// cspRecorder 记录 Handler 写入的响应
+type cspRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *cspRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *cspRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *cspRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if r.body.Len()+len(b) > maxCSPBody {
+		return 0, errors.New("csp response is too large")
+	}
+	return r.body.Write(b)
+}
@@ -5,12 +5,15 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"io/fs"
 	"log"
 	"os"
 	"time"
 
 	webview2 "github.com/yuaotian/go-win-webview2"
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"github.com/yuaotian/go-win-webview2/pkg/hotkey"
 )
 
 //go:embed tmp.html
@@ -19,7 +22,7 @@ var htmlTest embed.FS
 func main() {
 	var isFullscreen, isTopmost bool
 
-	w := webview2.NewWithOptions(webview2.WebViewOptions{
+	w, err := webview2.NewWithOptions(webview2.WebViewOptions{
 		Debug:     true,
 		AutoFocus: true,
 		WindowOptions: webview2.WindowOptions{
@@ -41,8 +44,14 @@ func main() {
 			DefaultBackground:  "#FFFFFF", // 默认背景色 (CSS 格式，如 "#FFFFFF")
 		},
 	})
-	if w == nil {
-		log.Fatalln("加载 webview 失败。")
+	if err != nil {
+		// 此时没有窗口，控制台输出用户也看不到
+		message := "加载 WebView2 失败: " + err.Error()
+		if errors.Is(err, edge.ErrFileNotFound) {
+			message = "加载 WebView2 失败，请确认已安装 Microsoft Edge WebView2 运行时。"
+		}
+		webview2.MessageBox("增强版 Webview 演示", message, webview2.ButtonsOK, webview2.IconError)
+		os.Exit(1)
 	}
 	defer w.Destroy()
 	miniWindow := false
@@ -139,6 +148,36 @@ func main() {
 		}
 	}
 
+	// 窗口内快捷键，只在 WebView 获得焦点时触发
+	if _, err := w.AddHotKeyString("F5", webview2.HotKeyWindow, w.Reload); err != nil {
+		log.Printf("警告: 注册窗口快捷键失败: %v", err)
+	}
+
+	// 组合键快捷键，keymap.json 存在时覆盖默认绑定
+	keymap := hotkey.Keymap{
+		{Key: "Ctrl+K Ctrl+D", Command: "webview.devTools"},
+		{Key: "Ctrl+K Ctrl+T", Command: "demo.topmost", When: "!textInputFocus"},
+	}
+	if user, err := hotkey.LoadKeymap("keymap.json"); err == nil {
+		keymap = keymap.Merge(user)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("警告: 读取 keymap.json 失败: %v", err)
+	}
+	_ = w.HandleShortcut("demo.topmost", func() {
+		isTopmost = !isTopmost
+		w.SetAlwaysOnTop(isTopmost)
+	})
+	if err := w.EnableShortcuts(webview2.ShortcutOptions{
+		Keymap: keymap,
+		OnPending: func(pending hotkey.Sequence) {
+			if pending != nil {
+				log.Printf("已按下 %s，等待下一个键...", pending)
+			}
+		},
+	}); err != nil {
+		log.Printf("警告: 启用快捷键失败: %v", err)
+	}
+
 	// 读取HTML内容
 	htmlContent, err := fs.ReadFile(htmlTest, "tmp.html")
 	if err != nil {
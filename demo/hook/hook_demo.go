@@ -2,25 +2,26 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"regexp"
 	"runtime"
-	"strings"
 	"sync"
 	"time"
 
 	webview2 "github.com/yuaotian/go-win-webview2"
+	"github.com/yuaotian/go-win-webview2/pkg/scriptpolicy"
+	"github.com/yuaotian/go-win-webview2/pkg/tracing"
 )
 
 // 全局配置
 const (
-	defaultPort   = 8080
-	defaultTitle  = "HTML5 测试 - JSHook 和 WebSocket 演示"
-	defaultWidth  = 1024
-	defaultHeight = 768
-	wsWindowTitle = "WebSocket 测试控制台"
-	wsWindowWidth = 800
+	defaultPort    = 8080
+	defaultTitle   = "HTML5 测试 - JSHook 和 WebSocket 演示"
+	defaultWidth   = 1024
+	defaultHeight  = 768
+	wsWindowTitle  = "WebSocket 测试控制台"
+	wsWindowWidth  = 800
 	wsWindowHeight = 600
 )
 
@@ -30,6 +31,10 @@ type AppState struct {
 	wsConnections sync.Map
 	perfStats     map[string]interface{}
 	mu            sync.RWMutex
+
+	// 脚本执行、绑定调用和导航的跟踪记录与汇总
+	recorder *tracing.Recorder
+	metrics  *tracing.Metrics
 }
 
 // WSTestWindow WebSocket测试窗口
@@ -43,6 +48,8 @@ func main() {
 	state := &AppState{
 		startTime: time.Now(),
 		perfStats: make(map[string]interface{}),
+		recorder:  tracing.NewRecorder(256),
+		metrics:   tracing.NewMetrics(),
 	}
 
 	// 创建带调试功能的 webview
@@ -55,8 +62,9 @@ func main() {
 	// 初始化基本配置
 	setupWebView(w, state)
 
-	// 添加钩子
+	// 添加钩子和性能跟踪
 	setupHooks(w)
+	setupTracing(w, state)
 
 	// 启用 WebSocket
 	if err := setupWebSocket(w, state); err != nil {
@@ -120,72 +128,81 @@ func setupWebView(w webview2.WebView, state *AppState) {
 
 // setupHooks 设置JS钩子
 func setupHooks(w webview2.WebView) {
-	// 性能监控钩子
-	perfHook := &webview2.BaseJSHook{
+	// 安全检查钩子: 记录敏感操作，拒绝远程控制发来的动态执行代码的脚本
+	securityHook := &webview2.BaseJSHook{
 		HookType: webview2.JSHookBefore,
-		Handler: func(script string) string {
-			if strings.Contains(script, "_webSocket") {
-				return script
+		ScriptHandler: func(ctx *webview2.ScriptContext, script string) (string, error) {
+			if ctx.Source == webview2.ScriptBind || ctx.Source == webview2.ScriptInternal {
+				return script, nil
+			}
+			err := checkSecurityIssues(script)
+			if err == nil {
+				return script, nil
 			}
-			return wrapWithPerformanceMonitoring(script)
+			if ctx.Source == webview2.ScriptRemote {
+				log.Printf("安全拦截: 远程脚本 (%s): %v", ctx.URL, err)
+				return "", err
+			}
+			log.Printf("安全警告: %s 脚本 (%s): %v", ctx.Source, ctx.Caller, err)
+			return script, nil
 		},
-		HookPriority: 0,
+		HookPriority: 1,
 	}
 
-	// 安全检查钩子
-	securityHook := &webview2.BaseJSHook{
-		HookType: webview2.JSHookBefore,
-		Handler: func(script string) string {
-			checkSecurityIssues(script)
-			return script
+	// 结果钩子: 记录脚本异常
+	resultHook := &webview2.BaseJSHook{
+		HookType: webview2.JSHookAfter,
+		ScriptHandler: func(ctx *webview2.ScriptContext, script string) (string, error) {
+			var thrown *webview2.EvalError
+			if errors.As(ctx.Err, &thrown) {
+				log.Printf("脚本异常 (%s): %s", ctx.URL, thrown.Message)
+			}
+			return script, nil
 		},
-		HookPriority: 1,
 	}
 
-	w.AddJSHook(perfHook)
 	w.AddJSHook(securityHook)
+	w.AddJSHook(resultHook)
 }
 
-// wrapWithPerformanceMonitoring 包装脚本以添加性能监控
-func wrapWithPerformanceMonitoring(script string) string {
-	return fmt.Sprintf(`
-		console.time('执行时间');
-		try {
-			const startMemory = performance.memory ? performance.memory.usedJSHeapSize : 0;
-			%s
-			if (performance.memory) {
-				const endMemory = performance.memory.usedJSHeapSize;
-				console.log('内存使用:', (endMemory - startMemory) / 1024 / 1024, 'MB');
-			}
-		} finally {
-			console.timeEnd('执行时间');
-			console.log('脚本大小:', %d, '字节');
+// slowSpan 是记录慢操作日志的阈值
+const slowSpan = 100 * time.Millisecond
+
+// setupTracing 记录脚本执行、绑定调用、消息和导航的耗时，慢操作写入日志
+func setupTracing(w webview2.WebView, state *AppState) {
+	state.metrics.Publish("webview2")
+	w.SetObserver(tracing.Multi(state.recorder, state.metrics, tracing.ObserverFunc(func(s tracing.Span) {
+		if s.Duration >= slowSpan || s.Err != nil {
+			log.Printf("慢操作: %s %s 耗时 %v, 大小 %d 字节, 错误: %v", s.Kind, s.Name, s.Duration, s.Size, s.Err)
 		}
-	`, script, len(script))
+	})))
 }
 
-// checkSecurityIssues 检查安全问题
-func checkSecurityIssues(script string) {
-	sensitivePatterns := map[string]string{
-		"eval\\(":           "使用eval",
-		"document\\.cookie": "访问cookie",
-		"localStorage":      "使用localStorage",
-		"sessionStorage":    "使用sessionStorage",
-		"new Function":      "动态创建函数",
-		"innerHTML":         "使用innerHTML",
-		"document\\.write":  "使用document.write",
-	}
+// 默认规则拒绝动态执行代码和写入 HTML，敏感操作只记录
+var (
+	blockedScanner   = scriptpolicy.NewScanner()
+	sensitiveScanner = scriptpolicy.NewScanner(
+		scriptpolicy.Rule{Name: "访问cookie", Path: "document.cookie"},
+		scriptpolicy.Rule{Name: "使用localStorage", Path: "localStorage"},
+		scriptpolicy.Rule{Name: "使用sessionStorage", Path: "sessionStorage"},
+	)
+)
 
-	for pattern, desc := range sensitivePatterns {
-		if matched, _ := regexp.MatchString(pattern, script); matched {
-			log.Printf("安全警告: 检测到%s", desc)
-		}
+// checkSecurityIssues 检查安全问题
+func checkSecurityIssues(script string) error {
+	for _, f := range sensitiveScanner.Scan(script) {
+		log.Printf("安全警告: 检测到%s (%d:%d)", f.Rule, f.Line, f.Column)
 	}
+	return blockedScanner.Check(script)
 }
 
 // setupWebSocket 设置WebSocket服务
 func setupWebSocket(w webview2.WebView, state *AppState) error {
-	err := w.EnableWebSocket(defaultPort)
+	// 测试页面不是本地内容，需要加入允许的来源才会注入客户端
+	err := w.EnableWebSocketWithOptions(webview2.WebSocketOptions{
+		Port:           defaultPort,
+		AllowedOrigins: []string{"https://html5test.com"},
+	})
 	if err != nil {
 		return fmt.Errorf("启动WebSocket失败: %v", err)
 	}
@@ -196,46 +213,50 @@ func setupWebSocket(w webview2.WebView, state *AppState) error {
 		go wsWindow.w.Run()
 	}
 
-	// 确保WebSocket连接在页面加载完成后初始化
-	w.Eval(fmt.Sprintf(`
+	// 内置客户端会自动连接和重连，这里为每个新连接添加演示用的事件处理
+	w.Init(`
 		window._initWebSocket = function() {
-			if (!window._webSocket || window._webSocket.readyState !== 1) {
+			const ws = window._webSocket;
+			if (!ws || ws._demoReady) {
+				return ws;
+			}
+			ws._demoReady = true;
+			ws.addEventListener('open', () => {
+				console.log('WebSocket已连接');
+				ws.send(JSON.stringify({
+					type: 'status',
+					data: '主窗口WebSocket已连接'
+				}));
+			});
+			ws.addEventListener('close', () => console.log('WebSocket已断开'));
+			ws.addEventListener('error', (e) => console.error('WebSocket错误:', e));
+			ws.addEventListener('message', (event) => {
 				try {
-					window._webSocket = new WebSocket('ws://localhost:%d/ws');
-					window._webSocket.onopen = () => {
-						console.log('WebSocket已连接');
-						window._webSocket.send(JSON.stringify({
-							type: 'status',
-							data: '主窗口WebSocket已连接'
-						}));
-					};
-					window._webSocket.onclose = () => console.log('WebSocket已断开');
-					window._webSocket.onerror = (e) => console.error('WebSocket错误:', e);
-					window._webSocket.onmessage = (event) => {
-						try {
-							const data = JSON.parse(event.data);
-							console.log('收到消息:', data);
-							if (data.type === 'console') {
-								eval(data.data);
-							}
-						} catch(e) {
-							console.error('解析消息失败:', e);
-						}
-					};
+					console.log('收到消息:', JSON.parse(event.data));
 				} catch(e) {
-					console.error('WebSocket连接失败:', e);
+					console.error('解析消息失败:', e);
 				}
-			}
-			return window._webSocket;
+			});
+			return ws;
 		};
-		
-		// 定期检查并重连
-		setInterval(() => {
-			if (!window._webSocket || window._webSocket.readyState !== 1) {
-				window._initWebSocket();
-			}
-		}, 3000);
-	`, defaultPort))
+
+		// 定期检查新连接
+		setInterval(() => window._initWebSocket(), 1000);
+
+		// 接收测试窗口发布到 main 房间的消息
+		if (window.webview2 && window.webview2.ws) {
+			window.webview2.ws.join('main', (data, from) => {
+				console.log('来自 ' + from + ' 的消息:', data);
+			});
+		}
+	`)
+
+	w.OnWebSocketConnect(func(conn *webview2.WSConn) {
+		log.Printf("WebSocket 连接: %s (%s)", conn.ID(), conn.Origin())
+	})
+	w.OnWebSocketDisconnect(func(conn *webview2.WSConn) {
+		log.Printf("WebSocket 断开: %s", conn.ID())
+	})
 
 	w.OnLoadingStateChanged(func(isLoading bool) {
 		if !isLoading {
@@ -284,15 +305,10 @@ func monitorServerStatus(w webview2.WebView, state *AppState) {
 
 	for range ticker.C {
 		stats := getServerStats(state)
-		message, err := json.Marshal(map[string]interface{}{
-			"type": "serverStatus",
-			"data": stats,
-		})
-		if err != nil {
+		// 只发送给测试窗口
+		if _, err := w.PublishWebSocket("console", stats); err != nil {
 			log.Printf("JSON序列化错误: %v", err)
-			continue
 		}
-		w.SendWebSocketMessage(string(message))
 	}
 }
 
@@ -311,6 +327,7 @@ func getServerStats(state *AppState) map[string]interface{} {
 			"gc_cycles": uint64(m.NumGC),
 		},
 		"goroutines": runtime.NumGoroutine(),
+		"scripts":    state.metrics.Snapshot(),
 	}
 }
 
@@ -813,8 +830,12 @@ func createWSTestWindow(mainWin webview2.WebView) *WSTestWindow {
 			</div>
 			<script>
 				// WebSocket初始化
-				window._webSocket = new WebSocket('ws://localhost:8080/ws');
-				window._webSocket.onopen = () => addMessage('WebSocket已连接', 'sent');
+				window._webSocket = new WebSocket('ws://` + mainWin.WebSocketAddr() + `/ws?token=` + mainWin.WebSocketToken() + `');
+				window._webSocket.onopen = () => {
+					addMessage('WebSocket已连接', 'sent');
+					// 加入 console 房间，接收主窗口和服务端发布的消息
+					window._webSocket.send(JSON.stringify({type: 'join', room: 'console'}));
+				};
 				window._webSocket.onclose = () => addMessage('WebSocket已断开', 'error');
 				window._webSocket.onerror = (e) => console.error('WebSocket错误:', e);
 				window._webSocket.onmessage = (event) => {
@@ -851,8 +872,10 @@ func createWSTestWindow(mainWin webview2.WebView) *WSTestWindow {
 					const message = input.value.trim();
 					if (message && window._webSocket && window._webSocket.readyState === 1) {
 						try {
+							// 只发送给加入 main 房间的主窗口
 							window._webSocket.send(JSON.stringify({
-								type: 'console',
+								type: 'publish',
+								room: 'main',
 								data: message
 							}));
 							addMessage('发送: ' + message, 'sent');
@@ -5,10 +5,8 @@ import (
 	"log"
 	"os"
 	"sync"
-	"unsafe"
 
 	webview2 "github.com/yuaotian/go-win-webview2"
-	"github.com/yuaotian/go-win-webview2/internal/w32"
 )
 
 //go:embed favicon.ico
@@ -18,20 +16,10 @@ var iconData embed.FS
 type WindowState struct {
 	sync.Mutex
 	isFullscreen bool
-	isMaximized  bool
 	isMinimized  bool
 	opacity      float64
-	lastWidth    int
-	lastHeight   int
-	lastX        int
-	lastY        int
 }
 
-// 窗口边缘调整大小的区域大小
-const (
-	resizeAreaSize = 8
-)
-
 func getIconBytes() []byte {
 	data, err := iconData.ReadFile("favicon.ico")
 	if err != nil {
@@ -52,7 +40,7 @@ func main() {
 	}
 
 	// 创建自定义样式的窗口
-	w := webview2.NewWithOptions(webview2.WebViewOptions{
+	w, err := webview2.NewWithOptions(webview2.WebViewOptions{
 		Debug: true,
 		WindowOptions: webview2.WindowOptions{
 			Title:              "现代化窗口示例",
@@ -66,9 +54,14 @@ func main() {
 			DisableContextMenu: false,
 			DefaultBackground:  "#ffffff",
 			Opacity:            state.opacity,
-			Resizable:          false,
+			Resizable:          true,
+			ResizeBorder:       8,
 		},
 	})
+	if err != nil {
+		webview2.MessageBox("现代化窗口示例", "创建窗口失败: "+err.Error(), webview2.ButtonsOK, webview2.IconError)
+		return
+	}
 
 	// 绑定窗口控制函数
 	bindWindowControls(w, state)
@@ -83,51 +76,8 @@ func main() {
 	w.Run()
 }
 
+// 标题栏拖动、最小化/最大化/关闭按钮和边缘调整大小由 -webkit-app-region 和 data-webview2-* 属性提供，无需绑定
 func bindWindowControls(w webview2.WebView, state *WindowState) {
-	// 最小化
-	w.Bind("minimize", func() {
-		log.Println("窗口最小化")
-		state.Lock()
-		state.isMinimized = true
-		state.Unlock()
-		w.Minimize()
-	})
-
-	// 最大化切换
-	w.Bind("toggleMaximize", func() {
-		state.Lock()
-		defer state.Unlock()
-
-		state.isMaximized = !state.isMaximized
-		if state.isMaximized {
-			// 保存当前窗口位置和大小
-			var rect w32.Rect
-			w32.GetWindowRect(w32.Handle(w.Window()), &rect)
-			state.lastX = int(rect.Left)
-			state.lastY = int(rect.Top)
-			state.lastWidth = int(rect.Right - rect.Left)
-			state.lastHeight = int(rect.Bottom - rect.Top)
-
-			// 获取工作区大小
-			var workArea w32.Rect
-			w32.SystemParametersInfo(w32.SPI_GETWORKAREA, 0, unsafe.Pointer(&workArea), 0)
-
-			log.Println("窗口最大化")
-			w.Maximize()
-		} else {
-			log.Println("窗口还原")
-			w.Restore()
-		}
-	})
-
-	// 关闭窗口
-	w.Bind("closeWindow", func() {
-		log.Println("关闭窗口")
-		w.Terminate()
-		w.Destroy()
-		os.Exit(0)
-	})
-
 	// 设置透明度
 	w.Bind("setOpacity", func(value float64) {
 		log.Printf("设置透明度: %.2f", value)
@@ -151,41 +101,6 @@ func bindWindowControls(w webview2.WebView, state *WindowState) {
 		log.Println("窗口居中")
 		w.Center()
 	})
-
-	// 窗口拖动
-	w.Bind("startDragging", func() {
-		log.Println("开始拖动窗口")
-		hwnd := w.Window()
-		w32.ReleaseCapture()
-		w32.SendMessage(w32.Handle(uintptr(hwnd)), w32.WMNCLButtonDown, w32.HTCaption, 0)
-	})
-
-	// 窗口大小调整
-	w.Bind("startResizing", func(edge string) {
-		log.Printf("开始调整窗口大小: %s", edge)
-		hwnd := w.Window()
-		w32.ReleaseCapture()
-		var hitTest uintptr
-		switch edge {
-		case "top":
-			hitTest = w32.HTTop
-		case "right":
-			hitTest = w32.HTRight
-		case "bottom":
-			hitTest = w32.HTBottom
-		case "left":
-			hitTest = w32.HTLeft
-		case "topLeft":
-			hitTest = w32.HTTopLeft
-		case "topRight":
-			hitTest = w32.HTTopRight
-		case "bottomLeft":
-			hitTest = w32.HTBottomLeft
-		case "bottomRight":
-			hitTest = w32.HTBottomRight
-		}
-		w32.SendMessage(w32.Handle(uintptr(hwnd)), w32.WMNCLButtonDown, hitTest, 0)
-	})
 }
 
 func registerHotkeys(w webview2.WebView, state *WindowState) {
@@ -233,7 +148,6 @@ func getHTML() string {
                 --bg-color: #ffffff;
                 --text-color: #333333;
                 --title-bar-height: 36px;
-                --resize-area: 8px;
             }
 
             * {
@@ -251,75 +165,6 @@ func getHTML() string {
                 user-select: none;
             }
 
-            .resize-handle {
-                position: fixed;
-                z-index: 9999;
-            }
-
-            .resize-handle.top {
-                top: 0;
-                left: var(--resize-area);
-                right: var(--resize-area);
-                height: var(--resize-area);
-                cursor: n-resize;
-            }
-
-            .resize-handle.right {
-                top: var(--resize-area);
-                right: 0;
-                bottom: var(--resize-area);
-                width: var(--resize-area);
-                cursor: e-resize;
-            }
-
-            .resize-handle.bottom {
-                bottom: 0;
-                left: var(--resize-area);
-                right: var(--resize-area);
-                height: var(--resize-area);
-                cursor: s-resize;
-            }
-
-            .resize-handle.left {
-                top: var(--resize-area);
-                left: 0;
-                bottom: var(--resize-area);
-                width: var(--resize-area);
-                cursor: w-resize;
-            }
-
-            .resize-handle.top-left {
-                top: 0;
-                left: 0;
-                width: var(--resize-area);
-                height: var(--resize-area);
-                cursor: nw-resize;
-            }
-
-            .resize-handle.top-right {
-                top: 0;
-                right: 0;
-                width: var(--resize-area);
-                height: var(--resize-area);
-                cursor: ne-resize;
-            }
-
-            .resize-handle.bottom-left {
-                bottom: 0;
-                left: 0;
-                width: var(--resize-area);
-                height: var(--resize-area);
-                cursor: sw-resize;
-            }
-
-            .resize-handle.bottom-right {
-                bottom: 0;
-                right: 0;
-                width: var(--resize-area);
-                height: var(--resize-area);
-                cursor: se-resize;
-            }
-
             .title-bar {
                 -webkit-app-region: drag;
                 position: fixed;
@@ -443,39 +288,6 @@ func getHTML() string {
         </style>
         <script>
             document.addEventListener('DOMContentLoaded', function() {
-                var titleBar = document.querySelector('.title-bar');
-                
-                // 添加窗口大小调整句柄
-                var resizeAreas = [
-                    { class: 'top', edge: 'top' },
-                    { class: 'right', edge: 'right' },
-                    { class: 'bottom', edge: 'bottom' },
-                    { class: 'left', edge: 'left' },
-                    { class: 'top-left', edge: 'topLeft' },
-                    { class: 'top-right', edge: 'topRight' },
-                    { class: 'bottom-left', edge: 'bottomLeft' },
-                    { class: 'bottom-right', edge: 'bottomRight' }
-                ];
-
-                for (var i = 0; i < resizeAreas.length; i++) {
-                    var area = resizeAreas[i];
-                    var handle = document.createElement('div');
-                    handle.className = 'resize-handle ' + area.class;
-                    handle.addEventListener('mousedown', function(e) {
-                        e.preventDefault();
-                        window.startResizing(this.getAttribute('data-edge'));
-                    });
-                    handle.setAttribute('data-edge', area.edge);
-                    document.body.appendChild(handle);
-                }
-
-                // 窗口拖动
-                titleBar.addEventListener('mousedown', function(e) {
-                    if (!e.target.closest('.controls')) {
-                        window.startDragging();
-                    }
-                });
-
                 // 添加键盘快捷键提示
                 var shortcuts = document.querySelectorAll('.shortcut');
                 for (var i = 0; i < shortcuts.length; i++) {
@@ -488,9 +300,9 @@ func getHTML() string {
         <div class="title-bar">
             <div class="title">现代化窗口示例</div>
             <div class="controls">
-                <button class="ctrl-btn" onclick="window.minimize()" title="最小化">─</button>
-                <button class="ctrl-btn" onclick="window.toggleMaximize()" title="最大化">□</button>
-                <button class="ctrl-btn close-btn" onclick="window.closeWindow()" title="关闭">×</button>
+                <button class="ctrl-btn" data-webview2-minimize title="最小化">─</button>
+                <button class="ctrl-btn" data-webview2-maximize title="最大化">□</button>
+                <button class="ctrl-btn close-btn" data-webview2-close title="关闭">×</button>
             </div>
         </div>
         <div class="main-content">
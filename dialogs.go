@@ -0,0 +1,145 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+)
+
+// FileFilter 文件类型过滤器
+type FileFilter struct {
+	Name    string `json:"name"`    // 显示名称，如 "图片"
+	Pattern string `json:"pattern"` // 匹配模式，多个用分号分隔，如 "*.png;*.jpg"
+}
+
+// FileDialogOptions 文件对话框选项
+type FileDialogOptions struct {
+	Title            string       `json:"title"`            // 对话框标题
+	DefaultPath      string       `json:"defaultPath"`      // 初始目录，或带文件名的初始路径
+	Filters          []FileFilter `json:"filters"`          // 文件类型过滤器
+	ButtonLabel      string       `json:"buttonLabel"`      // 确认按钮文字
+	DefaultExtension string       `json:"defaultExtension"` // 保存时自动补全的扩展名，如 "txt"
+}
+
+// Dialogs 显示以窗口为父窗口的原生文件对话框。
+// 用户取消时返回空路径和 nil 错误。
+type Dialogs struct {
+	w *webview
+}
+
+// Dialogs 返回窗口的文件对话框
+func (w *webview) Dialogs() *Dialogs {
+	return &Dialogs{w: w}
+}
+
+// OpenFile 选择一个已存在的文件
+func (d *Dialogs) OpenFile(opts FileDialogOptions) (string, error) {
+	paths, err := d.show(w32.FileDialogOpen, opts)
+	if len(paths) == 0 {
+		return "", err
+	}
+	return paths[0], err
+}
+
+// OpenFiles 选择多个已存在的文件
+func (d *Dialogs) OpenFiles(opts FileDialogOptions) ([]string, error) {
+	return d.show(w32.FileDialogOpenMultiple, opts)
+}
+
+// SaveFile 选择保存位置，文件已存在时会提示是否覆盖
+func (d *Dialogs) SaveFile(opts FileDialogOptions) (string, error) {
+	paths, err := d.show(w32.FileDialogSave, opts)
+	if len(paths) == 0 {
+		return "", err
+	}
+	return paths[0], err
+}
+
+// PickFolder 选择一个文件夹
+func (d *Dialogs) PickFolder(opts FileDialogOptions) (string, error) {
+	paths, err := d.show(w32.FileDialogFolder, opts)
+	if len(paths) == 0 {
+		return "", err
+	}
+	return paths[0], err
+}
+
+func (d *Dialogs) show(kind w32.FileDialogKind, opts FileDialogOptions) ([]string, error) {
+	config := w32.FileDialogConfig{
+		Kind:             kind,
+		Title:            opts.Title,
+		ButtonLabel:      opts.ButtonLabel,
+		DefaultExtension: opts.DefaultExtension,
+	}
+	for _, f := range opts.Filters {
+		config.Filters = append(config.Filters, w32.FileFilter{Name: f.Name, Spec: f.Pattern})
+	}
+	// DefaultPath 是已存在的目录时作为初始目录，否则拆分为目录和文件名
+	if opts.DefaultPath != "" {
+		if info, err := os.Stat(opts.DefaultPath); (err == nil && info.IsDir()) || kind == w32.FileDialogFolder {
+			config.Folder = opts.DefaultPath
+		} else {
+			config.Folder, config.FileName = filepath.Split(opts.DefaultPath)
+		}
+	}
+
+	var (
+		paths []string
+		err   error
+	)
+	// 对话框必须在窗口所在的 STA 线程上显示
+	d.w.runOnMain(func() {
+		paths, err = w32.ShowFileDialog(d.w.hwnd, config)
+	})
+	if errors.Is(err, w32.ErrDialogCancelled) {
+		return nil, nil
+	}
+	return paths, err
+}
+
+// dialogScript 在页面中提供 window.webview2.dialogs，各方法返回 Promise，取消时结果为 null
+const dialogScript = `
+	(function() {
+		window.webview2 = window.webview2 || {};
+		window.webview2.dialogs = {
+			openFile: function(opts) { return window.__webview2OpenFile(opts || {}); },
+			openFiles: function(opts) { return window.__webview2OpenFiles(opts || {}); },
+			saveFile: function(opts) { return window.__webview2SaveFile(opts || {}); },
+			pickFolder: function(opts) { return window.__webview2PickFolder(opts || {}); }
+		};
+	})();
+`
+
+// bindDialogs 将文件对话框绑定到页面
+func (w *webview) bindDialogs() {
+	d := w.Dialogs()
+	// 取消时返回 nil，页面得到 null 而不是空字符串
+	single := func(f func(FileDialogOptions) (string, error)) func(FileDialogOptions) (interface{}, error) {
+		return func(opts FileDialogOptions) (interface{}, error) {
+			path, err := f(opts)
+			if path == "" || err != nil {
+				return nil, err
+			}
+			return path, nil
+		}
+	}
+	_ = w.Bind("__webview2OpenFile", single(d.OpenFile))
+	_ = w.Bind("__webview2SaveFile", single(d.SaveFile))
+	_ = w.Bind("__webview2PickFolder", single(d.PickFolder))
+	_ = w.Bind("__webview2OpenFiles", func(opts FileDialogOptions) (interface{}, error) {
+		paths, err := d.OpenFiles(opts)
+		if len(paths) == 0 || err != nil {
+			return nil, err
+		}
+		return paths, nil
+	})
+	if err := w.browser.Init(dialogScript); err != nil {
+		log.Printf("Warning: Failed to add dialog script: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/remote"
+)
+
+var dpiAwarenessOnce sync.Once
+
+// enableDpiAwareness 将进程声明为 Per-Monitor V2 DPI 感知，必须在创建任何窗口之前调用
+func enableDpiAwareness() {
+	dpiAwarenessOnce.Do(func() {
+		if !w32.EnablePerMonitorDpiAwareness() {
+			log.Printf("Warning: Failed to enable per-monitor DPI awareness")
+		}
+	})
+}
+
+// scaleForDPI 将设备无关像素 (DIP, 96 DPI 下的像素) 转换为指定 DPI 下的物理像素
+func scaleForDPI(v int, dpi uint32) int32 {
+	return int32((int64(v)*int64(dpi) + w32.USER_DEFAULT_SCREEN_DPI/2) / w32.USER_DEFAULT_SCREEN_DPI)
+}
+
+// primaryMonitorDPI 返回主显示器的 DPI
+func primaryMonitorDPI() uint32 {
+	rect := w32.Rect{Right: 1, Bottom: 1}
+	monitor, _, _ := w32.User32MonitorFromRect.Call(uintptr(unsafe.Pointer(&rect)), w32.MONITOR_DEFAULTTOPRIMARY)
+	return w32.GetDpiForMonitor(monitor)
+}
+
+// DPI 返回窗口当前所在显示器的 DPI (96 表示 100% 缩放)
+func (w *webview) DPI() int {
+	return int(atomic.LoadUint32(&w.dpi))
+}
+
+// OnDPIChanged 设置 DPI 变化回调，窗口移动到不同 DPI 的显示器或系统缩放改变时触发
+func (w *webview) OnDPIChanged(callback func(dpi int)) {
+	w.m.Lock()
+	w.onDPIChanged = callback
+	w.m.Unlock()
+}
+
+// handleDPIChanged 处理 WM_DPICHANGED，按系统建议的矩形调整窗口并通知 Go 和页面
+func (w *webview) handleDPIChanged(dpi uint32, suggested *w32.Rect) {
+	if atomic.SwapUint32(&w.dpi, dpi) == dpi {
+		return
+	}
+	if !w.ignoreDPIRect {
+		_, _, _ = w32.User32SetWindowPos.Call(
+			w.hwnd,
+			0,
+			uintptr(suggested.Left),
+			uintptr(suggested.Top),
+			uintptr(suggested.Right-suggested.Left),
+			uintptr(suggested.Bottom-suggested.Top),
+			w32.SWP_NOZORDER|w32.SWP_NOACTIVATE,
+		)
+	}
+	w.browser.Resize()
+
+	w.m.Lock()
+	callback := w.onDPIChanged
+	w.m.Unlock()
+	if callback != nil {
+		callback(int(dpi))
+	}
+	w.notifyRemote(remote.EventDPIChanged, int(dpi))
+
+	if err := w.browser.Eval(fmt.Sprintf(
+		`window.dispatchEvent(new CustomEvent('webview2:dpichanged', {detail: {dpi: %d, scale: %g}}));`,
+		dpi, float64(dpi)/w32.USER_DEFAULT_SCREEN_DPI,
+	)); err != nil {
+		log.Printf("Warning: Failed to dispatch DPI change: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yuaotian/go-win-webview2/pkg/tracing"
+)
+
+// EvalError 是页面执行脚本时抛出的异常
+type EvalError struct {
+	// Message 是异常信息。Eval 的脚本作为顶层脚本执行，After 钩子中只能得知脚本未执行完，
+	// 异常详情见 DevTools 控制台
+	Message string
+}
+
+func (e *EvalError) Error() string {
+	return "script error: " + e.Message
+}
+
+// evalReply 是页面通过 __webview2EvalResult 返回的执行结果
+type evalReply struct {
+	value json.RawMessage
+	err   error
+}
+
+// evalState 记录等待结果的脚本
+type evalState struct {
+	sync.Mutex
+	seq     uint64
+	pending map[uint64]chan evalReply
+}
+
+// bindEvalResult 注册页面返回执行结果的绑定
+func (w *webview) bindEvalResult() {
+	_ = w.Bind("__webview2EvalResult", func(id uint64, ok bool, value json.RawMessage) {
+		w.evals.Lock()
+		ch := w.evals.pending[id]
+		delete(w.evals.pending, id)
+		w.evals.Unlock()
+		if ch == nil {
+			return
+		}
+		if ok {
+			ch <- evalReply{value: value}
+			return
+		}
+		var message string
+		_ = json.Unmarshal(value, &message)
+		ch <- evalReply{err: &EvalError{Message: message}}
+	})
+}
+
+// evalResultScript 将 script 包装为对表达式求值并通过绑定返回结果的脚本，
+// 结果为 Promise 时等待其完成，无法序列化为 JSON 的结果作为异常返回
+func evalResultScript(id uint64, script string) string {
+	return fmt.Sprintf(`(function() {
+		var id = %d, done = function(ok, value) { window.__webview2EvalResult(id, ok, value); };
+		Promise.resolve().then(function() {
+			return (0, eval)(%s);
+		}).then(function(value) {
+			var json;
+			try {
+				json = JSON.stringify(value === undefined ? null : value);
+			} catch (e) {
+				return done(false, 'result is not serializable: ' + e);
+			}
+			done(true, json === undefined ? null : JSON.parse(json));
+		}, function(e) {
+			done(false, String(e && e.stack || e));
+		});
+	})()`, id, jsString(script))
+}
+
+// evalResult 经过钩子后在当前页面中执行脚本并等待结果，结果同样交给 After 钩子。
+// 页面在返回结果前导航或关闭时一直等到 ctx 结束
+func (w *webview) evalResult(ctx context.Context, sctx ScriptContext, script string) (json.RawMessage, error) {
+	ch := make(chan evalReply, 1)
+	w.evals.Lock()
+	w.evals.seq++
+	id := w.evals.seq
+	if w.evals.pending == nil {
+		w.evals.pending = make(map[uint64]chan evalReply)
+	}
+	w.evals.pending[id] = ch
+	w.evals.Unlock()
+
+	defer func() {
+		w.evals.Lock()
+		delete(w.evals.pending, id)
+		w.evals.Unlock()
+	}()
+
+	start := time.Now()
+	hookCtx := sctx
+	final := script
+	var rejected error
+	w.runOnMain(func() {
+		hookCtx.URL = w.browser.Source()
+		if final, rejected = w.runBeforeHooks(&hookCtx, script); rejected == nil {
+			if err := w.browser.Eval(evalResultScript(id, final)); err != nil {
+				rejected = err
+			}
+		}
+	})
+	if rejected != nil {
+		hookCtx.Err = rejected
+		w.Dispatch(func() {
+			w.runAfterHooks(&hookCtx, script)
+			w.traceScript(tracing.KindEval, &hookCtx, script, start)
+		})
+		return nil, rejected
+	}
+
+	select {
+	case reply := <-ch:
+		hookCtx.Result, hookCtx.Err = reply.value, reply.err
+	case <-ctx.Done():
+		hookCtx.Err = ctx.Err()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			hookCtx.Err = fmt.Errorf("script did not return a result: %w", ctx.Err())
+		}
+	}
+	result, err := hookCtx.Result, hookCtx.Err
+	w.Dispatch(func() {
+		w.runAfterHooks(&hookCtx, final)
+		w.traceScript(tracing.KindEval, &hookCtx, final, start)
+	})
+	return result, err
+}
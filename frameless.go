@@ -0,0 +1,499 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"fmt"
+	"log"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"golang.org/x/sys/windows"
+)
+
+// defaultResizeBorder 是无边框窗口默认的可调整大小边框宽度 (DIP)
+const defaultResizeBorder = 5
+
+// framelessState 保存无边框窗口的标题栏和边框信息
+type framelessState struct {
+	border      int        // 可调整大小的边框宽度 (DIP)，0 表示不可调整大小
+	maximizable bool       // 是否允许最大化
+	minimizable bool       // 是否允许最小化
+	dragRects   []w32.Rect // 页面上报的拖动区域 (客户区物理像素)
+	noDragRects []w32.Rect // 拖动区域内不可拖动的部分
+	maxButton   *w32.Rect  // 页面上报的最大化按钮区域
+	maximized   bool       // 上次通知页面的最大化状态
+	background  uintptr    // 最大化按钮区域的背景色 (COLORREF)，未露出 WebView 时不绘制
+	paint       bool       // 是否绘制 background，透明窗口不绘制
+}
+
+// frameRect 是页面上报的矩形，单位为 CSS 像素
+type frameRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// frameRegions 是页面上报的标题栏区域
+type frameRegions struct {
+	Ratio     float64     `json:"ratio"` // window.devicePixelRatio
+	Drag      []frameRect `json:"drag"`
+	NoDrag    []frameRect `json:"noDrag"`
+	MaxButton *frameRect  `json:"maxButton"`
+}
+
+func (r frameRect) toClient(ratio float64) w32.Rect {
+	return w32.Rect{
+		Left:   int32(r.X * ratio),
+		Top:    int32(r.Y * ratio),
+		Right:  int32((r.X + r.Width) * ratio),
+		Bottom: int32((r.Y + r.Height) * ratio),
+	}
+}
+
+func ptInRect(r w32.Rect, x, y int32) bool {
+	return x >= r.Left && x < r.Right && y >= r.Top && y < r.Bottom
+}
+
+// framelessStyle 返回无边框窗口的样式。保留标题栏和边框样式以获得系统的最小化/最大化动画、
+// Aero Snap 以及任务栏行为，边框由 WM_NCCALCSIZE 去除
+func framelessStyle(opts WindowOptions) uint32 {
+	style := w32.WSOverlappedWindow
+	if !opts.Maximizable {
+		style &^= w32.WSMaximizeBox
+	}
+	if !opts.Minimizable {
+		style &^= w32.WSMinimizeBox
+	}
+	return style
+}
+
+func newFramelessState(opts WindowOptions) *framelessState {
+	border := opts.ResizeBorder
+	if border == 0 {
+		border = defaultResizeBorder
+	}
+	if border < 0 || !opts.Resizable {
+		border = 0
+	}
+	state := &framelessState{
+		border:      border,
+		maximizable: opts.Maximizable,
+		minimizable: opts.Minimizable,
+	}
+	// 挖去最大化按钮区域后露出的主窗口使用页面的默认背景色
+	if color, err := edge.ParseColor(opts.DefaultBackground); err == nil && color.A > 0 && !opts.Transparent {
+		state.background = uintptr(color.R) | uintptr(color.G)<<8 | uintptr(color.B)<<16
+		state.paint = true
+	}
+	return state
+}
+
+// initFrameless 为无边框窗口注入标题栏脚本并绑定窗口操作，需要在 WebView 创建后调用
+func (w *webview) initFrameless() {
+	if err := w.Bind("__webview2FrameAction", w.frameAction); err != nil {
+		log.Printf("Warning: Failed to bind frame action: %v", err)
+	}
+	if err := w.Bind("__webview2FrameResize", w.frameResize); err != nil {
+		log.Printf("Warning: Failed to bind frame resize: %v", err)
+	}
+	if err := w.Bind("__webview2FrameRegions", w.frameRegions); err != nil {
+		log.Printf("Warning: Failed to bind frame regions: %v", err)
+	}
+	if err := w.Bind("__webview2IsMaximized", func() bool {
+		return w32.IsZoomed(w.hwnd)
+	}); err != nil {
+		log.Printf("Warning: Failed to bind frame state: %v", err)
+	}
+	if err := w.browser.Init(fmt.Sprintf(framelessScript, w.frame.border, w.frame.maximizable)); err != nil {
+		log.Printf("Warning: Failed to add frameless script: %v", err)
+	}
+}
+
+// frameAction 处理页面标题栏操作: drag、minimize、maximize (切换)、close
+func (w *webview) frameAction(action string) {
+	w.Dispatch(func() {
+		switch action {
+		case "drag":
+			w.startNonClientAction(w32.HTCaption)
+		case "minimize":
+			if w.frame.minimizable {
+				_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_MINIMIZE)
+			}
+		case "maximize":
+			w.toggleMaximize()
+		case "close":
+			_, _, _ = w32.User32PostMessageW.Call(w.hwnd, w32.WMClose, 0, 0)
+		}
+	})
+}
+
+// frameResize 开始从指定边缘调整窗口大小
+func (w *webview) frameResize(edge string) {
+	hit, ok := map[string]uintptr{
+		"top":         w32.HTTop,
+		"bottom":      w32.HTBottom,
+		"left":        w32.HTLeft,
+		"right":       w32.HTRight,
+		"topLeft":     w32.HTTopLeft,
+		"topRight":    w32.HTTopRight,
+		"bottomLeft":  w32.HTBottomLeft,
+		"bottomRight": w32.HTBottomRight,
+	}[edge]
+	if !ok {
+		return
+	}
+	w.Dispatch(func() {
+		if w.frame.border == 0 || w32.IsZoomed(w.hwnd) || w.fullscreen {
+			return
+		}
+		w.startNonClientAction(hit)
+	})
+}
+
+// frameRegions 保存页面上报的拖动区域和最大化按钮位置，用于 WM_NCHITTEST
+func (w *webview) frameRegions(regions frameRegions) {
+	ratio := regions.Ratio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	var drag, noDrag []w32.Rect
+	for _, r := range regions.Drag {
+		drag = append(drag, r.toClient(ratio))
+	}
+	for _, r := range regions.NoDrag {
+		noDrag = append(noDrag, r.toClient(ratio))
+	}
+	var maxButton *w32.Rect
+	if regions.MaxButton != nil {
+		r := regions.MaxButton.toClient(ratio)
+		maxButton = &r
+	}
+
+	w.m.Lock()
+	w.frame.dragRects = drag
+	w.frame.noDragRects = noDrag
+	w.frame.maxButton = maxButton
+	w.m.Unlock()
+	w.Dispatch(w.updateCaptionRegion)
+}
+
+// startNonClientAction 释放页面的鼠标捕获并让系统以指定的命中区域开始拖动或调整大小
+func (w *webview) startNonClientAction(hit uintptr) {
+	pt, ok := w32.GetCursorPos()
+	if !ok {
+		return
+	}
+	w32.ReleaseCapture()
+	lp := uintptr(uint16(pt.X)) | uintptr(uint16(pt.Y))<<16
+	_, _, _ = w32.User32SendMessageW.Call(w.hwnd, w32.WMNCLButtonDown, hit, lp)
+}
+
+func (w *webview) toggleMaximize() {
+	if w.fullscreen {
+		return
+	}
+	if w32.IsZoomed(w.hwnd) {
+		_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_RESTORE)
+	} else if w.frame == nil || w.frame.maximizable {
+		_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_MAXIMIZE)
+	}
+}
+
+// captionRegionMax 是 WebView 宿主窗口区域的外边界，足够覆盖任何窗口大小，调整窗口大小时无需重建区域
+const captionRegionMax = 0x7FFF
+
+// updateCaptionRegion 在 Windows 11 上把页面上报的最大化按钮区域从 WebView 宿主窗口中挖去，
+// 使该区域的鼠标落在主窗口上，由 WM_NCHITTEST 返回 HTMAXBUTTON，系统在悬停时显示贴靠布局。
+// 该区域显示主窗口的背景而不是页面内容。只在主线程调用
+func (w *webview) updateCaptionRegion() {
+	if w.frame == nil || windows.RtlGetVersion().BuildNumber < 22000 {
+		return
+	}
+	w.m.Lock()
+	var hole *w32.Rect
+	if w.frame.maxButton != nil && w.frame.maximizable && !w.fullscreen {
+		r := *w.frame.maxButton
+		hole = &r
+	}
+	w.m.Unlock()
+
+	child, _, _ := w32.User32GetWindow.Call(w.hwnd, w32.GW_CHILD)
+	for ; child != 0; child, _, _ = w32.User32GetWindow.Call(child, w32.GW_HWNDNEXT) {
+		if hole == nil {
+			_, _, _ = w32.User32SetWindowRgn.Call(child, 0, 1)
+			continue
+		}
+		// 区域使用宿主窗口的坐标
+		var rect w32.Rect
+		_, _, _ = w32.User32GetWindowRect.Call(child, uintptr(unsafe.Pointer(&rect)))
+		origin := w32.Point{X: rect.Left, Y: rect.Top}
+		_, _, _ = w32.User32ScreenToClient.Call(w.hwnd, uintptr(unsafe.Pointer(&origin)))
+
+		rgn, _, _ := w32.Gdi32CreateRectRgn.Call(0, 0, captionRegionMax, captionRegionMax)
+		cut, _, _ := w32.Gdi32CreateRectRgn.Call(
+			uintptr(hole.Left-origin.X), uintptr(hole.Top-origin.Y),
+			uintptr(hole.Right-origin.X), uintptr(hole.Bottom-origin.Y),
+		)
+		if rgn == 0 || cut == 0 {
+			_, _, _ = w32.Gdi32DeleteObject.Call(rgn)
+			_, _, _ = w32.Gdi32DeleteObject.Call(cut)
+			continue
+		}
+		_, _, _ = w32.Gdi32CombineRgn.Call(rgn, rgn, cut, w32.RGN_DIFF)
+		_, _, _ = w32.Gdi32DeleteObject.Call(cut)
+		// 设置成功后区域归系统所有
+		if ret, _, _ := w32.User32SetWindowRgn.Call(child, rgn, 1); ret == 0 {
+			_, _, _ = w32.Gdi32DeleteObject.Call(rgn)
+		}
+	}
+}
+
+// framelessPaint 处理 WM_PAINT，主窗口只有挖去的最大化按钮区域可见
+func (w *webview) framelessPaint() {
+	var ps w32.PaintStruct
+	hdc, _, _ := w32.User32BeginPaint.Call(w.hwnd, uintptr(unsafe.Pointer(&ps)))
+	if hdc != 0 && w.frame.paint {
+		brush, _, _ := w32.Gdi32CreateSolidBrush.Call(w.frame.background)
+		if brush != 0 {
+			_, _, _ = w32.User32FillRect.Call(hdc, uintptr(unsafe.Pointer(&ps.RcPaint)), brush)
+			_, _, _ = w32.Gdi32DeleteObject.Call(brush)
+		}
+	}
+	_, _, _ = w32.User32EndPaint.Call(w.hwnd, uintptr(unsafe.Pointer(&ps)))
+}
+
+// framelessCalcSize 处理 WM_NCCALCSIZE，使客户区覆盖整个窗口。
+// 最大化时窗口会超出显示器一个边框的宽度，需要内缩以免内容被裁剪
+func (w *webview) framelessCalcSize(lp uintptr) {
+	if !w32.IsZoomed(w.hwnd) || w.fullscreen {
+		return
+	}
+	params := (*w32.NCCalcSizeParams)(unsafe.Pointer(lp))
+	rect := &params.Rgrc[0]
+	dpi := w32.GetDpiForWindow(w.hwnd)
+	padding := w32.GetSystemMetricsForDpi(w32.SM_CXPADDEDBORDER, dpi)
+	cx := w32.GetSystemMetricsForDpi(w32.SM_CXFRAME, dpi) + padding
+	cy := w32.GetSystemMetricsForDpi(w32.SM_CYFRAME, dpi) + padding
+	rect.Left += cx
+	rect.Top += cy
+	rect.Right -= cx
+	rect.Bottom -= cy
+}
+
+// framelessHitTest 处理无边框窗口的 WM_NCHITTEST：
+// 边框用于调整大小，页面上报的最大化按钮返回 HTMAXBUTTON 以支持贴靠布局，拖动区域返回 HTCAPTION
+func (w *webview) framelessHitTest(lp uintptr) uintptr {
+	x := int32(int16(lp & 0xffff))
+	y := int32(int16((lp >> 16) & 0xffff))
+
+	if w.frame.border > 0 && !w.fullscreen && !w32.IsZoomed(w.hwnd) {
+		var rect w32.Rect
+		_, _, _ = w32.User32GetWindowRect.Call(w.hwnd, uintptr(unsafe.Pointer(&rect)))
+		border := scaleForDPI(w.frame.border, uint32(w.DPI()))
+
+		top := y < rect.Top+border
+		bottom := y >= rect.Bottom-border
+		left := x < rect.Left+border
+		right := x >= rect.Right-border
+		switch {
+		case top && left:
+			return w32.HTTopLeft
+		case top && right:
+			return w32.HTTopRight
+		case bottom && left:
+			return w32.HTBottomLeft
+		case bottom && right:
+			return w32.HTBottomRight
+		case top:
+			return w32.HTTop
+		case bottom:
+			return w32.HTBottom
+		case left:
+			return w32.HTLeft
+		case right:
+			return w32.HTRight
+		}
+	}
+
+	pt := w32.Point{X: x, Y: y}
+	_, _, _ = w32.User32ScreenToClient.Call(w.hwnd, uintptr(unsafe.Pointer(&pt)))
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.frame.maxButton != nil && w.frame.maximizable && ptInRect(*w.frame.maxButton, pt.X, pt.Y) {
+		return w32.HTMaxButton
+	}
+	for _, r := range w.frame.noDragRects {
+		if ptInRect(r, pt.X, pt.Y) {
+			return w32.HTClient
+		}
+	}
+	for _, r := range w.frame.dragRects {
+		if ptInRect(r, pt.X, pt.Y) {
+			return w32.HTCaption
+		}
+	}
+	return w32.HTClient
+}
+
+// notifyMaximizeChanged 在最大化状态变化时通知页面
+func (w *webview) notifyMaximizeChanged(maximized bool) {
+	if w.frame == nil || w.frame.maximized == maximized {
+		return
+	}
+	w.frame.maximized = maximized
+	if err := w.browser.Eval(fmt.Sprintf(
+		`window.dispatchEvent(new CustomEvent('webview2:maximizechanged', {detail: {maximized: %t}}));`,
+		maximized,
+	)); err != nil {
+		log.Printf("Warning: Failed to dispatch maximize change: %v", err)
+	}
+}
+
+// framelessScript 在页面中实现无边框窗口的标题栏：
+//   - 拖动区域: CSS -webkit-app-region: drag / no-drag，或 data-webview2-drag / data-webview2-no-drag 属性
+//   - 窗口按钮: data-webview2-minimize、data-webview2-maximize、data-webview2-close 属性
+//   - 调整大小: 距窗口边缘 ResizeBorder (DIP) 以内的区域
+//
+// 最大化时 <html> 元素带有 data-webview2-maximized 属性，可用于切换按钮图标。
+// Windows 11 上第一个 data-webview2-maximize 元素的区域由窗口处理 (见 updateCaptionRegion)
+const framelessScript = `
+(function() {
+	var border = %d;
+	var maximizable = %t;
+	var dpi = 96;
+	var maximized = false;
+	var lastDown = {time: 0, x: 0, y: 0};
+
+	function call(name, args) {
+		if (typeof window[name] === 'function') {
+			window[name].apply(window, args);
+		}
+	}
+
+	function appRegion(el) {
+		for (; el && el.nodeType === 1; el = el.parentElement) {
+			if (el.hasAttribute('data-webview2-no-drag')) return 'no-drag';
+			if (el.hasAttribute('data-webview2-drag')) return 'drag';
+			var style = getComputedStyle(el);
+			var region = style.getPropertyValue('-webkit-app-region') || style.getPropertyValue('app-region');
+			if (region === 'drag' || region === 'no-drag') return region;
+		}
+		return '';
+	}
+
+	function control(el) {
+		return el && el.closest ? el.closest('[data-webview2-minimize],[data-webview2-maximize],[data-webview2-close]') : null;
+	}
+
+	function edgeAt(x, y) {
+		if (border <= 0 || maximized) return '';
+		var b = border * (dpi / 96) / window.devicePixelRatio;
+		var w = window.innerWidth, h = window.innerHeight;
+		var v = y < b ? 'top' : (y >= h - b ? 'bottom' : '');
+		var hz = x < b ? 'left' : (x >= w - b ? 'right' : '');
+		if (v && hz) return v + hz.charAt(0).toUpperCase() + hz.slice(1);
+		return v || hz;
+	}
+
+	var cursors = {
+		top: 'ns-resize', bottom: 'ns-resize', left: 'ew-resize', right: 'ew-resize',
+		topLeft: 'nwse-resize', bottomRight: 'nwse-resize', topRight: 'nesw-resize', bottomLeft: 'nesw-resize'
+	};
+	var cursorStyle = document.createElement('style');
+	function setCursor(edge) {
+		var css = edge ? '* { cursor: ' + cursors[edge] + ' !important; }' : '';
+		if (cursorStyle.textContent !== css) cursorStyle.textContent = css;
+	}
+
+	function rectOf(el) {
+		var r = el.getBoundingClientRect();
+		return {x: r.left, y: r.top, width: r.width, height: r.height};
+	}
+
+	// 只在区域变化时通知 Go，样式变化很频繁，每次 RPC 还需要一次返回结果的 Eval
+	var reportPending = false, lastRegions = '';
+	function report() {
+		if (reportPending) return;
+		reportPending = true;
+		requestAnimationFrame(function() {
+			reportPending = false;
+			if (!document.body) return;
+			var drag = [], noDrag = [], maxButton = null;
+			var all = document.body.getElementsByTagName('*');
+			for (var i = 0; i < all.length; i++) {
+				var el = all[i];
+				var style = getComputedStyle(el);
+				var region = style.getPropertyValue('-webkit-app-region') || style.getPropertyValue('app-region');
+				if (el.hasAttribute('data-webview2-drag') || region === 'drag') drag.push(rectOf(el));
+				if (el.hasAttribute('data-webview2-no-drag') || region === 'no-drag' || control(el) === el) noDrag.push(rectOf(el));
+				if (!maxButton && el.hasAttribute('data-webview2-maximize')) maxButton = rectOf(el);
+			}
+			var regions = {ratio: window.devicePixelRatio, drag: drag, noDrag: noDrag, maxButton: maxButton};
+			var json = JSON.stringify(regions);
+			if (json === lastRegions) return;
+			lastRegions = json;
+			call('__webview2FrameRegions', [regions]);
+		});
+	}
+
+	document.addEventListener('mousemove', function(e) {
+		setCursor(edgeAt(e.clientX, e.clientY));
+	}, true);
+
+	document.addEventListener('mousedown', function(e) {
+		if (e.button !== 0) return;
+		var edge = edgeAt(e.clientX, e.clientY);
+		if (edge) {
+			e.preventDefault();
+			e.stopPropagation();
+			call('__webview2FrameResize', [edge]);
+			return;
+		}
+		if (control(e.target) || appRegion(e.target) !== 'drag') return;
+		e.preventDefault();
+		var now = Date.now();
+		var doubleClick = now - lastDown.time < 500 &&
+			Math.abs(e.screenX - lastDown.x) < 4 && Math.abs(e.screenY - lastDown.y) < 4;
+		lastDown = doubleClick ? {time: 0, x: 0, y: 0} : {time: now, x: e.screenX, y: e.screenY};
+		call('__webview2FrameAction', [doubleClick ? (maximizable ? 'maximize' : '') : 'drag']);
+	}, true);
+
+	document.addEventListener('click', function(e) {
+		var el = control(e.target);
+		if (!el) return;
+		if (el.hasAttribute('data-webview2-minimize')) call('__webview2FrameAction', ['minimize']);
+		else if (el.hasAttribute('data-webview2-maximize')) call('__webview2FrameAction', ['maximize']);
+		else if (el.hasAttribute('data-webview2-close')) call('__webview2FrameAction', ['close']);
+	});
+
+	window.addEventListener('webview2:dpichanged', function(e) { dpi = e.detail.dpi; report(); });
+	function setMaximized(value) {
+		maximized = value;
+		if (maximized) document.documentElement.setAttribute('data-webview2-maximized', '');
+		else document.documentElement.removeAttribute('data-webview2-maximized');
+		setCursor('');
+	}
+	window.addEventListener('webview2:maximizechanged', function(e) { setMaximized(e.detail.maximized); });
+	window.addEventListener('resize', report);
+	document.addEventListener('DOMContentLoaded', function() {
+		document.head.appendChild(cursorStyle);
+		if (window.webview2 && window.webview2.getDPI) {
+			window.webview2.getDPI().then(function(value) { dpi = value; report(); });
+		}
+		if (window.__webview2IsMaximized) {
+			window.__webview2IsMaximized().then(setMaximized);
+		}
+		new MutationObserver(report).observe(document.body, {
+			childList: true, subtree: true, attributes: true,
+			attributeFilter: ['class', 'style', 'hidden', 'data-webview2-drag', 'data-webview2-no-drag',
+				'data-webview2-minimize', 'data-webview2-maximize', 'data-webview2-close']
+		});
+		report();
+	});
+})();
+`
@@ -0,0 +1,251 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"syscall"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+)
+
+// errorHotKeyAlreadyRegistered 是 RegisterHotKey 在热键已被其他程序占用时的错误码
+const errorHotKeyAlreadyRegistered = syscall.Errno(1409)
+
+var (
+	// ErrHotKeyRegistered 表示同一个键组合已在本窗口注册
+	ErrHotKeyRegistered = errors.New("hotkey is already registered")
+	// ErrHotKeyInUse 表示全局热键已被其他程序占用
+	ErrHotKeyInUse = errors.New("hotkey is in use by another application")
+	// ErrHotKeyNotRegistered 表示热键未注册或已注销
+	ErrHotKeyNotRegistered = errors.New("hotkey is not registered")
+)
+
+// HotKeyScope 是热键的作用范围
+type HotKeyScope int
+
+const (
+	// HotKeyGlobal 是系统全局热键，窗口没有焦点时也会触发，同一键组合只能被一个程序注册
+	HotKeyGlobal HotKeyScope = iota
+	// HotKeyWindow 是窗口内快捷键，只在 WebView 获得焦点时触发，按键不再传给页面。
+	// 与菜单快捷键相同时菜单优先
+	HotKeyWindow
+)
+
+func (s HotKeyScope) String() string {
+	switch s {
+	case HotKeyGlobal:
+		return "global"
+	case HotKeyWindow:
+		return "window"
+	default:
+		return fmt.Sprintf("HotKeyScope(%d)", int(s))
+	}
+}
+
+// HotKeyError 是注册或注销热键失败的错误，包含失败的键组合
+type HotKeyError struct {
+	HotKey HotKey
+	Scope  HotKeyScope
+	Err    error
+}
+
+func (e *HotKeyError) Error() string {
+	return fmt.Sprintf("%s hotkey %s: %v", e.Scope, e.HotKey, e.Err)
+}
+
+func (e *HotKeyError) Unwrap() error {
+	return e.Err
+}
+
+// HotKeyRegistration 是已注册的热键，用于注销
+type HotKeyRegistration struct {
+	w       *webview
+	id      int
+	hotkey  HotKey
+	scope   HotKeyScope
+	handler HotKeyHandler
+}
+
+// HotKey 返回注册的键组合
+func (r *HotKeyRegistration) HotKey() HotKey {
+	return r.hotkey
+}
+
+// Scope 返回热键的作用范围
+func (r *HotKeyRegistration) Scope() HotKeyScope {
+	return r.scope
+}
+
+// Unregister 注销热键，重复调用返回 ErrHotKeyNotRegistered
+func (r *HotKeyRegistration) Unregister() error {
+	return r.w.unregisterHotKey(r)
+}
+
+// hotKeyRegistry 保存注册的热键，由 webview.m 保护，注册和注销在主线程进行
+type hotKeyRegistry struct {
+	nextID int
+	byKey  map[HotKey]*HotKeyRegistration
+	byID   map[int]*HotKeyRegistration // 全局热键，键为 WM_HOTKEY 的 ID
+}
+
+// hotKeyKey 返回热键在注册表中的键，去掉不影响匹配的 MOD_NOREPEAT
+func hotKeyKey(hotkey HotKey) HotKey {
+	return HotKey{Modifiers: hotkey.Modifiers &^ w32.MOD_NOREPEAT, KeyCode: hotkey.KeyCode}
+}
+
+// AddHotKey 注册热键，返回用于注销的 HotKeyRegistration。
+// 键组合已在任一作用范围注册时返回 ErrHotKeyRegistered，全局热键被其他程序占用时返回 ErrHotKeyInUse，
+// 错误为 *HotKeyError。handler 在主线程调用
+func (w *webview) AddHotKey(hotkey HotKey, scope HotKeyScope, handler HotKeyHandler) (*HotKeyRegistration, error) {
+	if handler == nil {
+		return nil, &HotKeyError{HotKey: hotkey, Scope: scope, Err: errors.New("handler is nil")}
+	}
+	if scope != HotKeyGlobal && scope != HotKeyWindow {
+		return nil, &HotKeyError{HotKey: hotkey, Scope: scope, Err: errors.New("unknown scope")}
+	}
+
+	var reg *HotKeyRegistration
+	var err error
+	w.runOnMain(func() {
+		key := hotKeyKey(hotkey)
+		w.m.Lock()
+		if existing, ok := w.hotkeys.byKey[key]; ok {
+			w.m.Unlock()
+			err = &HotKeyError{HotKey: hotkey, Scope: scope, Err: ErrHotKeyRegistered}
+			if existing.scope != scope {
+				err = &HotKeyError{HotKey: hotkey, Scope: scope, Err: fmt.Errorf("%w as %s hotkey", ErrHotKeyRegistered, existing.scope)}
+			}
+			return
+		}
+		w.hotkeys.nextID++
+		id := w.hotkeys.nextID
+		w.m.Unlock()
+
+		if scope == HotKeyGlobal {
+			ret, _, callErr := w32.User32RegisterHotKey.Call(w.hwnd, uintptr(id), uintptr(hotkey.Modifiers), uintptr(hotkey.KeyCode))
+			if ret == 0 {
+				if callErr == errorHotKeyAlreadyRegistered {
+					callErr = ErrHotKeyInUse
+				}
+				err = &HotKeyError{HotKey: hotkey, Scope: scope, Err: callErr}
+				return
+			}
+		}
+
+		reg = &HotKeyRegistration{w: w, id: id, hotkey: hotkey, scope: scope, handler: handler}
+		w.m.Lock()
+		if w.hotkeys.byKey == nil {
+			w.hotkeys.byKey = make(map[HotKey]*HotKeyRegistration)
+			w.hotkeys.byID = make(map[int]*HotKeyRegistration)
+		}
+		w.hotkeys.byKey[key] = reg
+		if scope == HotKeyGlobal {
+			w.hotkeys.byID[id] = reg
+		}
+		w.m.Unlock()
+	})
+	return reg, err
+}
+
+// AddHotKeyString 通过字符串注册热键，如 w.AddHotKeyString("F5", HotKeyWindow, w.Reload)
+func (w *webview) AddHotKeyString(hotkey string, scope HotKeyScope, handler HotKeyHandler) (*HotKeyRegistration, error) {
+	hk, err := ParseHotKey(hotkey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hotkey %q: %w", hotkey, err)
+	}
+	return w.AddHotKey(hk, scope, handler)
+}
+
+// RegisterHotKey 注册全局热键
+func (w *webview) RegisterHotKey(modifiers int, keyCode int, handler HotKeyHandler) error {
+	_, err := w.AddHotKey(HotKey{Modifiers: modifiers, KeyCode: keyCode}, HotKeyGlobal, handler)
+	return err
+}
+
+// UnregisterHotKey 注销由 RegisterHotKey 注册的全局热键
+func (w *webview) UnregisterHotKey(modifiers int, keyCode int) {
+	w.m.Lock()
+	reg, ok := w.hotkeys.byKey[hotKeyKey(HotKey{Modifiers: modifiers, KeyCode: keyCode})]
+	w.m.Unlock()
+	if !ok || reg.scope != HotKeyGlobal {
+		return
+	}
+	if err := reg.Unregister(); err != nil {
+		log.Printf("Warning: Failed to unregister hotkey: %v", err)
+	}
+}
+
+// RegisterHotKeyString 通过字符串注册全局热键
+// 例如: "Ctrl+Alt+Q"
+func (w *webview) RegisterHotKeyString(hotkey string, handler HotKeyHandler) error {
+	_, err := w.AddHotKeyString(hotkey, HotKeyGlobal, handler)
+	return err
+}
+
+func (w *webview) unregisterHotKey(reg *HotKeyRegistration) error {
+	var err error
+	w.runOnMain(func() {
+		key := hotKeyKey(reg.hotkey)
+		w.m.Lock()
+		if w.hotkeys.byKey[key] != reg {
+			w.m.Unlock()
+			err = &HotKeyError{HotKey: reg.hotkey, Scope: reg.scope, Err: ErrHotKeyNotRegistered}
+			return
+		}
+		delete(w.hotkeys.byKey, key)
+		delete(w.hotkeys.byID, reg.id)
+		w.m.Unlock()
+
+		if reg.scope == HotKeyGlobal {
+			if ret, _, callErr := w32.User32UnregisterHotKey.Call(w.hwnd, uintptr(reg.id)); ret == 0 {
+				err = &HotKeyError{HotKey: reg.hotkey, Scope: reg.scope, Err: callErr}
+			}
+		}
+	})
+	return err
+}
+
+// unregisterAllHotKeys 注销所有热键，在销毁窗口时调用
+func (w *webview) unregisterAllHotKeys() {
+	w.m.Lock()
+	ids := make([]int, 0, len(w.hotkeys.byID))
+	for id := range w.hotkeys.byID {
+		ids = append(ids, id)
+	}
+	w.hotkeys.byKey = nil
+	w.hotkeys.byID = nil
+	w.m.Unlock()
+
+	for _, id := range ids {
+		_, _, _ = w32.User32UnregisterHotKey.Call(w.hwnd, uintptr(id))
+	}
+}
+
+// globalHotKey 处理 WM_HOTKEY，返回是否找到对应的热键
+func (w *webview) globalHotKey(id int) bool {
+	w.m.Lock()
+	reg, ok := w.hotkeys.byID[id]
+	w.m.Unlock()
+	if !ok {
+		return false
+	}
+	reg.handler()
+	return true
+}
+
+// windowHotKey 处理 WebView 获得焦点时的按键，返回是否匹配窗口内快捷键
+func (w *webview) windowHotKey(virtualKey uint) bool {
+	key := HotKey{Modifiers: currentModifiers(), KeyCode: int(virtualKey)}
+	w.m.Lock()
+	reg, ok := w.hotkeys.byKey[key]
+	w.m.Unlock()
+	if !ok || reg.scope != HotKeyWindow {
+		return false
+	}
+	reg.handler()
+	return true
+}
@@ -0,0 +1,257 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/pkg/hresult"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	Ole32CoCreateInstance              = ole32.NewProc("CoCreateInstance")
+	Shell32SHCreateItemFromParsingName = shell32.NewProc("SHCreateItemFromParsingName")
+)
+
+var (
+	clsidFileOpenDialog = windows.GUID{Data1: 0xDC1C5A9C, Data2: 0xE88A, Data3: 0x4DDE, Data4: [8]byte{0xA5, 0xA1, 0x60, 0xF8, 0x2A, 0x20, 0xAE, 0xF7}}
+	clsidFileSaveDialog = windows.GUID{Data1: 0xC0B4E2F3, Data2: 0xBA21, Data3: 0x4773, Data4: [8]byte{0x8D, 0xBA, 0x33, 0x5E, 0xC9, 0x46, 0xEB, 0x8B}}
+	iidIFileOpenDialog  = windows.GUID{Data1: 0xD57C7288, Data2: 0xD4AD, Data3: 0x4768, Data4: [8]byte{0xBE, 0x02, 0x9D, 0x96, 0x95, 0x32, 0xD9, 0x60}}
+	iidIFileSaveDialog  = windows.GUID{Data1: 0x84BCCD23, Data2: 0x5FDE, Data3: 0x4CDB, Data4: [8]byte{0xAE, 0xA4, 0xAF, 0x64, 0xB8, 0x3D, 0x78, 0xAB}}
+	iidIShellItem       = windows.GUID{Data1: 0x43826D1E, Data2: 0xE718, Data3: 0x42EE, Data4: [8]byte{0xBC, 0x55, 0xA1, 0xE2, 0x61, 0xC3, 0x7B, 0xFE}}
+)
+
+const (
+	CLSCTX_INPROC_SERVER = 0x1
+
+	FOS_OVERWRITEPROMPT  = 0x00000002
+	FOS_NOCHANGEDIR      = 0x00000008
+	FOS_PICKFOLDERS      = 0x00000020
+	FOS_FORCEFILESYSTEM  = 0x00000040
+	FOS_ALLOWMULTISELECT = 0x00000200
+	FOS_PATHMUSTEXIST    = 0x00000800
+	FOS_FILEMUSTEXIST    = 0x00001000
+
+	SIGDN_FILESYSPATH = 0x80058000
+)
+
+// ErrDialogCancelled is returned when the user closes a file dialog without choosing
+var ErrDialogCancelled = errors.New("dialog cancelled")
+
+// IFileDialog/IFileOpenDialog/IShellItem/IShellItemArray vtable 索引
+const (
+	vtblRelease = 2
+
+	fileDialogShow             = 3
+	fileDialogSetFileTypes     = 4
+	fileDialogSetOptions       = 9
+	fileDialogGetOptions       = 10
+	fileDialogSetFolder        = 12
+	fileDialogSetFileName      = 15
+	fileDialogSetTitle         = 17
+	fileDialogSetOkButtonLabel = 18
+	fileDialogGetResult        = 20
+	fileDialogSetDefaultExt    = 22
+	fileOpenDialogGetResults   = 27
+
+	shellItemGetDisplayName = 5
+
+	shellItemArrayGetCount  = 7
+	shellItemArrayGetItemAt = 8
+)
+
+// FileDialogKind 文件对话框的类型
+type FileDialogKind int
+
+const (
+	FileDialogOpen FileDialogKind = iota
+	FileDialogOpenMultiple
+	FileDialogSave
+	FileDialogFolder
+)
+
+// FileFilter 对应 COMDLG_FILTERSPEC，Spec 形如 "*.png;*.jpg"
+type FileFilter struct {
+	Name string
+	Spec string
+}
+
+// FileDialogConfig 描述要显示的文件对话框
+type FileDialogConfig struct {
+	Kind             FileDialogKind
+	Title            string
+	ButtonLabel      string
+	Folder           string // 初始目录
+	FileName         string // 初始文件名
+	DefaultExtension string // 保存时自动补全的扩展名 (不含点)
+	Filters          []FileFilter
+}
+
+// comCall calls the method at the given vtable index on a COM object
+//
+//go:uintptrescapes
+func comCall(obj uintptr, method int, args ...uintptr) uintptr {
+	vtbl := *(*uintptr)(unsafe.Pointer(obj))
+	fn := *(*uintptr)(unsafe.Pointer(vtbl + uintptr(method)*unsafe.Sizeof(uintptr(0))))
+	a := make([]uintptr, 5)
+	copy(a, args)
+	r, _, _ := syscall.Syscall6(fn, uintptr(len(args)+1), obj, a[0], a[1], a[2], a[3], a[4])
+	return r
+}
+
+func comRelease(obj uintptr) {
+	if obj != 0 {
+		comCall(obj, vtblRelease)
+	}
+}
+
+func failed(hr uintptr) bool {
+	return hresult.FromUintptr(hr).Failed()
+}
+
+// ShowFileDialog shows a modal IFileOpenDialog/IFileSaveDialog owned by hwnd
+// and returns the chosen paths, or ErrDialogCancelled
+func ShowFileDialog(hwnd uintptr, config FileDialogConfig) ([]string, error) {
+	clsid, iid := &clsidFileOpenDialog, &iidIFileOpenDialog
+	if config.Kind == FileDialogSave {
+		clsid, iid = &clsidFileSaveDialog, &iidIFileSaveDialog
+	}
+	var dialog uintptr
+	hr, _, _ := Ole32CoCreateInstance.Call(
+		uintptr(unsafe.Pointer(clsid)),
+		0,
+		CLSCTX_INPROC_SERVER,
+		uintptr(unsafe.Pointer(iid)),
+		uintptr(unsafe.Pointer(&dialog)),
+	)
+	if failed(hr) {
+		return nil, fmt.Errorf("failed to create file dialog: %w", hresult.FromUintptr(hr))
+	}
+	defer comRelease(dialog)
+
+	var options uint32
+	comCall(dialog, fileDialogGetOptions, uintptr(unsafe.Pointer(&options)))
+	options |= FOS_FORCEFILESYSTEM | FOS_NOCHANGEDIR
+	switch config.Kind {
+	case FileDialogOpen:
+		options |= FOS_FILEMUSTEXIST | FOS_PATHMUSTEXIST
+	case FileDialogOpenMultiple:
+		options |= FOS_FILEMUSTEXIST | FOS_PATHMUSTEXIST | FOS_ALLOWMULTISELECT
+	case FileDialogSave:
+		options |= FOS_OVERWRITEPROMPT | FOS_PATHMUSTEXIST
+	case FileDialogFolder:
+		options |= FOS_PICKFOLDERS | FOS_PATHMUSTEXIST
+	}
+	comCall(dialog, fileDialogSetOptions, uintptr(options))
+
+	if config.Title != "" {
+		comCall(dialog, fileDialogSetTitle, uintptr(unsafe.Pointer(utf16Ptr(config.Title))))
+	}
+	if config.ButtonLabel != "" {
+		comCall(dialog, fileDialogSetOkButtonLabel, uintptr(unsafe.Pointer(utf16Ptr(config.ButtonLabel))))
+	}
+	if config.DefaultExtension != "" {
+		comCall(dialog, fileDialogSetDefaultExt, uintptr(unsafe.Pointer(utf16Ptr(config.DefaultExtension))))
+	}
+	if len(config.Filters) > 0 && config.Kind != FileDialogFolder {
+		specs := make([][2]*uint16, len(config.Filters))
+		for i, f := range config.Filters {
+			specs[i][0], _ = windows.UTF16PtrFromString(f.Name)
+			specs[i][1], _ = windows.UTF16PtrFromString(f.Spec)
+		}
+		comCall(dialog, fileDialogSetFileTypes, uintptr(len(specs)), uintptr(unsafe.Pointer(&specs[0])))
+	}
+	if config.Folder != "" {
+		if folder, err := shellItemFromPath(config.Folder); err == nil {
+			comCall(dialog, fileDialogSetFolder, folder)
+			comRelease(folder)
+		}
+	}
+	if config.FileName != "" {
+		comCall(dialog, fileDialogSetFileName, uintptr(unsafe.Pointer(utf16Ptr(config.FileName))))
+	}
+
+	hr = comCall(dialog, fileDialogShow, hwnd)
+	if hresult.FromUintptr(hr) == hresult.HRESULT_CANCELLED {
+		return nil, ErrDialogCancelled
+	}
+	if failed(hr) {
+		return nil, fmt.Errorf("failed to show file dialog: %w", hresult.FromUintptr(hr))
+	}
+
+	if config.Kind == FileDialogOpenMultiple {
+		return fileDialogResults(dialog)
+	}
+	var item uintptr
+	if hr := comCall(dialog, fileDialogGetResult, uintptr(unsafe.Pointer(&item))); failed(hr) {
+		return nil, fmt.Errorf("failed to get file dialog result: %w", hresult.FromUintptr(hr))
+	}
+	defer comRelease(item)
+	path, err := shellItemPath(item)
+	if err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+func fileDialogResults(dialog uintptr) ([]string, error) {
+	var items uintptr
+	if hr := comCall(dialog, fileOpenDialogGetResults, uintptr(unsafe.Pointer(&items))); failed(hr) {
+		return nil, fmt.Errorf("failed to get file dialog results: %w", hresult.FromUintptr(hr))
+	}
+	defer comRelease(items)
+
+	var count uint32
+	comCall(items, shellItemArrayGetCount, uintptr(unsafe.Pointer(&count)))
+	paths := make([]string, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var item uintptr
+		if hr := comCall(items, shellItemArrayGetItemAt, uintptr(i), uintptr(unsafe.Pointer(&item))); failed(hr) {
+			continue
+		}
+		path, err := shellItemPath(item)
+		comRelease(item)
+		if err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func shellItemFromPath(path string) (uintptr, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	var item uintptr
+	hr, _, _ := Shell32SHCreateItemFromParsingName.Call(
+		uintptr(unsafe.Pointer(utf16Ptr(abs))),
+		0,
+		uintptr(unsafe.Pointer(&iidIShellItem)),
+		uintptr(unsafe.Pointer(&item)),
+	)
+	if failed(hr) {
+		return 0, fmt.Errorf("failed to create shell item: %w", hresult.FromUintptr(hr))
+	}
+	return item, nil
+}
+
+func shellItemPath(item uintptr) (string, error) {
+	var name *uint16
+	if hr := comCall(item, shellItemGetDisplayName, SIGDN_FILESYSPATH, uintptr(unsafe.Pointer(&name))); failed(hr) {
+		return "", fmt.Errorf("failed to get file path: %w", hresult.FromUintptr(hr))
+	}
+	defer windows.CoTaskMemFree(unsafe.Pointer(name))
+	return windows.UTF16PtrToString(name), nil
+}
+
+func utf16Ptr(s string) *uint16 {
+	p, _ := windows.UTF16PtrFromString(s)
+	return p
+}
@@ -0,0 +1,131 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	gdi32                   = windows.NewLazySystemDLL("gdi32")
+	Gdi32CreateCompatibleDC = gdi32.NewProc("CreateCompatibleDC")
+	Gdi32CreateDIBSection   = gdi32.NewProc("CreateDIBSection")
+	Gdi32SelectObject       = gdi32.NewProc("SelectObject")
+	Gdi32DeleteDC           = gdi32.NewProc("DeleteDC")
+	Gdi32DeleteObject       = gdi32.NewProc("DeleteObject")
+
+	User32DrawIconEx  = user32.NewProc("DrawIconEx")
+	User32DestroyIcon = user32.NewProc("DestroyIcon")
+)
+
+const (
+	SM_CXSMICON = 49
+	SM_CYSMICON = 50
+
+	DI_NORMAL      = 0x0003
+	DIB_RGB_COLORS = 0
+)
+
+// BitmapInfoHeader 对应 Win32 BITMAPINFOHEADER 结构
+type BitmapInfoHeader struct {
+	BiSize          uint32
+	BiWidth         int32
+	BiHeight        int32
+	BiPlanes        uint16
+	BiBitCount      uint16
+	BiCompression   uint32
+	BiSizeImage     uint32
+	BiXPelsPerMeter int32
+	BiYPelsPerMeter int32
+	BiClrUsed       uint32
+	BiClrImportant  uint32
+}
+
+// iconImage returns the image data in an .ico file that best matches size,
+// or data itself when it is not an .ico file (e.g. PNG)
+func iconImage(data []byte, size int) []byte {
+	if len(data) < 6 || binary.LittleEndian.Uint16(data[0:]) != 0 || binary.LittleEndian.Uint16(data[2:]) != 1 {
+		return data
+	}
+	count := int(binary.LittleEndian.Uint16(data[4:]))
+	var best []byte
+	bestDiff := -1
+	for i := 0; i < count; i++ {
+		entry := 6 + i*16
+		if entry+16 > len(data) {
+			break
+		}
+		width := int(data[entry])
+		if width == 0 {
+			width = 256
+		}
+		length := int(binary.LittleEndian.Uint32(data[entry+8:]))
+		offset := int(binary.LittleEndian.Uint32(data[entry+12:]))
+		if offset < 0 || length <= 0 || offset+length > len(data) {
+			continue
+		}
+		diff := width - size
+		if diff < 0 {
+			// 优先选择不小于目标尺寸的图像
+			diff = 1000 - diff
+		}
+		if bestDiff < 0 || diff < bestDiff {
+			best = data[offset : offset+length]
+			bestDiff = diff
+		}
+	}
+	if best == nil {
+		return data
+	}
+	return best
+}
+
+// CreateIconFromBytes creates an icon of the given size from .ico or PNG data.
+// The caller owns the returned icon and should release it with DestroyIcon
+func CreateIconFromBytes(data []byte, size int) uintptr {
+	if len(data) == 0 {
+		return 0
+	}
+	image := iconImage(data, size)
+	icon, _, _ := User32CreateIconFromResourceEx.Call(
+		uintptr(unsafe.Pointer(&image[0])),
+		uintptr(len(image)),
+		1,          // fIcon
+		0x00030000, // 版本
+		uintptr(size),
+		uintptr(size),
+		0,
+	)
+	return icon
+}
+
+// CreateBitmapFromIcon renders an icon into a 32bpp premultiplied-alpha bitmap,
+// suitable for menu item images. The caller should release it with DeleteObject
+func CreateBitmapFromIcon(icon uintptr, size int) uintptr {
+	hdc, _, _ := Gdi32CreateCompatibleDC.Call(0)
+	if hdc == 0 {
+		return 0
+	}
+	defer Gdi32DeleteDC.Call(hdc)
+
+	header := BitmapInfoHeader{
+		BiWidth:    int32(size),
+		BiHeight:   -int32(size), // 自上而下
+		BiPlanes:   1,
+		BiBitCount: 32,
+	}
+	header.BiSize = uint32(unsafe.Sizeof(header))
+	var bits uintptr
+	bitmap, _, _ := Gdi32CreateDIBSection.Call(hdc, uintptr(unsafe.Pointer(&header)), DIB_RGB_COLORS, uintptr(unsafe.Pointer(&bits)), 0, 0)
+	if bitmap == 0 {
+		return 0
+	}
+	old, _, _ := Gdi32SelectObject.Call(hdc, bitmap)
+	_, _, _ = User32DrawIconEx.Call(hdc, 0, 0, icon, uintptr(size), uintptr(size), 0, 0, DI_NORMAL)
+	_, _, _ = Gdi32SelectObject.Call(hdc, old)
+	return bitmap
+}
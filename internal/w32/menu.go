@@ -0,0 +1,82 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	User32CreateMenu          = user32.NewProc("CreateMenu")
+	User32CreatePopupMenu     = user32.NewProc("CreatePopupMenu")
+	User32AppendMenuW         = user32.NewProc("AppendMenuW")
+	User32DestroyMenu         = user32.NewProc("DestroyMenu")
+	User32SetMenu             = user32.NewProc("SetMenu")
+	User32DrawMenuBar         = user32.NewProc("DrawMenuBar")
+	User32CheckMenuItem       = user32.NewProc("CheckMenuItem")
+	User32SetMenuItemInfoW    = user32.NewProc("SetMenuItemInfoW")
+	User32TrackPopupMenu      = user32.NewProc("TrackPopupMenu")
+	User32SetForegroundWindow = user32.NewProc("SetForegroundWindow")
+)
+
+const (
+	WMCommand = 0x0111
+)
+
+const (
+	MF_STRING      = 0x0000
+	MF_GRAYED      = 0x0001
+	MF_CHECKED     = 0x0008
+	MF_UNCHECKED   = 0x0000
+	MF_POPUP       = 0x0010
+	MF_SEPARATOR   = 0x0800
+	MF_BYCOMMAND   = 0x0000
+	MFT_RADIOCHECK = 0x0200
+
+	MIIM_FTYPE  = 0x0100
+	MIIM_BITMAP = 0x0080
+
+	TPM_RIGHTBUTTON = 0x0002
+	TPM_RETURNCMD   = 0x0100
+	TPM_NONOTIFY    = 0x0080
+)
+
+// MenuItemInfo 对应 Win32 MENUITEMINFOW 结构
+type MenuItemInfo struct {
+	CbSize        uint32
+	FMask         uint32
+	FType         uint32
+	FState        uint32
+	WID           uint32
+	HSubMenu      uintptr
+	HbmpChecked   uintptr
+	HbmpUnchecked uintptr
+	DwItemData    uintptr
+	DwTypeData    *uint16
+	Cch           uint32
+	HbmpItem      uintptr
+}
+
+// AppendMenu appends an item to a menu. For MF_POPUP items id is the submenu handle
+func AppendMenu(hmenu uintptr, flags uint32, id uintptr, label string) bool {
+	var text uintptr
+	if flags&MF_SEPARATOR == 0 {
+		p, err := windows.UTF16PtrFromString(label)
+		if err != nil {
+			return false
+		}
+		text = uintptr(unsafe.Pointer(p))
+	}
+	ret, _, _ := User32AppendMenuW.Call(hmenu, uintptr(flags), id, text)
+	return ret != 0
+}
+
+// SetMenuItemInfo updates a menu item identified by its command id
+func SetMenuItemInfo(hmenu uintptr, id uint32, info *MenuItemInfo) bool {
+	info.CbSize = uint32(unsafe.Sizeof(*info))
+	ret, _, _ := User32SetMenuItemInfoW.Call(hmenu, uintptr(id), 0, uintptr(unsafe.Pointer(info)))
+	return ret != 0
+}
@@ -0,0 +1,98 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	shell32                 = windows.NewLazySystemDLL("shell32")
+	Shell32ShellNotifyIconW = shell32.NewProc("Shell_NotifyIconW")
+
+	User32RegisterWindowMessageW = user32.NewProc("RegisterWindowMessageW")
+)
+
+const (
+	NIM_ADD        = 0x00000000
+	NIM_MODIFY     = 0x00000001
+	NIM_DELETE     = 0x00000002
+	NIM_SETVERSION = 0x00000004
+
+	NIF_MESSAGE = 0x00000001
+	NIF_ICON    = 0x00000002
+	NIF_TIP     = 0x00000004
+	NIF_INFO    = 0x00000010
+	NIF_SHOWTIP = 0x00000080
+
+	NIIF_NONE       = 0x00000000
+	NIIF_INFO       = 0x00000001
+	NIIF_WARNING    = 0x00000002
+	NIIF_ERROR      = 0x00000003
+	NIIF_USER       = 0x00000004
+	NIIF_NOSOUND    = 0x00000010
+	NIIF_LARGE_ICON = 0x00000020
+
+	NIN_BALLOONUSERCLICK = WMUser + 5
+)
+
+const (
+	WMNull          = 0x0000
+	WMUser          = 0x0400
+	WMLButtonUp     = 0x0202
+	WMLButtonDblClk = 0x0203
+	WMRButtonUp     = 0x0205
+	WMContextMenu   = 0x007B
+)
+
+// NotifyIconData 对应 Win32 NOTIFYICONDATAW 结构
+type NotifyIconData struct {
+	CbSize           uint32
+	HWnd             uintptr
+	UID              uint32
+	UFlags           uint32
+	UCallbackMessage uint32
+	HIcon            uintptr
+	SzTip            [128]uint16
+	DwState          uint32
+	DwStateMask      uint32
+	SzInfo           [256]uint16
+	UVersion         uint32
+	SzInfoTitle      [64]uint16
+	DwInfoFlags      uint32
+	GuidItem         windows.GUID
+	HBalloonIcon     uintptr
+}
+
+// ShellNotifyIcon sends a message to the taskbar's notification area
+func ShellNotifyIcon(message uint32, data *NotifyIconData) bool {
+	data.CbSize = uint32(unsafe.Sizeof(*data))
+	ret, _, _ := Shell32ShellNotifyIconW.Call(uintptr(message), uintptr(unsafe.Pointer(data)))
+	return ret != 0
+}
+
+// CopyUTF16 copies s into a fixed-size, null-terminated UTF-16 buffer, truncating if needed
+func CopyUTF16(dst []uint16, s string) {
+	src, err := windows.UTF16FromString(s)
+	if err != nil {
+		src = []uint16{0}
+	}
+	if len(src) > len(dst) {
+		src = src[:len(dst)]
+		src[len(src)-1] = 0
+	}
+	copy(dst, src)
+}
+
+// RegisterWindowMessage defines a new window message that is guaranteed to be unique throughout the system
+func RegisterWindowMessage(name string) uint32 {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0
+	}
+	ret, _, _ := User32RegisterWindowMessageW.Call(uintptr(unsafe.Pointer(p)))
+	return uint32(ret)
+}
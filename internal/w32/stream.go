@@ -0,0 +1,80 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/pkg/hresult"
+)
+
+var (
+	Ole32CreateStreamOnHGlobal = ole32.NewProc("CreateStreamOnHGlobal")
+	Ole32GetHGlobalFromStream  = ole32.NewProc("GetHGlobalFromStream")
+
+	Kernel32GlobalLock   = kernel32.NewProc("GlobalLock")
+	Kernel32GlobalUnlock = kernel32.NewProc("GlobalUnlock")
+)
+
+const (
+	streamStat = 12
+
+	STATFLAG_NONAME = 1
+)
+
+// statStg 对应 Win32 STATSTG 结构，这里只用到 CbSize
+type statStg struct {
+	PwcsName          uintptr
+	Type              uint32
+	CbSize            uint64
+	Mtime             [2]uint32
+	Ctime             [2]uint32
+	Atime             [2]uint32
+	GrfMode           uint32
+	GrfLocksSupported uint32
+	Clsid             [16]byte
+	GrfStateBits      uint32
+	Reserved          uint32
+}
+
+// NewMemoryStream creates an empty IStream backed by global memory, release it with ReleaseStream
+func NewMemoryStream() (uintptr, error) {
+	var stream uintptr
+	hr, _, _ := Ole32CreateStreamOnHGlobal.Call(0, 1, uintptr(unsafe.Pointer(&stream)))
+	if failed(hr) {
+		return 0, fmt.Errorf("CreateStreamOnHGlobal: %w", hresult.FromUintptr(hr))
+	}
+	return stream, nil
+}
+
+// StreamBytes copies the content of a stream created by NewMemoryStream
+func StreamBytes(stream uintptr) ([]byte, error) {
+	var stat statStg
+	if hr := comCall(stream, streamStat, uintptr(unsafe.Pointer(&stat)), STATFLAG_NONAME); failed(hr) {
+		return nil, fmt.Errorf("IStream.Stat: %w", hresult.FromUintptr(hr))
+	}
+	var hglobal uintptr
+	if hr, _, _ := Ole32GetHGlobalFromStream.Call(stream, uintptr(unsafe.Pointer(&hglobal))); failed(hr) {
+		return nil, fmt.Errorf("GetHGlobalFromStream: %w", hresult.FromUintptr(hr))
+	}
+	if stat.CbSize == 0 {
+		return []byte{}, nil
+	}
+
+	ptr, _, err := Kernel32GlobalLock.Call(hglobal)
+	if ptr == 0 {
+		return nil, fmt.Errorf("GlobalLock: %w", err)
+	}
+	defer Kernel32GlobalUnlock.Call(hglobal)
+
+	data := make([]byte, stat.CbSize)
+	copy(data, (*[1 << 30]byte)(unsafe.Pointer(ptr))[:stat.CbSize:stat.CbSize])
+	return data, nil
+}
+
+// ReleaseStream releases a stream created by NewMemoryStream
+func ReleaseStream(stream uintptr) {
+	comRelease(stream)
+}
@@ -0,0 +1,324 @@
+//go:build windows
+// +build windows
+
+package w32
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/pkg/hresult"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	User32MessageBoxW = user32.NewProc("MessageBoxW")
+
+	Kernel32CreateActCtxW      = kernel32.NewProc("CreateActCtxW")
+	Kernel32ActivateActCtx     = kernel32.NewProc("ActivateActCtx")
+	Kernel32DeactivateActCtx   = kernel32.NewProc("DeactivateActCtx")
+	comctl32                   = windows.NewLazySystemDLL("comctl32")
+	Comctl32TaskDialogIndirect = comctl32.NewProc("TaskDialogIndirect")
+)
+
+const (
+	MB_OK                = 0x00000000
+	MB_OKCANCEL          = 0x00000001
+	MB_ABORTRETRYIGNORE  = 0x00000002
+	MB_YESNOCANCEL       = 0x00000003
+	MB_YESNO             = 0x00000004
+	MB_RETRYCANCEL       = 0x00000005
+	MB_CANCELTRYCONTINUE = 0x00000006
+	MB_ICONERROR         = 0x00000010
+	MB_ICONQUESTION      = 0x00000020
+	MB_ICONWARNING       = 0x00000030
+	MB_ICONINFORMATION   = 0x00000040
+	MB_TASKMODAL         = 0x00002000
+	MB_SETFOREGROUND     = 0x00010000
+
+	IDOK       = 1
+	IDCANCEL   = 2
+	IDABORT    = 3
+	IDRETRY    = 4
+	IDIGNORE   = 5
+	IDYES      = 6
+	IDNO       = 7
+	IDCLOSE    = 8
+	IDTRYAGAIN = 10
+	IDCONTINUE = 11
+)
+
+const (
+	TDF_ENABLE_HYPERLINKS           = 0x00000001
+	TDF_ALLOW_DIALOG_CANCELLATION   = 0x00000008
+	TDF_USE_COMMAND_LINKS           = 0x00000010
+	TDF_VERIFICATION_FLAG_CHECKED   = 0x00000100
+	TDF_SHOW_PROGRESS_BAR           = 0x00000200
+	TDF_SHOW_MARQUEE_PROGRESS_BAR   = 0x00000400
+	TDF_POSITION_RELATIVE_TO_WINDOW = 0x00001000
+
+	TDCBF_OK_BUTTON     = 0x0001
+	TDCBF_YES_BUTTON    = 0x0002
+	TDCBF_NO_BUTTON     = 0x0004
+	TDCBF_CANCEL_BUTTON = 0x0008
+	TDCBF_RETRY_BUTTON  = 0x0010
+	TDCBF_CLOSE_BUTTON  = 0x0020
+
+	TD_WARNING_ICON     = 0xFFFF
+	TD_ERROR_ICON       = 0xFFFE
+	TD_INFORMATION_ICON = 0xFFFD
+	TD_SHIELD_ICON      = 0xFFFC
+
+	TDN_CREATED   = 0
+	TDN_DESTROYED = 5
+
+	TDM_CLICK_BUTTON             = WMUser + 102
+	TDM_SET_MARQUEE_PROGRESS_BAR = WMUser + 103
+	TDM_SET_PROGRESS_BAR_POS     = WMUser + 106
+	TDM_SET_PROGRESS_BAR_MARQUEE = WMUser + 107
+	TDM_SET_ELEMENT_TEXT         = WMUser + 108
+	TDM_ENABLE_BUTTON            = WMUser + 111
+	TDM_UPDATE_ELEMENT_TEXT      = WMUser + 114
+	TDE_CONTENT                  = 0
+	TDE_MAIN_INSTRUCTION         = 2
+)
+
+// MessageBox shows a modal MessageBoxW and returns the ID of the chosen button
+func MessageBox(hwnd uintptr, title, text string, flags uint32) int {
+	if hwnd == 0 {
+		// 没有父窗口时保证对话框显示在前台
+		flags |= MB_TASKMODAL | MB_SETFOREGROUND
+	}
+	r, _, _ := User32MessageBoxW.Call(
+		hwnd,
+		uintptr(unsafe.Pointer(utf16Ptr(text))),
+		uintptr(unsafe.Pointer(utf16Ptr(title))),
+		uintptr(flags),
+	)
+	return int(r)
+}
+
+// TaskDialogButton corresponds to TASKDIALOG_BUTTON
+type TaskDialogButton struct {
+	ID   int
+	Text string
+}
+
+// TaskDialogConfig describes a TASKDIALOGCONFIG
+type TaskDialogConfig struct {
+	Parent           uintptr
+	Flags            uint32
+	CommonButtons    uint32
+	Title            string
+	Icon             uint16 // TD_*_ICON
+	MainInstruction  string
+	Content          string
+	Buttons          []TaskDialogButton
+	DefaultButton    int
+	VerificationText string
+	ExpandedInfo     string
+	Footer           string
+
+	// Created 在对话框创建后调用，参数为对话框窗口句柄
+	Created func(hwnd uintptr)
+	// Destroyed 在对话框销毁时调用
+	Destroyed func()
+}
+
+// ErrTaskDialogUnavailable is returned when comctl32 v6 cannot be loaded
+var ErrTaskDialogUnavailable = errors.New("task dialogs require comctl32 version 6")
+
+// actCtx corresponds to ACTCTXW
+type actCtx struct {
+	CbSize                 uint32
+	DwFlags                uint32
+	LpSource               *uint16
+	WProcessorArchitecture uint16
+	WLangId                uint16
+	LpAssemblyDirectory    *uint16
+	LpResourceName         uintptr
+	LpApplicationName      *uint16
+	HModule                uintptr
+}
+
+const (
+	actCtxFlagAssemblyDirectoryValid = 0x004
+	actCtxFlagResourceNameValid      = 0x008
+)
+
+var (
+	commonControlsOnce sync.Once
+	commonControlsCtx  uintptr
+)
+
+// commonControlsContext 返回启用 comctl32 v6 的激活上下文。
+// 未嵌入清单的程序默认加载 v5，没有 TaskDialogIndirect，
+// 这里借用 shell32.dll 中 ID 为 124 的清单资源
+func commonControlsContext() uintptr {
+	commonControlsOnce.Do(func() {
+		system, err := windows.GetSystemDirectory()
+		if err != nil {
+			return
+		}
+		ctx := actCtx{
+			DwFlags:             actCtxFlagAssemblyDirectoryValid | actCtxFlagResourceNameValid,
+			LpSource:            utf16Ptr(filepath.Join(system, "shell32.dll")),
+			LpAssemblyDirectory: utf16Ptr(system),
+			LpResourceName:      124,
+		}
+		ctx.CbSize = uint32(unsafe.Sizeof(ctx))
+		h, _, _ := Kernel32CreateActCtxW.Call(uintptr(unsafe.Pointer(&ctx)))
+		if h != uintptr(windows.InvalidHandle) {
+			commonControlsCtx = h
+		}
+	})
+	return commonControlsCtx
+}
+
+var (
+	taskDialogCallbackOnce sync.Once
+	taskDialogCallback     uintptr
+	taskDialogs            sync.Map // refData -> *TaskDialogConfig
+	taskDialogNextID       uintptr
+	taskDialogIDLock       sync.Mutex
+)
+
+func taskDialogProc(hwnd, msg, wp, lp, refData uintptr) uintptr {
+	v, ok := taskDialogs.Load(refData)
+	if !ok {
+		return 0
+	}
+	config := v.(*TaskDialogConfig)
+	switch msg {
+	case TDN_CREATED:
+		if config.Created != nil {
+			config.Created(hwnd)
+		}
+	case TDN_DESTROYED:
+		if config.Destroyed != nil {
+			config.Destroyed()
+		}
+	}
+	return 0
+}
+
+// packer 按 1 字节对齐写入结构体，TASKDIALOGCONFIG 在 commctrl.h 中是紧凑排列的
+type packer struct {
+	buf []byte
+}
+
+func (p *packer) u32(v uint32) {
+	p.buf = append(p.buf, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(p.buf[len(p.buf)-4:], v)
+}
+
+func (p *packer) ptr(v uintptr) {
+	if unsafe.Sizeof(v) == 8 {
+		p.buf = append(p.buf, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint64(p.buf[len(p.buf)-8:], uint64(v))
+	} else {
+		p.u32(uint32(v))
+	}
+}
+
+// ShowTaskDialog shows a modal TaskDialogIndirect and returns the chosen
+// button ID and the state of the verification checkbox
+func ShowTaskDialog(config TaskDialogConfig) (button int, verified bool, err error) {
+	// 回调在调用线程上执行，对话框运行期间不能切换线程
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if ctx := commonControlsContext(); ctx != 0 {
+		var cookie uintptr
+		if r, _, _ := Kernel32ActivateActCtx.Call(ctx, uintptr(unsafe.Pointer(&cookie))); r != 0 {
+			defer Kernel32DeactivateActCtx.Call(0, cookie)
+		}
+	}
+	if err := Comctl32TaskDialogIndirect.Find(); err != nil {
+		return 0, false, ErrTaskDialogUnavailable
+	}
+
+	taskDialogCallbackOnce.Do(func() {
+		taskDialogCallback = windows.NewCallback(taskDialogProc)
+	})
+	taskDialogIDLock.Lock()
+	taskDialogNextID++
+	refData := taskDialogNextID
+	taskDialogIDLock.Unlock()
+	taskDialogs.Store(refData, &config)
+	defer taskDialogs.Delete(refData)
+
+	// 字符串在调用结束前必须保持可达
+	var keep []*uint16
+	str := func(s string) uintptr {
+		if s == "" {
+			return 0
+		}
+		p := utf16Ptr(s)
+		keep = append(keep, p)
+		return uintptr(unsafe.Pointer(p))
+	}
+
+	var buttons packer
+	for _, b := range config.Buttons {
+		buttons.u32(uint32(b.ID))
+		buttons.ptr(str(b.Text))
+	}
+	var buttonsPtr uintptr
+	if len(buttons.buf) > 0 {
+		buttonsPtr = uintptr(unsafe.Pointer(&buttons.buf[0]))
+	}
+
+	flags := config.Flags
+	if config.Parent != 0 {
+		flags |= TDF_POSITION_RELATIVE_TO_WINDOW
+	}
+	var p packer
+	p.u32(0) // cbSize，最后填写
+	p.ptr(config.Parent)
+	p.ptr(0) // hInstance
+	p.u32(flags)
+	p.u32(config.CommonButtons)
+	p.ptr(str(config.Title))
+	p.ptr(uintptr(config.Icon))
+	p.ptr(str(config.MainInstruction))
+	p.ptr(str(config.Content))
+	p.u32(uint32(len(config.Buttons)))
+	p.ptr(buttonsPtr)
+	p.u32(uint32(config.DefaultButton))
+	p.u32(0) // cRadioButtons
+	p.ptr(0) // pRadioButtons
+	p.u32(0) // nDefaultRadioButton
+	p.ptr(str(config.VerificationText))
+	p.ptr(str(config.ExpandedInfo))
+	p.ptr(0) // pszExpandedControlText
+	p.ptr(0) // pszCollapsedControlText
+	p.ptr(0) // hFooterIcon
+	p.ptr(str(config.Footer))
+	p.ptr(taskDialogCallback)
+	p.ptr(refData)
+	p.u32(0) // cxWidth
+	binary.LittleEndian.PutUint32(p.buf, uint32(len(p.buf)))
+
+	var (
+		pressed int32
+		checked int32
+	)
+	hr, _, _ := Comctl32TaskDialogIndirect.Call(
+		uintptr(unsafe.Pointer(&p.buf[0])),
+		uintptr(unsafe.Pointer(&pressed)),
+		0,
+		uintptr(unsafe.Pointer(&checked)),
+	)
+	runtime.KeepAlive(keep)
+	runtime.KeepAlive(buttons.buf)
+	if failed(hr) {
+		return 0, false, fmt.Errorf("TaskDialogIndirect failed: %w", hresult.FromUintptr(hr))
+	}
+	return int(pressed), checked != 0, nil
+}
@@ -4,6 +4,7 @@
 package w32
 
 import (
+	"sync"
 	"unicode/utf16"
 	"unsafe"
 
@@ -18,6 +19,14 @@ var (
 	kernel32                   = windows.NewLazySystemDLL("kernel32")
 	Kernel32GetCurrentThreadID = kernel32.NewProc("GetCurrentThreadId")
 
+	dwmapi                          = windows.NewLazySystemDLL("dwmapi")
+	DwmExtendFrameIntoClientArea    = dwmapi.NewProc("DwmExtendFrameIntoClientArea")
+	DwmSetWindowAttribute           = dwmapi.NewProc("DwmSetWindowAttribute")
+
+	shcore                 = windows.NewLazySystemDLL("shcore")
+	ShcoreGetDpiForMonitor = shcore.NewProc("GetDpiForMonitor")
+	ShcoreSetProcessDpiAwareness = shcore.NewProc("SetProcessDpiAwareness")
+
 	shlwapi                  = windows.NewLazySystemDLL("shlwapi")
 	shlwapiSHCreateMemStream = shlwapi.NewProc("SHCreateMemStream")
 
@@ -53,11 +62,38 @@ var (
 	User32SetLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
 	User32CreateIconFromResourceEx = user32.NewProc("CreateIconFromResourceEx")
 	User32ReleaseCapture    = user32.NewProc("ReleaseCapture")
+	User32GetKeyState       = user32.NewProc("GetKeyState")
+	User32GetWindowPlacement = user32.NewProc("GetWindowPlacement")
+	User32SetWindowPlacement = user32.NewProc("SetWindowPlacement")
+	User32MonitorFromWindow  = user32.NewProc("MonitorFromWindow")
+	User32MonitorFromRect    = user32.NewProc("MonitorFromRect")
+	User32GetMonitorInfoW    = user32.NewProc("GetMonitorInfoW")
+	User32EnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+	User32GetDpiForWindow    = user32.NewProc("GetDpiForWindow")
+	User32SetProcessDpiAwarenessContext = user32.NewProc("SetProcessDpiAwarenessContext")
+	User32SetProcessDPIAware = user32.NewProc("SetProcessDPIAware")
+	User32AdjustWindowRectExForDpi = user32.NewProc("AdjustWindowRectExForDpi")
+	User32GetSystemMetricsForDpi = user32.NewProc("GetSystemMetricsForDpi")
+	User32IsZoomed           = user32.NewProc("IsZoomed")
+	User32IsIconic           = user32.NewProc("IsIconic")
+	User32ScreenToClient     = user32.NewProc("ScreenToClient")
+	User32GetCursorPos       = user32.NewProc("GetCursorPos")
+	User32GetWindow          = user32.NewProc("GetWindow")
+	User32SetWindowRgn       = user32.NewProc("SetWindowRgn")
+	Gdi32CreateRectRgn       = gdi32.NewProc("CreateRectRgn")
+	Gdi32CombineRgn          = gdi32.NewProc("CombineRgn")
+	Gdi32CreateSolidBrush    = gdi32.NewProc("CreateSolidBrush")
+	User32BeginPaint         = user32.NewProc("BeginPaint")
+	User32EndPaint           = user32.NewProc("EndPaint")
+	User32FillRect           = user32.NewProc("FillRect")
 )
 
 const (
-	SM_CXSCREEN = 0
-	SM_CYSCREEN = 1
+	SM_CXSCREEN       = 0
+	SM_CYSCREEN       = 1
+	SM_CXFRAME        = 32
+	SM_CYFRAME        = 33
+	SM_CXPADDEDBORDER = 92
 )
 
 const (
@@ -107,11 +143,15 @@ const (
 	WMMove          = 0x0003
 	WMSize          = 0x0005
 	WMActivate      = 0x0006
+	WMPaint         = 0x000F
 	WMClose         = 0x0010
 	WMQuit          = 0x0012
 	WMGetMinMaxInfo = 0x0024
+	WMNCCalcSize    = 0x0083
 	WMNCLButtonDown = 0x00A1
+	WMNCLButtonUp   = 0x00A2
 	WMMoving        = 0x0216
+	WMDpiChanged    = 0x02E0
 	WMApp           = 0x8000
 )
 
@@ -144,7 +184,10 @@ const (
 )
 
 const (
+	HTClient      = 1
 	HTCaption     = 2
+	HTMinButton   = 8
+	HTMaxButton   = 9
 	HTLeft        = 10
 	HTRight       = 11
 	HTTop         = 12
@@ -153,6 +196,27 @@ const (
 	HTBottom      = 15
 	HTBottomLeft  = 16
 	HTBottomRight = 17
+	HTClose       = 20
+)
+
+const (
+	SIZE_RESTORED  = 0
+	SIZE_MINIMIZED = 1
+	SIZE_MAXIMIZED = 2
+)
+
+const (
+	VK_LWIN = 0x5B
+	VK_RWIN = 0x5C
+)
+
+const (
+	GW_HWNDNEXT = 2
+	GW_CHILD    = 5
+)
+
+const (
+	RGN_DIFF = 4
 )
 
 const (
@@ -188,12 +252,44 @@ const (
 	VK_F10    = 0x79
 	VK_F11    = 0x7A
 	VK_F12    = 0x7B
+
+	VK_SHIFT    = 0x10
+	VK_CONTROL  = 0x11
+	VK_MENU     = 0x12
+	VK_NUMPAD0  = 0x60
+	VK_ADD      = 0x6B
+	VK_SUBTRACT = 0x6D
+	VK_OEM_PLUS  = 0xBB
+	VK_OEM_MINUS = 0xBD
 )
 
 const (
 	SPI_GETWORKAREA = 0x0030
 )
 
+const (
+	MONITOR_DEFAULTTONULL    = 0
+	MONITOR_DEFAULTTOPRIMARY = 1
+	MONITOR_DEFAULTTONEAREST = 2
+
+	MONITORINFOF_PRIMARY = 1
+
+	MDT_EFFECTIVE_DPI = 0
+
+	USER_DEFAULT_SCREEN_DPI = 96
+
+	PROCESS_PER_MONITOR_DPI_AWARE = 2
+)
+
+// DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2 是 ((DPI_AWARENESS_CONTEXT)-4)
+const DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2 = ^uintptr(3)
+
+const (
+	SW_SHOWNORMAL    = 1
+	SW_SHOWMINIMIZED = 2
+	SW_SHOWMAXIMIZED = 3
+)
+
 const (
 	GWL_EXSTYLE = -20
 	WS_EX_LAYERED = 0x00080000
@@ -205,6 +301,19 @@ const (
 	IMAGE_ICON = 1
 )
 
+const (
+	DWMWA_USE_IMMERSIVE_DARK_MODE = 20
+	DWMWA_SYSTEMBACKDROP_TYPE     = 38
+)
+
+// Margins 对应 Win32 MARGINS 结构
+type Margins struct {
+	CxLeftWidth    int32
+	CxRightWidth   int32
+	CyTopHeight    int32
+	CyBottomHeight int32
+}
+
 type WndClassExW struct {
 	CbSize        uint32
 	Style         uint32
@@ -227,6 +336,22 @@ type Rect struct {
 	Bottom int32
 }
 
+// PaintStruct is PAINTSTRUCT, filled by BeginPaint
+type PaintStruct struct {
+	Hdc         uintptr
+	FErase      int32
+	RcPaint     Rect
+	FRestore    int32
+	FIncUpdate  int32
+	RgbReserved [32]byte
+}
+
+// NCCalcSizeParams is NCCALCSIZE_PARAMS, the lParam of WM_NCCALCSIZE when wParam is TRUE
+type NCCalcSizeParams struct {
+	Rgrc  [3]Rect
+	Lppos uintptr
+}
+
 type MinMaxInfo struct {
 	PtReserved     Point
 	PtMaxSize      Point
@@ -239,6 +364,25 @@ type Point struct {
 	X, Y int32
 }
 
+// WindowPlacement 对应 Win32 WINDOWPLACEMENT 结构
+type WindowPlacement struct {
+	Length           uint32
+	Flags            uint32
+	ShowCmd          uint32
+	PtMinPosition    Point
+	PtMaxPosition    Point
+	RcNormalPosition Rect
+}
+
+// MonitorInfoEx 对应 Win32 MONITORINFOEXW 结构
+type MonitorInfoEx struct {
+	CbSize    uint32
+	RcMonitor Rect
+	RcWork    Rect
+	DwFlags   uint32
+	SzDevice  [32]uint16
+}
+
 type Msg struct {
 	Hwnd     windows.Handle
 	Message  uint32
@@ -315,6 +459,135 @@ func SystemParametersInfo(uiAction uint32, uiParam uint32, pvParam unsafe.Pointe
 	return ret != 0
 }
 
+// IsKeyDown reports whether the given virtual key is currently pressed
+func IsKeyDown(vk int) bool {
+	ret, _, _ := User32GetKeyState.Call(uintptr(vk))
+	return int16(ret) < 0
+}
+
+// GetMonitorInfo retrieves information about a display monitor
+func GetMonitorInfo(hmonitor uintptr) (MonitorInfoEx, bool) {
+	info := MonitorInfoEx{}
+	info.CbSize = uint32(unsafe.Sizeof(info))
+	ret, _, _ := User32GetMonitorInfoW.Call(hmonitor, uintptr(unsafe.Pointer(&info)))
+	return info, ret != 0
+}
+
+var (
+	enumMonitorsMu       sync.Mutex
+	enumMonitorsResult   []uintptr
+	enumMonitorsCallback = windows.NewCallback(func(hmonitor, hdc, rect, data uintptr) uintptr {
+		enumMonitorsResult = append(enumMonitorsResult, hmonitor)
+		return 1
+	})
+)
+
+// EnumDisplayMonitors returns the handles of all display monitors
+func EnumDisplayMonitors() []uintptr {
+	enumMonitorsMu.Lock()
+	defer enumMonitorsMu.Unlock()
+	enumMonitorsResult = nil
+	_, _, _ = User32EnumDisplayMonitors.Call(0, 0, enumMonitorsCallback, 0)
+	monitors := enumMonitorsResult
+	enumMonitorsResult = nil
+	return monitors
+}
+
+// GetDpiForMonitor returns the effective DPI of a monitor, or 96 when unavailable
+func GetDpiForMonitor(hmonitor uintptr) uint32 {
+	if ShcoreGetDpiForMonitor.Find() != nil {
+		return USER_DEFAULT_SCREEN_DPI
+	}
+	var dpiX, dpiY uint32
+	hr, _, _ := ShcoreGetDpiForMonitor.Call(
+		hmonitor,
+		MDT_EFFECTIVE_DPI,
+		uintptr(unsafe.Pointer(&dpiX)),
+		uintptr(unsafe.Pointer(&dpiY)),
+	)
+	if int32(hr) < 0 || dpiX == 0 {
+		return USER_DEFAULT_SCREEN_DPI
+	}
+	return dpiX
+}
+
+// GetDpiForWindow returns the DPI of a window, or 96 when unavailable
+func GetDpiForWindow(hwnd uintptr) uint32 {
+	if User32GetDpiForWindow.Find() != nil {
+		monitor, _, _ := User32MonitorFromWindow.Call(hwnd, MONITOR_DEFAULTTONEAREST)
+		return GetDpiForMonitor(monitor)
+	}
+	dpi, _, _ := User32GetDpiForWindow.Call(hwnd)
+	if dpi == 0 {
+		return USER_DEFAULT_SCREEN_DPI
+	}
+	return uint32(dpi)
+}
+
+// EnablePerMonitorDpiAwareness declares the process as per-monitor (v2) DPI aware,
+// falling back to older APIs on earlier Windows versions
+func EnablePerMonitorDpiAwareness() bool {
+	if User32SetProcessDpiAwarenessContext.Find() == nil {
+		if r, _, _ := User32SetProcessDpiAwarenessContext.Call(DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2); r != 0 {
+			return true
+		}
+	}
+	if ShcoreSetProcessDpiAwareness.Find() == nil {
+		if hr, _, _ := ShcoreSetProcessDpiAwareness.Call(PROCESS_PER_MONITOR_DPI_AWARE); int32(hr) >= 0 {
+			return true
+		}
+	}
+	if User32SetProcessDPIAware.Find() == nil {
+		r, _, _ := User32SetProcessDPIAware.Call()
+		return r != 0
+	}
+	return false
+}
+
+// AdjustWindowRectForDpi calculates the window rectangle for the desired client rectangle at the given DPI
+func AdjustWindowRectForDpi(rect *Rect, style uint32, exStyle uint32, dpi uint32) {
+	if User32AdjustWindowRectExForDpi.Find() == nil {
+		_, _, _ = User32AdjustWindowRectExForDpi.Call(
+			uintptr(unsafe.Pointer(rect)),
+			uintptr(style),
+			0,
+			uintptr(exStyle),
+			uintptr(dpi),
+		)
+		return
+	}
+	_, _, _ = User32AdjustWindowRect.Call(uintptr(unsafe.Pointer(rect)), uintptr(style), 0)
+}
+
+// IsIconic reports whether the window is minimized
+func IsIconic(hwnd uintptr) bool {
+	ret, _, _ := User32IsIconic.Call(hwnd)
+	return ret != 0
+}
+
+// IsZoomed reports whether the window is maximized
+func IsZoomed(hwnd uintptr) bool {
+	ret, _, _ := User32IsZoomed.Call(hwnd)
+	return ret != 0
+}
+
+// GetSystemMetricsForDpi returns a system metric scaled for the given DPI
+func GetSystemMetricsForDpi(index int, dpi uint32) int32 {
+	if User32GetSystemMetricsForDpi.Find() == nil {
+		ret, _, _ := User32GetSystemMetricsForDpi.Call(uintptr(index), uintptr(dpi))
+		return int32(ret)
+	}
+	ret, _, _ := User32GetSystemMetrics.Call(uintptr(index))
+	return int32(ret)
+}
+
+// GetCursorPos returns the cursor position in screen coordinates
+func GetCursorPos() (Point, bool) {
+	var pt Point
+	ret, _, _ := User32GetCursorPos.Call(uintptr(unsafe.Pointer(&pt)))
+	return pt, ret != 0
+}
+
 // ReleaseCapture releases the mouse capture from a window
 func ReleaseCapture() bool {
 	ret, _, _ := User32ReleaseCapture.Call()
@@ -0,0 +1,351 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/yuaotian/go-win-webview2/pkg/tracing"
+)
+
+// JSHookType 定义 Hook 的类型
+type JSHookType int
+
+const (
+	JSHookBefore JSHookType = iota // JS 执行前
+	JSHookAfter                    // JS 执行后
+)
+
+// JSHook 定义 JavaScript 钩子接口
+type JSHook interface {
+	Type() JSHookType            // 获取 Hook 类型
+	Handle(script string) string // 处理脚本
+	Priority() int               // Hook 优先级，数字越小优先级越高
+}
+
+// ScriptHook 是可以读取上下文并拒绝脚本的钩子，实现了该接口的钩子使用 HandleScript 代替 Handle。
+// Before 钩子返回修改后的脚本，返回错误时脚本不会执行，之后的 Before 钩子也不再调用；
+// After 钩子可从 ctx.Result 和 ctx.Err 读取执行结果，返回值被忽略
+type ScriptHook interface {
+	JSHook
+	HandleScript(ctx *ScriptContext, script string) (string, error)
+}
+
+// ScriptSource 表示脚本的来源
+type ScriptSource int
+
+const (
+	ScriptEval     ScriptSource = iota // Eval
+	ScriptInit                         // Init
+	ScriptBind                         // Bind 生成的粘合代码：注册函数和返回调用结果
+	ScriptRemote                       // 远程控制协议的 remote.eval 和 WebSocket 的 eval 消息
+	ScriptInternal                     // 库内部的脚本，如 WebSocket 客户端、Reload 和 ClearCache
+)
+
+func (s ScriptSource) String() string {
+	switch s {
+	case ScriptEval:
+		return "eval"
+	case ScriptInit:
+		return "init"
+	case ScriptBind:
+		return "bind"
+	case ScriptRemote:
+		return "remote"
+	case ScriptInternal:
+		return "internal"
+	}
+	return fmt.Sprintf("ScriptSource(%d)", int(s))
+}
+
+// ScriptContext 是钩子处理脚本时的上下文
+type ScriptContext struct {
+	Source  ScriptSource
+	URL     string // 执行脚本的页面 URL，Init 脚本为注册时的页面，之后加载的页面也会执行
+	Caller  string // 调用 Eval、Init 或 Bind 的位置 (file:line)，内部生成的脚本为空
+	Binding string // Source 为 ScriptBind 时的函数名
+
+	// 以下字段仅在 After 钩子中有效。Init 脚本在之后的页面中执行，没有结果
+	Result json.RawMessage // 脚本最后一个表达式的值，无法序列化时为 null
+	Err    error           // 被拒绝 (*ScriptRejectedError)、抛出异常 (*EvalError) 或执行失败的错误
+}
+
+// ScriptRejectedError 表示脚本被 Before 钩子拒绝
+type ScriptRejectedError struct {
+	Source ScriptSource
+	Caller string
+	Err    error
+}
+
+func (e *ScriptRejectedError) Error() string {
+	if e.Caller != "" {
+		return fmt.Sprintf("%s script from %s rejected: %v", e.Source, e.Caller, e.Err)
+	}
+	return fmt.Sprintf("%s script rejected: %v", e.Source, e.Err)
+}
+
+func (e *ScriptRejectedError) Unwrap() error {
+	return e.Err
+}
+
+// BaseJSHook 提供基本的 JSHook 实现，设置了 ScriptHandler 时优先使用 ScriptHandler
+type BaseJSHook struct {
+	HookType      JSHookType
+	Handler       func(script string) string
+	ScriptHandler func(ctx *ScriptContext, script string) (string, error)
+	HookPriority  int
+}
+
+func (h *BaseJSHook) Type() JSHookType {
+	return h.HookType
+}
+
+func (h *BaseJSHook) Handle(script string) string {
+	if h.Handler != nil {
+		return h.Handler(script)
+	}
+	return script
+}
+
+func (h *BaseJSHook) HandleScript(ctx *ScriptContext, script string) (string, error) {
+	if h.ScriptHandler != nil {
+		return h.ScriptHandler(ctx, script)
+	}
+	return h.Handle(script), nil
+}
+
+func (h *BaseJSHook) Priority() int {
+	return h.HookPriority
+}
+
+// AddJSHook 添加 JavaScript 钩子
+func (w *webview) AddJSHook(hook JSHook) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	// 按优先级插入
+	inserted := false
+	for i, h := range w.jsHooks {
+		if hook.Priority() < h.Priority() {
+			// 在此位置插入
+			w.jsHooks = append(w.jsHooks[:i], append([]JSHook{hook}, w.jsHooks[i:]...)...)
+			inserted = true
+			break
+		}
+	}
+	if !inserted {
+		w.jsHooks = append(w.jsHooks, hook)
+	}
+}
+
+// RemoveJSHook 移除 JavaScript 钩子
+func (w *webview) RemoveJSHook(hook JSHook) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for i, h := range w.jsHooks {
+		if h == hook {
+			w.jsHooks = append(w.jsHooks[:i], w.jsHooks[i+1:]...)
+			break
+		}
+	}
+}
+
+// ClearJSHooks 清除所有 JavaScript 钩子
+func (w *webview) ClearJSHooks() {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.jsHooks = nil
+}
+
+// hooks 返回指定类型的钩子，调用钩子时不持有锁，钩子中可以调用 webview 的方法
+func (w *webview) hooks(hookType JSHookType) []JSHook {
+	w.m.Lock()
+	defer w.m.Unlock()
+	var hooks []JSHook
+	for _, hook := range w.jsHooks {
+		if hook.Type() == hookType {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// runBeforeHooks 依次调用 Before 钩子，返回最终的脚本或 *ScriptRejectedError
+func (w *webview) runBeforeHooks(ctx *ScriptContext, script string) (string, error) {
+	for _, hook := range w.hooks(JSHookBefore) {
+		sh, ok := hook.(ScriptHook)
+		if !ok {
+			script = hook.Handle(script)
+			continue
+		}
+		result, err := sh.HandleScript(ctx, script)
+		if err != nil {
+			return "", &ScriptRejectedError{Source: ctx.Source, Caller: ctx.Caller, Err: err}
+		}
+		script = result
+	}
+	return script, nil
+}
+
+// runAfterHooks 依次调用 After 钩子
+func (w *webview) runAfterHooks(ctx *ScriptContext, script string) {
+	for _, hook := range w.hooks(JSHookAfter) {
+		if sh, ok := hook.(ScriptHook); ok {
+			_, _ = sh.HandleScript(ctx, script)
+		} else {
+			hook.Handle(script)
+		}
+	}
+}
+
+// callerLocation 返回调用栈上第 skip 层的位置，用于 ScriptContext.Caller
+func callerLocation(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// evalScript 经过钩子后在主线程执行脚本，存在 After 钩子时等待执行结果并交给 After 钩子
+func (w *webview) evalScript(sctx ScriptContext, js string) {
+	w.Dispatch(func() {
+		start := time.Now()
+		ctx := sctx
+		ctx.URL = w.browser.Source()
+		script, err := w.runBeforeHooks(&ctx, js)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			ctx.Err = err
+			w.runAfterHooks(&ctx, js)
+			w.traceScript(tracing.KindEval, &ctx, js, start)
+			return
+		}
+
+		if len(w.hooks(JSHookAfter)) == 0 {
+			if w.observer() == nil {
+				if err := w.browser.Eval(script); err != nil {
+					log.Printf("Warning: Failed to evaluate script: %v", err)
+				}
+				return
+			}
+			// 只有跟踪时不改变脚本，等待执行完成以记录持续时间
+			err = w.browser.ExecuteScript(script, func(_ string, err error) {
+				ctx.Err = err
+				w.traceScript(tracing.KindEval, &ctx, script, start)
+			})
+			if err != nil {
+				ctx.Err = err
+				w.traceScript(tracing.KindEval, &ctx, script, start)
+			}
+			return
+		}
+		w.evals.Lock()
+		w.evals.seq++
+		id := w.evals.seq
+		w.evals.Unlock()
+		finish := func(result string, err error) {
+			ctx.Result, ctx.Err = parseScriptResult(result, err)
+			w.runAfterHooks(&ctx, script)
+			w.traceScript(tracing.KindEval, &ctx, script, start)
+		}
+		// 记录按 id 区分，连续执行的多个脚本和绑定函数的返回不会互相影响
+		if err := w.browser.Eval(scriptPendingMark(id)); err != nil {
+			log.Printf("Warning: Failed to evaluate script: %v", err)
+		}
+		err = w.browser.ExecuteScript(capturingScript(id, script), func(result string, err error) {
+			if err != nil {
+				finish(result, err)
+				return
+			}
+			// 脚本抛出异常时 ExecuteScript 同样返回 null，再检查脚本是否执行到了末尾
+			if err := w.browser.ExecuteScript(scriptDoneProbe(id), func(done string, err error) {
+				if err == nil && done == "false" {
+					err = &EvalError{Message: "uncaught exception or syntax error, see the DevTools console"}
+				}
+				finish(result, err)
+			}); err != nil {
+				finish(result, err)
+			}
+		})
+		if err != nil {
+			ctx.Err = err
+			w.runAfterHooks(&ctx, script)
+			w.traceScript(tracing.KindEval, &ctx, script, start)
+		}
+	})
+}
+
+// evalInternal 以 ScriptInternal 为来源执行库内部的脚本
+func (w *webview) evalInternal(js string) {
+	w.evalScript(ScriptContext{Source: ScriptInternal}, js)
+}
+
+// initInternal 以 ScriptInternal 为来源注册库内部的 Init 脚本
+func (w *webview) initInternal(js string) {
+	w.initScript(ScriptContext{Source: ScriptInternal}, js)
+}
+
+// initScript 经过钩子后注册在每个页面加载前执行的脚本
+func (w *webview) initScript(sctx ScriptContext, js string) {
+	w.runOnMain(func() {
+		start := time.Now()
+		ctx := sctx
+		ctx.URL = w.browser.Source()
+		script, err := w.runBeforeHooks(&ctx, js)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			ctx.Err = err
+			w.runAfterHooks(&ctx, js)
+			w.traceScript(tracing.KindInit, &ctx, js, start)
+			return
+		}
+
+		if err := w.browser.Init(initBaseScript + ";" + script); err != nil {
+			log.Printf("Warning: Failed to add init script: %v", err)
+			ctx.Err = err
+		}
+		w.runAfterHooks(&ctx, script)
+		w.traceScript(tracing.KindInit, &ctx, script, start)
+	})
+}
+
+// scriptDoneKey 是记录脚本是否执行到末尾的全局对象，键为脚本的 id
+const scriptDoneKey = "__webview2ScriptDone"
+
+// scriptPendingMark 返回在脚本之前执行的脚本，将 id 记录为未完成
+func scriptPendingMark(id uint64) string {
+	return fmt.Sprintf("(window.%[1]s = window.%[1]s || {})[%[2]d] = false;", scriptDoneKey, id)
+}
+
+// capturingScript 在脚本末尾追加一条 var 声明，在初始化时将 id 记录为已完成。
+// 脚本不做包装，仍作为顶层脚本执行，顶层的 let、const 和 class 声明在之后的脚本中可见；
+// var 语句的完成值为空，ExecuteScript 的结果仍是脚本最后一个表达式的值
+func capturingScript(id uint64, script string) string {
+	return fmt.Sprintf("%[1]s\n;var %[2]s = (function(d) { if (d) d[%[3]d] = true; return d; })(window.%[2]s);",
+		script, scriptDoneKey, id)
+}
+
+// scriptDoneProbe 返回检查 capturingScript 是否执行到末尾的脚本，并删除 id 的记录。
+// 结果为 true 或 false；没有记录时 (期间发生了导航) 为 null，此时无法判断
+func scriptDoneProbe(id uint64) string {
+	return fmt.Sprintf("(function(d) { if (!d || !(%[2]d in d)) return null; var done = d[%[2]d]; delete d[%[2]d]; return done; })(window.%[1]s)",
+		scriptDoneKey, id)
+}
+
+// parseScriptResult 将 ExecuteScript 的执行结果转换为 JSON 结果
+func parseScriptResult(result string, err error) (json.RawMessage, error) {
+	if err != nil {
+		return nil, err
+	}
+	if result == "" {
+		result = "null"
+	}
+	return json.RawMessage(result), nil
+}
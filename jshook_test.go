@@ -0,0 +1,85 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"encoding/json"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scriptStep 是在页面中依次执行的一段脚本，reset 为 true 时模拟导航到新页面
+type scriptStep struct {
+	Code  string `json:"code"`
+	Reset bool   `json:"reset"`
+}
+
+// runScripts 在 Node.js 的 vm 中依次执行脚本，返回每段脚本的完成值，抛出异常时为 "error"
+func runScripts(t *testing.T, steps []scriptStep) []interface{} {
+	t.Helper()
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	const runner = `
+const vm = require('vm');
+const newPage = () => { const c = vm.createContext({}); vm.runInContext('var window = globalThis;', c); return c; };
+let page = newPage();
+const steps = JSON.parse(require('fs').readFileSync(0, 'utf8'));
+console.log(JSON.stringify(steps.map(s => {
+	if (s.reset) { page = newPage(); return null; }
+	try { const v = vm.runInContext(s.code, page); return v === undefined ? null : v; } catch (e) { return 'error'; }
+})));`
+	input, _ := json.Marshal(steps)
+	cmd := exec.Command(node, "-e", runner)
+	cmd.Stdin = strings.NewReader(string(input))
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("node: %v", err)
+	}
+	var results []interface{}
+	if err := json.Unmarshal(out, &results); err != nil {
+		t.Fatalf("node output %q: %v", out, err)
+	}
+	return results
+}
+
+func TestScriptDoneProbeInterleaved(t *testing.T) {
+	// 两个脚本和一个绑定函数的返回连续执行，之后才执行各自的检查
+	steps := []scriptStep{
+		{Code: scriptPendingMark(1)},
+		{Code: capturingScript(1, "let a = 1; a + 1")},
+		{Code: scriptPendingMark(2)},
+		{Code: capturingScript(2, "throw new Error('b')")},
+		{Code: scriptPendingMark(3)},
+		{Code: capturingScript(3, "var = ;")},
+		{Code: scriptPendingMark(4)},
+		{Code: capturingScript(4, "a * 10")},
+		{Code: scriptDoneProbe(1)},
+		{Code: scriptDoneProbe(2)},
+		{Code: scriptDoneProbe(3)},
+		{Code: scriptDoneProbe(4)},
+		// 检查后记录被删除
+		{Code: scriptDoneProbe(1)},
+		// 脚本和检查之间发生导航
+		{Code: scriptPendingMark(5)},
+		{Code: capturingScript(5, "'ok'")},
+		{Reset: true},
+		{Code: scriptDoneProbe(5)},
+	}
+	want := []interface{}{
+		false, 2.0,
+		false, "error",
+		false, "error",
+		false, 10.0,
+		true, false, false, true,
+		nil,
+		false, "ok", nil, nil,
+	}
+	if got := runScripts(t, steps); !reflect.DeepEqual(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,656 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+)
+
+// MenuItemKind 菜单项类型
+type MenuItemKind int
+
+const (
+	MenuItemNormal    MenuItemKind = iota // 普通菜单项
+	MenuItemCheckbox                      // 复选框，点击时切换 Checked
+	MenuItemRadio                         // 单选项，点击时选中并取消同一菜单中其它单选项
+	MenuItemSeparator                     // 分隔线
+	MenuItemSubmenu                       // 子菜单
+)
+
+// MenuItem 描述一个菜单项，可用于原生菜单栏和 WebView 右键菜单
+type MenuItem struct {
+	Label       string               // 显示文本，& 用于标记助记符
+	Kind        MenuItemKind         // 类型，设置了 Submenu 时视为子菜单
+	Accelerator string               // 快捷键，如 "Ctrl+Shift+S"，仅在原生菜单中显示并响应
+	Icon        []byte               // 图标 (PNG 或 ICO 数据)
+	Checked     bool                 // 复选框/单选项是否选中
+	Disabled    bool                 // 是否禁用
+	Submenu     *Menu                // 子菜单
+	OnClick     func(item *MenuItem) // 点击回调
+
+	// Name 是 WebView 默认右键菜单项的名称，如 "copy"、"paste"、"inspectElement"，自定义菜单项为空
+	Name string
+
+	native *edge.ICoreWebView2ContextMenuItem
+}
+
+// Menu 是菜单项的有序列表
+type Menu struct {
+	Items []*MenuItem
+}
+
+// NewMenu 创建包含指定菜单项的菜单
+func NewMenu(items ...*MenuItem) *Menu {
+	return &Menu{Items: items}
+}
+
+// NewMenuItem 创建普通菜单项
+func NewMenuItem(label string, onClick func(item *MenuItem)) *MenuItem {
+	return &MenuItem{Label: label, OnClick: onClick}
+}
+
+// NewCheckboxItem 创建复选框菜单项
+func NewCheckboxItem(label string, checked bool, onClick func(item *MenuItem)) *MenuItem {
+	return &MenuItem{Label: label, Kind: MenuItemCheckbox, Checked: checked, OnClick: onClick}
+}
+
+// NewRadioItem 创建单选菜单项，同一菜单中的单选项互斥
+func NewRadioItem(label string, checked bool, onClick func(item *MenuItem)) *MenuItem {
+	return &MenuItem{Label: label, Kind: MenuItemRadio, Checked: checked, OnClick: onClick}
+}
+
+// NewSubmenu 创建子菜单项
+func NewSubmenu(label string, submenu *Menu) *MenuItem {
+	return &MenuItem{Label: label, Kind: MenuItemSubmenu, Submenu: submenu}
+}
+
+// NewSeparator 创建分隔线
+func NewSeparator() *MenuItem {
+	return &MenuItem{Kind: MenuItemSeparator}
+}
+
+// Append 在菜单末尾添加菜单项
+func (m *Menu) Append(items ...*MenuItem) *Menu {
+	m.Items = append(m.Items, items...)
+	return m
+}
+
+// Insert 在 index 位置插入菜单项，index 超出范围时添加到末尾
+func (m *Menu) Insert(index int, items ...*MenuItem) *Menu {
+	if index < 0 || index > len(m.Items) {
+		index = len(m.Items)
+	}
+	rest := append([]*MenuItem{}, m.Items[index:]...)
+	m.Items = append(append(m.Items[:index], items...), rest...)
+	return m
+}
+
+// Remove 按名称移除默认右键菜单项 (不查找子菜单)
+func (m *Menu) Remove(names ...string) *Menu {
+	items := m.Items[:0]
+	for _, item := range m.Items {
+		remove := false
+		for _, name := range names {
+			if item.Name != "" && item.Name == name {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			items = append(items, item)
+		}
+	}
+	m.Items = items
+	return m
+}
+
+// Find 按名称查找默认右键菜单项，会递归查找子菜单
+func (m *Menu) Find(name string) *MenuItem {
+	for _, item := range m.Items {
+		if item.Name == name {
+			return item
+		}
+		if item.Submenu != nil {
+			if found := item.Submenu.Find(name); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func (item *MenuItem) kind() MenuItemKind {
+	if item.Submenu != nil {
+		return MenuItemSubmenu
+	}
+	return item.Kind
+}
+
+// activate 处理菜单项被点击：更新选中状态并调用回调
+func (item *MenuItem) activate(parent *Menu) {
+	switch item.kind() {
+	case MenuItemCheckbox:
+		item.Checked = !item.Checked
+	case MenuItemRadio:
+		if parent != nil {
+			for _, sibling := range parent.Items {
+				if sibling.kind() == MenuItemRadio {
+					sibling.Checked = false
+				}
+			}
+		}
+		item.Checked = true
+	}
+	if item.OnClick != nil {
+		item.OnClick(item)
+	}
+}
+
+// menuEntry 记录命令 ID 对应的菜单项及其所在菜单
+type menuEntry struct {
+	item   *MenuItem
+	parent *Menu
+}
+
+// nativeMenu 是由 Menu 构建的 Win32 菜单及其资源
+type nativeMenu struct {
+	handle  uintptr
+	entries map[uint16]menuEntry
+	bitmaps []uintptr
+	accels  []menuAccelerator
+	nextID  uint16
+}
+
+type menuAccelerator struct {
+	hotkey HotKey
+	id     uint16
+}
+
+func (n *nativeMenu) destroy() {
+	if n == nil {
+		return
+	}
+	if n.handle != 0 {
+		_, _, _ = w32.User32DestroyMenu.Call(n.handle)
+	}
+	for _, bitmap := range n.bitmaps {
+		_, _, _ = w32.Gdi32DeleteObject.Call(bitmap)
+	}
+}
+
+// buildNativeMenu 构建菜单栏 (popup 为 false) 或弹出菜单，命令 ID 从 firstID 开始分配
+func buildNativeMenu(menu *Menu, popup bool, firstID uint16) (*nativeMenu, error) {
+	n := &nativeMenu{entries: map[uint16]menuEntry{}, nextID: firstID}
+	var err error
+	n.handle, err = n.build(menu, popup)
+	if err != nil {
+		n.destroy()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *nativeMenu) build(menu *Menu, popup bool) (uintptr, error) {
+	proc := w32.User32CreatePopupMenu
+	if !popup {
+		proc = w32.User32CreateMenu
+	}
+	handle, _, _ := proc.Call()
+	if handle == 0 {
+		return 0, errors.New("failed to create menu")
+	}
+	if menu == nil {
+		return handle, nil
+	}
+
+	for _, item := range menu.Items {
+		label := item.Label
+		switch item.kind() {
+		case MenuItemSeparator:
+			w32.AppendMenu(handle, w32.MF_SEPARATOR, 0, "")
+			continue
+		case MenuItemSubmenu:
+			submenu, err := n.build(item.Submenu, true)
+			if err != nil {
+				_, _, _ = w32.User32DestroyMenu.Call(handle)
+				return 0, err
+			}
+			var flags uint32 = w32.MF_POPUP
+			if item.Disabled {
+				flags |= w32.MF_GRAYED
+			}
+			if !w32.AppendMenu(handle, flags, submenu, label) {
+				_, _, _ = w32.User32DestroyMenu.Call(submenu)
+				_, _, _ = w32.User32DestroyMenu.Call(handle)
+				return 0, fmt.Errorf("failed to append submenu %q", item.Label)
+			}
+			continue
+		}
+
+		id := n.nextID
+		n.nextID++
+		n.entries[id] = menuEntry{item: item, parent: menu}
+
+		if item.Accelerator != "" {
+			if hotkey, err := ParseHotKey(item.Accelerator); err != nil {
+				log.Printf("Warning: Invalid menu accelerator %q: %v", item.Accelerator, err)
+			} else {
+				n.accels = append(n.accels, menuAccelerator{hotkey: hotkey, id: id})
+				label += "\t" + item.Accelerator
+			}
+		}
+
+		var flags uint32 = w32.MF_STRING
+		if item.Checked {
+			flags |= w32.MF_CHECKED
+		}
+		if item.Disabled {
+			flags |= w32.MF_GRAYED
+		}
+		if !w32.AppendMenu(handle, flags, uintptr(id), label) {
+			// 已添加的子菜单随父菜单一起销毁
+			_, _, _ = w32.User32DestroyMenu.Call(handle)
+			return 0, fmt.Errorf("failed to append menu item %q", item.Label)
+		}
+
+		info := w32.MenuItemInfo{}
+		if item.kind() == MenuItemRadio {
+			info.FMask |= w32.MIIM_FTYPE
+			info.FType |= w32.MFT_RADIOCHECK
+		}
+		if len(item.Icon) > 0 {
+			if bitmap := menuBitmap(item.Icon); bitmap != 0 {
+				n.bitmaps = append(n.bitmaps, bitmap)
+				info.FMask |= w32.MIIM_BITMAP
+				info.HbmpItem = bitmap
+			}
+		}
+		if info.FMask != 0 {
+			w32.SetMenuItemInfo(handle, uint32(id), &info)
+		}
+	}
+	return handle, nil
+}
+
+// menuBitmap 将图标数据转换为菜单项位图
+func menuBitmap(data []byte) uintptr {
+	size, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CXSMICON)
+	icon := w32.CreateIconFromBytes(data, int(size))
+	if icon == 0 {
+		return 0
+	}
+	defer w32.User32DestroyIcon.Call(icon)
+	return w32.CreateBitmapFromIcon(icon, int(size))
+}
+
+// activate 处理命令 ID 对应的菜单项，并同步原生菜单的选中状态
+func (n *nativeMenu) activate(id uint16) bool {
+	entry, ok := n.entries[id]
+	if !ok || entry.item.Disabled {
+		return ok
+	}
+	entry.item.activate(entry.parent)
+	for otherID, other := range n.entries {
+		if other.parent != entry.parent {
+			continue
+		}
+		if kind := other.item.kind(); kind == MenuItemCheckbox || kind == MenuItemRadio {
+			var state uintptr = w32.MF_UNCHECKED
+			if other.item.Checked {
+				state = w32.MF_CHECKED
+			}
+			_, _, _ = w32.User32CheckMenuItem.Call(n.handle, uintptr(otherID), w32.MF_BYCOMMAND|state)
+		}
+	}
+	return true
+}
+
+// matchAccelerator 查找与当前按键匹配的菜单快捷键
+func (n *nativeMenu) matchAccelerator(virtualKey uint) (uint16, bool) {
+	if n == nil {
+		return 0, false
+	}
+	modifiers := currentModifiers()
+	for _, accel := range n.accels {
+		if uint(accel.hotkey.KeyCode) == virtualKey && accel.hotkey.Modifiers&^w32.MOD_NOREPEAT == modifiers {
+			return accel.id, true
+		}
+	}
+	return 0, false
+}
+
+// currentModifiers 返回当前按下的修饰键 (MOD_* 组合)
+func currentModifiers() int {
+	var modifiers int
+	if w32.IsKeyDown(w32.VK_CONTROL) {
+		modifiers |= w32.MOD_CONTROL
+	}
+	if w32.IsKeyDown(w32.VK_MENU) {
+		modifiers |= w32.MOD_ALT
+	}
+	if w32.IsKeyDown(w32.VK_SHIFT) {
+		modifiers |= w32.MOD_SHIFT
+	}
+	if w32.IsKeyDown(w32.VK_LWIN) || w32.IsKeyDown(w32.VK_RWIN) {
+		modifiers |= w32.MOD_WIN
+	}
+	return modifiers
+}
+
+// menuBarFirstID 是菜单栏命令 ID 的起始值
+const menuBarFirstID = 1000
+
+// SetMenu 设置窗口菜单栏，传入 nil 移除菜单栏。
+// 修改菜单项 (如 Checked、Disabled) 后再次调用 SetMenu 以刷新
+func (w *webview) SetMenu(menu *Menu) error {
+	var err error
+	w.runOnMain(func() {
+		var built *nativeMenu
+		if menu != nil {
+			if built, err = buildNativeMenu(menu, false, menuBarFirstID); err != nil {
+				return
+			}
+		}
+		var handle uintptr
+		if built != nil {
+			handle = built.handle
+		}
+		if r, _, _ := w32.User32SetMenu.Call(w.hwnd, handle); r == 0 {
+			built.destroy()
+			err = errors.New("failed to set window menu")
+			return
+		}
+		_, _, _ = w32.User32DrawMenuBar.Call(w.hwnd)
+
+		old := w.menuBar
+		w.menuBar = built
+		old.destroy()
+	})
+	return err
+}
+
+// handleMenuCommand 处理 WM_COMMAND 中的菜单命令
+func (w *webview) handleMenuCommand(id uint16) bool {
+	if w.menuBar == nil {
+		return false
+	}
+	return w.menuBar.activate(id)
+}
+
+// menuAcceleratorKey 在 WebView 获得焦点时响应菜单栏快捷键
+func (w *webview) menuAcceleratorKey(virtualKey uint) bool {
+	id, ok := w.menuBar.matchAccelerator(virtualKey)
+	if !ok {
+		return false
+	}
+	return w.handleMenuCommand(id)
+}
+
+// ContextMenuTargetKind 右键菜单目标类型
+type ContextMenuTargetKind int
+
+const (
+	ContextMenuTargetPage         ContextMenuTargetKind = edge.COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND_PAGE
+	ContextMenuTargetImage        ContextMenuTargetKind = edge.COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND_IMAGE
+	ContextMenuTargetSelectedText ContextMenuTargetKind = edge.COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND_SELECTED_TEXT
+	ContextMenuTargetAudio        ContextMenuTargetKind = edge.COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND_AUDIO
+	ContextMenuTargetVideo        ContextMenuTargetKind = edge.COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND_VIDEO
+)
+
+// ContextMenuTarget 描述右键点击的位置和元素
+type ContextMenuTarget struct {
+	Kind          ContextMenuTargetKind // 目标类型
+	Editable      bool                  // 是否为可编辑元素
+	MainFrame     bool                  // 是否在主框架中
+	PageURL       string                // 页面地址
+	FrameURL      string                // 框架地址
+	LinkURL       string                // 链接地址，不在链接上时为空
+	LinkText      string                // 链接文本
+	SourceURL     string                // 图片/音视频的源地址
+	SelectionText string                // 选中的文本
+	X, Y          int                   // 菜单位置 (WebView 客户区坐标)
+}
+
+// ContextMenuHandler 在显示右键菜单前调用，可增删、替换 menu 中的菜单项。
+// menu 初始为 WebView 的默认菜单，默认菜单项的 Name 不为空
+type ContextMenuHandler func(menu *Menu, target ContextMenuTarget)
+
+// OnContextMenu 设置右键菜单处理函数，同时会启用 WebView 默认右键菜单。
+// 需要 WebView2 Runtime 支持 ContextMenuRequested (101 及以上版本)
+func (w *webview) OnContextMenu(handler ContextMenuHandler) {
+	w.m.Lock()
+	w.contextMenuHandler = handler
+	w.m.Unlock()
+	if handler != nil {
+		w.runOnMain(func() {
+			if err := w.browser.EnableContextMenu(); err != nil {
+				log.Printf("Warning: Failed to enable context menu: %v", err)
+			}
+		})
+	}
+}
+
+// contextMenuRequested 将默认右键菜单转换为 Menu，交给处理函数修改后写回
+func (w *webview) contextMenuRequested(args *edge.ICoreWebView2ContextMenuRequestedEventArgs) {
+	w.m.Lock()
+	handler := w.contextMenuHandler
+	w.m.Unlock()
+	chromium, ok := w.browser.(*edge.Chromium)
+	if handler == nil || !ok {
+		return
+	}
+
+	collection, err := args.GetMenuItems()
+	if err != nil {
+		log.Printf("Warning: Failed to get context menu items: %v", err)
+		return
+	}
+	defer collection.Release()
+
+	menu := readContextMenu(collection)
+	// 处理函数可能移除默认菜单项，在调用前记录所有持有引用的菜单项
+	defer releaseContextMenu(nativeContextMenuItems(menu, nil))
+	handler(menu, readContextMenuTarget(args))
+
+	w.contextMenuItems = map[int32]menuEntry{}
+	if err := w.writeContextMenu(chromium, collection, menu); err != nil {
+		log.Printf("Warning: Failed to update context menu: %v", err)
+	}
+}
+
+func readContextMenuTarget(args *edge.ICoreWebView2ContextMenuRequestedEventArgs) ContextMenuTarget {
+	var target ContextMenuTarget
+	if location, err := args.GetLocation(); err == nil {
+		target.X, target.Y = int(location.X), int(location.Y)
+	}
+	native, err := args.GetContextMenuTarget()
+	if err != nil {
+		return target
+	}
+	defer native.Release()
+
+	kind, _ := native.GetKind()
+	target.Kind = ContextMenuTargetKind(kind)
+	target.Editable, _ = native.GetIsEditable()
+	target.MainFrame, _ = native.GetIsRequestedForMainFrame()
+	target.PageURL, _ = native.GetPageUri()
+	target.FrameURL, _ = native.GetFrameUri()
+	if has, _ := native.GetHasLinkUri(); has {
+		target.LinkURL, _ = native.GetLinkUri()
+	}
+	if has, _ := native.GetHasLinkText(); has {
+		target.LinkText, _ = native.GetLinkText()
+	}
+	if has, _ := native.GetHasSourceUri(); has {
+		target.SourceURL, _ = native.GetSourceUri()
+	}
+	if has, _ := native.GetHasSelection(); has {
+		target.SelectionText, _ = native.GetSelectionText()
+	}
+	return target
+}
+
+// readContextMenu 读取默认右键菜单，返回的菜单项持有原生菜单项的引用
+func readContextMenu(collection *edge.ICoreWebView2ContextMenuItemCollection) *Menu {
+	menu := &Menu{}
+	count, err := collection.GetCount()
+	if err != nil {
+		return menu
+	}
+	for i := uint32(0); i < count; i++ {
+		native, err := collection.GetValueAtIndex(i)
+		if err != nil {
+			continue
+		}
+		item := &MenuItem{native: native}
+		item.Name, _ = native.GetName()
+		item.Label, _ = native.GetLabel()
+		item.Accelerator, _ = native.GetShortcutKeyDescription()
+		item.Checked, _ = native.GetIsChecked()
+		if enabled, err := native.GetIsEnabled(); err == nil {
+			item.Disabled = !enabled
+		}
+		kind, _ := native.GetKind()
+		switch kind {
+		case edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_CHECK_BOX:
+			item.Kind = MenuItemCheckbox
+		case edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_RADIO:
+			item.Kind = MenuItemRadio
+		case edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SEPARATOR:
+			item.Kind = MenuItemSeparator
+		case edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SUBMENU:
+			item.Kind = MenuItemSubmenu
+			if children, err := native.GetChildren(); err == nil {
+				item.Submenu = readContextMenu(children)
+				children.Release()
+			}
+		}
+		menu.Items = append(menu.Items, item)
+	}
+	return menu
+}
+
+// nativeContextMenuItems 将 menu 及其子菜单中持有原生菜单项引用的菜单项追加到 items
+func nativeContextMenuItems(menu *Menu, items []*MenuItem) []*MenuItem {
+	if menu == nil {
+		return items
+	}
+	for _, item := range menu.Items {
+		if item.native != nil {
+			items = append(items, item)
+		}
+		items = nativeContextMenuItems(item.Submenu, items)
+	}
+	return items
+}
+
+// releaseContextMenu 释放 readContextMenu 获取的原生菜单项引用
+func releaseContextMenu(items []*MenuItem) {
+	for _, item := range items {
+		if item.native != nil {
+			item.native.Release()
+			item.native = nil
+		}
+	}
+}
+
+// writeContextMenu 用 menu 替换原生菜单集合中的内容，自定义菜单项会新建原生菜单项
+func (w *webview) writeContextMenu(chromium *edge.Chromium, collection *edge.ICoreWebView2ContextMenuItemCollection, menu *Menu) error {
+	count, err := collection.GetCount()
+	if err != nil {
+		return err
+	}
+	for i := count; i > 0; i-- {
+		if err := collection.RemoveValueAtIndex(i - 1); err != nil {
+			return err
+		}
+	}
+
+	index := uint32(0)
+	for _, item := range menu.Items {
+		native := item.native
+		if native == nil {
+			native, err = w.createContextMenuItem(chromium, item, menu)
+			if err != nil {
+				return err
+			}
+		}
+		if item.kind() == MenuItemSubmenu && item.Submenu != nil {
+			children, err := native.GetChildren()
+			if err == nil {
+				err = w.writeContextMenu(chromium, children, item.Submenu)
+				children.Release()
+			}
+			if err != nil {
+				if item.native == nil {
+					native.Release()
+				}
+				return err
+			}
+		}
+		err = collection.InsertValueAtIndex(index, native)
+		if item.native == nil {
+			// 集合持有新建菜单项的引用
+			native.Release()
+		}
+		if err != nil {
+			return err
+		}
+		index++
+	}
+	return nil
+}
+
+func (w *webview) createContextMenuItem(chromium *edge.Chromium, item *MenuItem, parent *Menu) (*edge.ICoreWebView2ContextMenuItem, error) {
+	var kind edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND
+	switch item.kind() {
+	case MenuItemCheckbox:
+		kind = edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_CHECK_BOX
+	case MenuItemRadio:
+		kind = edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_RADIO
+	case MenuItemSeparator:
+		kind = edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SEPARATOR
+	case MenuItemSubmenu:
+		kind = edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SUBMENU
+	default:
+		kind = edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_COMMAND
+	}
+
+	// 右键菜单不支持显示快捷键，Label 中的制表符之后部分会被忽略
+	label := strings.SplitN(item.Label, "\t", 2)[0]
+	native, err := chromium.CreateContextMenuItem(label, item.Icon, kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind == edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SEPARATOR {
+		return native, nil
+	}
+	_ = native.PutIsEnabled(!item.Disabled)
+	if kind == edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_CHECK_BOX || kind == edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_RADIO {
+		_ = native.PutIsChecked(item.Checked)
+	}
+	if kind != edge.COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SUBMENU {
+		if id, err := native.GetCommandId(); err == nil {
+			w.contextMenuItems[id] = menuEntry{item: item, parent: parent}
+		}
+	}
+	return native, nil
+}
+
+// customContextMenuItemSelected 处理自定义右键菜单项被选中
+func (w *webview) customContextMenuItemSelected(native *edge.ICoreWebView2ContextMenuItem) {
+	id, err := native.GetCommandId()
+	if err != nil {
+		return
+	}
+	if entry, ok := w.contextMenuItems[id]; ok {
+		entry.item.activate(entry.parent)
+	}
+}
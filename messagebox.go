@@ -0,0 +1,292 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+// MessageBoxButtons 消息框的按钮组合
+type MessageBoxButtons int
+
+const (
+	ButtonsOK                MessageBoxButtons = w32.MB_OK                // 确定
+	ButtonsOKCancel          MessageBoxButtons = w32.MB_OKCANCEL          // 确定、取消
+	ButtonsAbortRetryIgnore  MessageBoxButtons = w32.MB_ABORTRETRYIGNORE  // 中止、重试、忽略
+	ButtonsYesNoCancel       MessageBoxButtons = w32.MB_YESNOCANCEL       // 是、否、取消
+	ButtonsYesNo             MessageBoxButtons = w32.MB_YESNO             // 是、否
+	ButtonsRetryCancel       MessageBoxButtons = w32.MB_RETRYCANCEL       // 重试、取消
+	ButtonsCancelTryContinue MessageBoxButtons = w32.MB_CANCELTRYCONTINUE // 取消、重试、继续
+)
+
+// MessageBoxIcon 消息框和任务对话框的图标
+type MessageBoxIcon int
+
+const (
+	IconNone        MessageBoxIcon = iota // 无图标
+	IconInformation                       // 信息
+	IconWarning                           // 警告
+	IconError                             // 错误
+	IconQuestion                          // 询问 (任务对话框中显示为信息图标)
+	IconShield                            // 盾牌 (消息框中不显示)
+)
+
+// DialogResult 用户点击的按钮
+type DialogResult int
+
+const (
+	ResultNone     DialogResult = 0
+	ResultOK       DialogResult = w32.IDOK
+	ResultCancel   DialogResult = w32.IDCANCEL
+	ResultAbort    DialogResult = w32.IDABORT
+	ResultRetry    DialogResult = w32.IDRETRY
+	ResultIgnore   DialogResult = w32.IDIGNORE
+	ResultYes      DialogResult = w32.IDYES
+	ResultNo       DialogResult = w32.IDNO
+	ResultClose    DialogResult = w32.IDCLOSE
+	ResultTryAgain DialogResult = w32.IDTRYAGAIN
+	ResultContinue DialogResult = w32.IDCONTINUE
+)
+
+func (icon MessageBoxIcon) messageBoxFlags() uint32 {
+	switch icon {
+	case IconInformation:
+		return w32.MB_ICONINFORMATION
+	case IconWarning:
+		return w32.MB_ICONWARNING
+	case IconError:
+		return w32.MB_ICONERROR
+	case IconQuestion:
+		return w32.MB_ICONQUESTION
+	}
+	return 0
+}
+
+func (icon MessageBoxIcon) taskDialogIcon() uint16 {
+	switch icon {
+	case IconInformation, IconQuestion:
+		return w32.TD_INFORMATION_ICON
+	case IconWarning:
+		return w32.TD_WARNING_ICON
+	case IconError:
+		return w32.TD_ERROR_ICON
+	case IconShield:
+		return w32.TD_SHIELD_ICON
+	}
+	return 0
+}
+
+// MessageBox 显示模态消息框并返回用户点击的按钮。
+// 不需要窗口或 WebView2 运行时，可用于启动失败时提示用户。
+func MessageBox(title, text string, buttons MessageBoxButtons, icon MessageBoxIcon) DialogResult {
+	return DialogResult(w32.MessageBox(0, title, text, uint32(buttons)|icon.messageBoxFlags()))
+}
+
+// MessageBox 显示以窗口为父窗口的模态消息框
+func (w *webview) MessageBox(title, text string, buttons MessageBoxButtons, icon MessageBoxIcon) DialogResult {
+	var result DialogResult
+	w.runOnMain(func() {
+		result = DialogResult(w32.MessageBox(w.hwnd, title, text, uint32(buttons)|icon.messageBoxFlags()))
+	})
+	return result
+}
+
+// TaskDialogButton 任务对话框的自定义按钮
+type TaskDialogButton struct {
+	ID   int    // 按钮 ID，用户点击后作为结果返回，应避开 DialogResult 中的值
+	Text string // 按钮文字，使用命令链接时第一个换行后的内容显示为说明
+}
+
+// TaskDialogOptions 任务对话框选项
+type TaskDialogOptions struct {
+	Title               string             // 窗口标题
+	Heading             string             // 主要说明 (大号字体)
+	Content             string             // 正文
+	Footer              string             // 底部说明
+	ExpandedInfo        string             // 可展开的详细信息
+	Icon                MessageBoxIcon     // 图标
+	Buttons             []TaskDialogButton // 自定义按钮
+	CommonButtons       []DialogResult     // 系统按钮，支持 OK/Cancel/Yes/No/Retry/Close，未设置任何按钮时显示确定
+	CommandLinks        bool               // 自定义按钮显示为命令链接
+	DefaultButton       int                // 默认按钮 ID
+	VerificationText    string             // 复选框文字，为空时不显示
+	VerificationChecked bool               // 复选框初始状态
+	ProgressBar         bool               // 显示进度条
+	Marquee             bool               // 进度条为滚动样式
+	Cancelable          bool               // 允许按 Esc 或关闭按钮取消 (结果为 ResultCancel)
+
+	// OnCreated 在对话框显示后于对话框线程上调用，耗时操作应放到 goroutine 中，
+	// 通过 TaskDialogController 更新进度或关闭对话框
+	OnCreated func(ctl *TaskDialogController)
+}
+
+// TaskDialogResult 任务对话框的结果
+type TaskDialogResult struct {
+	Button              int  // 点击的按钮 ID，自定义按钮为其 ID，系统按钮为 DialogResult
+	VerificationChecked bool // 复选框是否选中
+}
+
+// TaskDialogController 在对话框显示期间更新对话框，可在任意 goroutine 中调用，
+// 对话框关闭后调用无效
+type TaskDialogController struct {
+	m    sync.Mutex
+	hwnd uintptr
+}
+
+func (c *TaskDialogController) send(msg, wp, lp uintptr) {
+	// 发送消息时不能持有锁，对话框线程销毁时也需要获取锁
+	c.m.Lock()
+	hwnd := c.hwnd
+	c.m.Unlock()
+	if hwnd != 0 {
+		_, _, _ = w32.User32SendMessageW.Call(hwnd, msg, wp, lp)
+	}
+}
+
+// SetProgress 设置进度 (0-100)
+func (c *TaskDialogController) SetProgress(percent int) {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	c.send(w32.TDM_SET_PROGRESS_BAR_POS, uintptr(percent), 0)
+}
+
+// SetMarquee 切换进度条为滚动样式或普通样式
+func (c *TaskDialogController) SetMarquee(enable bool) {
+	var on uintptr
+	if enable {
+		on = 1
+	}
+	c.send(w32.TDM_SET_MARQUEE_PROGRESS_BAR, on, 0)
+	c.send(w32.TDM_SET_PROGRESS_BAR_MARQUEE, on, 0)
+}
+
+// SetContent 更新正文
+func (c *TaskDialogController) SetContent(text string) {
+	c.setText(w32.TDE_CONTENT, text)
+}
+
+// SetHeading 更新主要说明
+func (c *TaskDialogController) SetHeading(text string) {
+	c.setText(w32.TDE_MAIN_INSTRUCTION, text)
+}
+
+func (c *TaskDialogController) setText(element uintptr, text string) {
+	p, err := windows.UTF16PtrFromString(text)
+	if err != nil {
+		return
+	}
+	c.send(w32.TDM_SET_ELEMENT_TEXT, element, uintptr(unsafe.Pointer(p)))
+	runtime.KeepAlive(p)
+}
+
+// EnableButton 启用或禁用按钮
+func (c *TaskDialogController) EnableButton(id int, enable bool) {
+	var on uintptr
+	if enable {
+		on = 1
+	}
+	c.send(w32.TDM_ENABLE_BUTTON, uintptr(id), on)
+}
+
+// Close 以指定按钮 ID 作为结果关闭对话框
+func (c *TaskDialogController) Close(id int) {
+	c.send(w32.TDM_CLICK_BUTTON, uintptr(id), 0)
+}
+
+// TaskDialog 显示模态任务对话框。
+// 与 MessageBox 一样不需要窗口或 WebView2 运行时。
+func TaskDialog(opts TaskDialogOptions) (TaskDialogResult, error) {
+	return showTaskDialog(0, opts)
+}
+
+// TaskDialog 显示以窗口为父窗口的任务对话框
+func (w *webview) TaskDialog(opts TaskDialogOptions) (TaskDialogResult, error) {
+	var (
+		result TaskDialogResult
+		err    error
+	)
+	w.runOnMain(func() {
+		result, err = showTaskDialog(w.hwnd, opts)
+	})
+	return result, err
+}
+
+func showTaskDialog(parent uintptr, opts TaskDialogOptions) (TaskDialogResult, error) {
+	config := w32.TaskDialogConfig{
+		Parent:           parent,
+		Title:            opts.Title,
+		Icon:             opts.Icon.taskDialogIcon(),
+		MainInstruction:  opts.Heading,
+		Content:          opts.Content,
+		DefaultButton:    opts.DefaultButton,
+		VerificationText: opts.VerificationText,
+		ExpandedInfo:     opts.ExpandedInfo,
+		Footer:           opts.Footer,
+	}
+	for _, b := range opts.Buttons {
+		config.Buttons = append(config.Buttons, w32.TaskDialogButton{ID: b.ID, Text: b.Text})
+	}
+	for _, b := range opts.CommonButtons {
+		switch b {
+		case ResultOK:
+			config.CommonButtons |= w32.TDCBF_OK_BUTTON
+		case ResultCancel:
+			config.CommonButtons |= w32.TDCBF_CANCEL_BUTTON
+		case ResultYes:
+			config.CommonButtons |= w32.TDCBF_YES_BUTTON
+		case ResultNo:
+			config.CommonButtons |= w32.TDCBF_NO_BUTTON
+		case ResultRetry:
+			config.CommonButtons |= w32.TDCBF_RETRY_BUTTON
+		case ResultClose:
+			config.CommonButtons |= w32.TDCBF_CLOSE_BUTTON
+		}
+	}
+	if opts.CommandLinks && len(opts.Buttons) > 0 {
+		config.Flags |= w32.TDF_USE_COMMAND_LINKS
+	}
+	if opts.VerificationChecked {
+		config.Flags |= w32.TDF_VERIFICATION_FLAG_CHECKED
+	}
+	if opts.Marquee {
+		config.Flags |= w32.TDF_SHOW_MARQUEE_PROGRESS_BAR
+	} else if opts.ProgressBar {
+		config.Flags |= w32.TDF_SHOW_PROGRESS_BAR
+	}
+	if opts.Cancelable {
+		config.Flags |= w32.TDF_ALLOW_DIALOG_CANCELLATION
+	}
+
+	ctl := &TaskDialogController{}
+	config.Created = func(hwnd uintptr) {
+		ctl.m.Lock()
+		ctl.hwnd = hwnd
+		ctl.m.Unlock()
+		if opts.Marquee {
+			ctl.send(w32.TDM_SET_PROGRESS_BAR_MARQUEE, 1, 0)
+		}
+		if opts.OnCreated != nil {
+			opts.OnCreated(ctl)
+		}
+	}
+	config.Destroyed = func() {
+		ctl.m.Lock()
+		ctl.hwnd = 0
+		ctl.m.Unlock()
+	}
+
+	button, checked, err := w32.ShowTaskDialog(config)
+	if err != nil {
+		return TaskDialogResult{}, err
+	}
+	return TaskDialogResult{Button: button, VerificationChecked: checked}, nil
+}
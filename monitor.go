@@ -0,0 +1,198 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+// ErrMonitorNotFound 表示指定的显示器不存在 (可能已断开)
+var ErrMonitorNotFound = errors.New("monitor not found")
+
+// Rect 描述屏幕上的矩形区域，单位为物理像素
+type Rect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Monitor 描述一个显示器
+type Monitor struct {
+	Name     string  `json:"name"`     // 设备名，如 \\.\DISPLAY1
+	Bounds   Rect    `json:"bounds"`   // 显示器区域
+	WorkArea Rect    `json:"workArea"` // 工作区 (不含任务栏)
+	DPI      int     `json:"dpi"`      // 有效 DPI (96 表示 100%)
+	Scale    float64 `json:"scale"`    // 缩放比例，即 DPI/96
+	Primary  bool    `json:"primary"`  // 是否为主显示器
+}
+
+func rectFromW32(r w32.Rect) Rect {
+	return Rect{X: int(r.Left), Y: int(r.Top), Width: int(r.Right - r.Left), Height: int(r.Bottom - r.Top)}
+}
+
+// monitorFromHandle 读取显示器句柄对应的信息
+func monitorFromHandle(hmonitor uintptr) (Monitor, bool) {
+	info, ok := w32.GetMonitorInfo(hmonitor)
+	if !ok {
+		return Monitor{}, false
+	}
+	dpi := w32.GetDpiForMonitor(hmonitor)
+	return Monitor{
+		Name:     windows.UTF16ToString(info.SzDevice[:]),
+		Bounds:   rectFromW32(info.RcMonitor),
+		WorkArea: rectFromW32(info.RcWork),
+		DPI:      int(dpi),
+		Scale:    float64(dpi) / w32.USER_DEFAULT_SCREEN_DPI,
+		Primary:  info.DwFlags&w32.MONITORINFOF_PRIMARY != 0,
+	}, true
+}
+
+// findMonitor 按设备名查找显示器句柄
+func findMonitor(name string) (uintptr, bool) {
+	for _, hmonitor := range w32.EnumDisplayMonitors() {
+		if info, ok := w32.GetMonitorInfo(hmonitor); ok && windows.UTF16ToString(info.SzDevice[:]) == name {
+			return hmonitor, true
+		}
+	}
+	return 0, false
+}
+
+// Monitors 返回所有显示器，顺序与系统枚举顺序一致
+func Monitors() []Monitor {
+	var monitors []Monitor
+	for _, hmonitor := range w32.EnumDisplayMonitors() {
+		if m, ok := monitorFromHandle(hmonitor); ok {
+			monitors = append(monitors, m)
+		}
+	}
+	return monitors
+}
+
+// PrimaryMonitor 返回主显示器
+func PrimaryMonitor() (Monitor, bool) {
+	for _, m := range Monitors() {
+		if m.Primary {
+			return m, true
+		}
+	}
+	return Monitor{}, false
+}
+
+// windowMonitor 返回窗口所在 (与窗口相交面积最大) 的显示器句柄
+func (w *webview) windowMonitor() uintptr {
+	hmonitor, _, _ := w32.User32MonitorFromWindow.Call(w.hwnd, w32.MONITOR_DEFAULTTONEAREST)
+	return hmonitor
+}
+
+// CurrentMonitor 返回窗口当前所在的显示器
+func (w *webview) CurrentMonitor() (Monitor, bool) {
+	var (
+		m  Monitor
+		ok bool
+	)
+	w.runOnMain(func() {
+		m, ok = monitorFromHandle(w.windowMonitor())
+	})
+	return m, ok
+}
+
+// MoveToMonitor 将窗口移动到指定显示器并在其工作区内居中，窗口的逻辑尺寸保持不变。
+// 最大化或全屏的窗口会在目标显示器上保持最大化或全屏。
+func (w *webview) MoveToMonitor(m Monitor) error {
+	var err error
+	w.runOnMain(func() {
+		target, ok := findMonitor(m.Name)
+		if !ok {
+			err = fmt.Errorf("%w: %s", ErrMonitorNotFound, m.Name)
+			return
+		}
+		if target == w.windowMonitor() {
+			return
+		}
+		err = w.moveToMonitor(target)
+	})
+	return err
+}
+
+func (w *webview) moveToMonitor(target uintptr) error {
+	info, ok := w32.GetMonitorInfo(target)
+	if !ok {
+		return ErrMonitorNotFound
+	}
+
+	placement := w32.WindowPlacement{}
+	placement.Length = uint32(unsafe.Sizeof(placement))
+	if w.fullscreen {
+		placement = w.fsPlacement
+	} else if r, _, _ := w32.User32GetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement))); r == 0 {
+		return errors.New("failed to get window placement")
+	}
+
+	// 还原状态下的矩形按 DPI 比例换算到目标显示器，并在工作区内居中
+	offsetX, offsetY := monitorWorkspaceOffset(target)
+	normal := placement.RcNormalPosition
+	width := normal.Right - normal.Left
+	height := normal.Bottom - normal.Top
+	if from, to := w32.GetDpiForWindow(w.hwnd), w32.GetDpiForMonitor(target); from != 0 && from != to {
+		width = int32(int64(width) * int64(to) / int64(from))
+		height = int32(int64(height) * int64(to) / int64(from))
+	}
+	state := sanitizeWindowState(WindowState{
+		X:       info.RcWork.Left + (info.RcWork.Right-info.RcWork.Left-width)/2,
+		Y:       info.RcWork.Top + (info.RcWork.Bottom-info.RcWork.Top-height)/2,
+		Width:   width,
+		Height:  height,
+		Monitor: windows.UTF16ToString(info.SzDevice[:]),
+		DPI:     w32.GetDpiForMonitor(target),
+	})
+	placement.RcNormalPosition = w32.Rect{
+		Left:   state.X - offsetX,
+		Top:    state.Y - offsetY,
+		Right:  state.X - offsetX + state.Width,
+		Bottom: state.Y - offsetY + state.Height,
+	}
+	if placement.ShowCmd == w32.SW_SHOWMINIMIZED {
+		placement.ShowCmd = w32.SW_SHOWNORMAL
+	}
+
+	if w.fullscreen {
+		// 全屏时只更新退出全屏后要恢复的位置，窗口本身铺满目标显示器
+		w.fsPlacement = placement
+		w.fitToMonitor(target)
+		return nil
+	}
+
+	w.ignoreDPIRect = true
+	_, _, _ = w32.User32SetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement)))
+	w.ignoreDPIRect = false
+	w.browser.Resize()
+	return nil
+}
+
+// fitToMonitor 使窗口铺满指定显示器，用于全屏
+func (w *webview) fitToMonitor(hmonitor uintptr) {
+	info, ok := w32.GetMonitorInfo(hmonitor)
+	if !ok {
+		return
+	}
+	bounds := info.RcMonitor
+	w.ignoreDPIRect = true
+	_, _, _ = w32.User32SetWindowPos.Call(
+		w.hwnd,
+		uintptr(w32.HWND_TOP),
+		uintptr(bounds.Left),
+		uintptr(bounds.Top),
+		uintptr(bounds.Right-bounds.Left),
+		uintptr(bounds.Bottom-bounds.Top),
+		w32.SWP_FRAMECHANGED|w32.SWP_NOACTIVATE,
+	)
+	w.ignoreDPIRect = false
+	w.browser.Resize()
+}
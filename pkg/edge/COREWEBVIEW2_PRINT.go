@@ -0,0 +1,48 @@
+package edge
+
+type COREWEBVIEW2_PRINT_ORIENTATION uint32
+
+const (
+	COREWEBVIEW2_PRINT_ORIENTATION_PORTRAIT  = 0
+	COREWEBVIEW2_PRINT_ORIENTATION_LANDSCAPE = 1
+)
+
+type COREWEBVIEW2_PRINT_COLLATION uint32
+
+const (
+	COREWEBVIEW2_PRINT_COLLATION_DEFAULT    = 0
+	COREWEBVIEW2_PRINT_COLLATION_COLLATED   = 1
+	COREWEBVIEW2_PRINT_COLLATION_UNCOLLATED = 2
+)
+
+type COREWEBVIEW2_PRINT_COLOR_MODE uint32
+
+const (
+	COREWEBVIEW2_PRINT_COLOR_MODE_DEFAULT   = 0
+	COREWEBVIEW2_PRINT_COLOR_MODE_COLOR     = 1
+	COREWEBVIEW2_PRINT_COLOR_MODE_GRAYSCALE = 2
+)
+
+type COREWEBVIEW2_PRINT_DUPLEX uint32
+
+const (
+	COREWEBVIEW2_PRINT_DUPLEX_DEFAULT              = 0
+	COREWEBVIEW2_PRINT_DUPLEX_ONE_SIDED            = 1
+	COREWEBVIEW2_PRINT_DUPLEX_TWO_SIDED_LONG_EDGE  = 2
+	COREWEBVIEW2_PRINT_DUPLEX_TWO_SIDED_SHORT_EDGE = 3
+)
+
+type COREWEBVIEW2_PRINT_DIALOG_KIND uint32
+
+const (
+	COREWEBVIEW2_PRINT_DIALOG_KIND_BROWSER = 0
+	COREWEBVIEW2_PRINT_DIALOG_KIND_SYSTEM  = 1
+)
+
+type COREWEBVIEW2_PRINT_STATUS uint32
+
+const (
+	COREWEBVIEW2_PRINT_STATUS_SUCCEEDED           = 0
+	COREWEBVIEW2_PRINT_STATUS_PRINTER_UNAVAILABLE = 1
+	COREWEBVIEW2_PRINT_STATUS_OTHER_ERROR         = 2
+)
@@ -5,8 +5,6 @@ package edge
 
 import (
 	"unsafe"
-
-	"golang.org/x/sys/windows"
 )
 
 type _ICoreWebView2AcceleratorKeyPressedEventArgsVtbl struct {
@@ -29,54 +27,48 @@ func (i *ICoreWebView2AcceleratorKeyPressedEventArgs) AddRef() uintptr {
 }
 
 func (i *ICoreWebView2AcceleratorKeyPressedEventArgs) GetKeyEventKind() (COREWEBVIEW2_KEY_EVENT_KIND, error) {
-	var err error
 	var keyEventKind COREWEBVIEW2_KEY_EVENT_KIND
-	_, _, err = i.vtbl.GetKeyEventKind.Call(
+	hr, _, _ := i.vtbl.GetKeyEventKind.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&keyEventKind)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2AcceleratorKeyPressedEventArgs", "GetKeyEventKind"); err != nil {
 		return 0, err
 	}
 	return keyEventKind, nil
 }
 
 func (i *ICoreWebView2AcceleratorKeyPressedEventArgs) GetVirtualKey() (uint, error) {
-	var err error
 	var virtualKey uint
-	_, _, err = i.vtbl.GetVirtualKey.Call(
+	hr, _, _ := i.vtbl.GetVirtualKey.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&virtualKey)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2AcceleratorKeyPressedEventArgs", "GetVirtualKey"); err != nil {
 		return 0, err
 	}
 	return virtualKey, nil
 }
 
 func (i *ICoreWebView2AcceleratorKeyPressedEventArgs) GetPhysicalKeyStatus() (COREWEBVIEW2_PHYSICAL_KEY_STATUS, error) {
-	var err error
 	var physicalKeyStatus COREWEBVIEW2_PHYSICAL_KEY_STATUS
-	_, _, err = i.vtbl.GetPhysicalKeyStatus.Call(
+	hr, _, _ := i.vtbl.GetPhysicalKeyStatus.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&physicalKeyStatus)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2AcceleratorKeyPressedEventArgs", "GetPhysicalKeyStatus"); err != nil {
 		return COREWEBVIEW2_PHYSICAL_KEY_STATUS{}, err
 	}
 	return physicalKeyStatus, nil
 }
 
 func (i *ICoreWebView2AcceleratorKeyPressedEventArgs) PutHandled(handled bool) error {
-	var err error
-
-	_, _, err = i.vtbl.PutHandled.Call(
+	hr, _, _ := i.vtbl.PutHandled.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(boolToInt(handled)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2AcceleratorKeyPressedEventArgs", "PutHandled"); err != nil {
 		return err
 	}
 	return nil
 }
-
@@ -0,0 +1,129 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+)
+
+type _ICoreWebView2ContextMenuItemVtbl struct {
+	_IUnknownVtbl
+	GetName                   ComProc
+	GetLabel                  ComProc
+	GetCommandId              ComProc
+	GetShortcutKeyDescription ComProc
+	GetIcon                   ComProc
+	GetKind                   ComProc
+	PutIsEnabled              ComProc
+	GetIsEnabled              ComProc
+	PutIsChecked              ComProc
+	GetIsChecked              ComProc
+	GetChildren               ComProc
+	AddCustomItemSelected     ComProc
+	RemoveCustomItemSelected  ComProc
+}
+
+type ICoreWebView2ContextMenuItem struct {
+	vtbl *_ICoreWebView2ContextMenuItemVtbl
+}
+
+func (i *ICoreWebView2ContextMenuItem) AddRef() uintptr {
+	r, _, _ := i.vtbl.AddRef.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+func (i *ICoreWebView2ContextMenuItem) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetName() (string, error) {
+	return getString(i.vtbl.GetName, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetLabel() (string, error) {
+	return getString(i.vtbl.GetLabel, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetCommandId() (int32, error) {
+	var id int32
+	hr, _, _ := i.vtbl.GetCommandId.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&id)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "GetCommandId"); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetShortcutKeyDescription() (string, error) {
+	return getString(i.vtbl.GetShortcutKeyDescription, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetKind() (COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND, error) {
+	var kind COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND
+	hr, _, _ := i.vtbl.GetKind.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&kind)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "GetKind"); err != nil {
+		return 0, err
+	}
+	return kind, nil
+}
+
+func (i *ICoreWebView2ContextMenuItem) PutIsEnabled(value bool) error {
+	hr, _, _ := i.vtbl.PutIsEnabled.Call(
+		uintptr(unsafe.Pointer(i)),
+		boolToInt(value),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "PutIsEnabled"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetIsEnabled() (bool, error) {
+	return getBool(i.vtbl.GetIsEnabled, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuItem) PutIsChecked(value bool) error {
+	hr, _, _ := i.vtbl.PutIsChecked.Call(
+		uintptr(unsafe.Pointer(i)),
+		boolToInt(value),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "PutIsChecked"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetIsChecked() (bool, error) {
+	return getBool(i.vtbl.GetIsChecked, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuItem) GetChildren() (*ICoreWebView2ContextMenuItemCollection, error) {
+	var children *ICoreWebView2ContextMenuItemCollection
+	hr, _, _ := i.vtbl.GetChildren.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&children)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "GetChildren"); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+func (i *ICoreWebView2ContextMenuItem) AddCustomItemSelected(eventHandler *ICoreWebView2CustomItemSelectedEventHandler, token *_EventRegistrationToken) error {
+	hr, _, _ := i.vtbl.AddCustomItemSelected.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(eventHandler)),
+		uintptr(unsafe.Pointer(token)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItem", "AddCustomItemSelected"); err != nil {
+		return err
+	}
+	return nil
+}
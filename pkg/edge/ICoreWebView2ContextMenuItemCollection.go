@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+)
+
+type _ICoreWebView2ContextMenuItemCollectionVtbl struct {
+	_IUnknownVtbl
+	GetCount           ComProc
+	GetValueAtIndex    ComProc
+	RemoveValueAtIndex ComProc
+	InsertValueAtIndex ComProc
+}
+
+type ICoreWebView2ContextMenuItemCollection struct {
+	vtbl *_ICoreWebView2ContextMenuItemCollectionVtbl
+}
+
+func (i *ICoreWebView2ContextMenuItemCollection) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+func (i *ICoreWebView2ContextMenuItemCollection) GetCount() (uint32, error) {
+	var count uint32
+	hr, _, _ := i.vtbl.GetCount.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&count)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItemCollection", "GetCount"); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (i *ICoreWebView2ContextMenuItemCollection) GetValueAtIndex(index uint32) (*ICoreWebView2ContextMenuItem, error) {
+	var item *ICoreWebView2ContextMenuItem
+	hr, _, _ := i.vtbl.GetValueAtIndex.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(index),
+		uintptr(unsafe.Pointer(&item)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItemCollection", "GetValueAtIndex"); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (i *ICoreWebView2ContextMenuItemCollection) RemoveValueAtIndex(index uint32) error {
+	hr, _, _ := i.vtbl.RemoveValueAtIndex.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(index),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItemCollection", "RemoveValueAtIndex"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2ContextMenuItemCollection) InsertValueAtIndex(index uint32, item *ICoreWebView2ContextMenuItem) error {
+	hr, _, _ := i.vtbl.InsertValueAtIndex.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(index),
+		uintptr(unsafe.Pointer(item)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuItemCollection", "InsertValueAtIndex"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+)
+
+type _ICoreWebView2ContextMenuRequestedEventArgsVtbl struct {
+	_IUnknownVtbl
+	GetMenuItems         ComProc
+	GetContextMenuTarget ComProc
+	GetLocation          ComProc
+	PutSelectedCommandId ComProc
+	GetSelectedCommandId ComProc
+	PutHandled           ComProc
+	GetHandled           ComProc
+	GetDeferral          ComProc
+}
+
+type ICoreWebView2ContextMenuRequestedEventArgs struct {
+	vtbl *_ICoreWebView2ContextMenuRequestedEventArgsVtbl
+}
+
+func (i *ICoreWebView2ContextMenuRequestedEventArgs) GetMenuItems() (*ICoreWebView2ContextMenuItemCollection, error) {
+	var items *ICoreWebView2ContextMenuItemCollection
+	hr, _, _ := i.vtbl.GetMenuItems.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&items)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuRequestedEventArgs", "GetMenuItems"); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (i *ICoreWebView2ContextMenuRequestedEventArgs) GetContextMenuTarget() (*ICoreWebView2ContextMenuTarget, error) {
+	var target *ICoreWebView2ContextMenuTarget
+	hr, _, _ := i.vtbl.GetContextMenuTarget.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&target)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuRequestedEventArgs", "GetContextMenuTarget"); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func (i *ICoreWebView2ContextMenuRequestedEventArgs) GetLocation() (w32.Point, error) {
+	var point w32.Point
+	hr, _, _ := i.vtbl.GetLocation.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&point)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuRequestedEventArgs", "GetLocation"); err != nil {
+		return w32.Point{}, err
+	}
+	return point, nil
+}
+
+func (i *ICoreWebView2ContextMenuRequestedEventArgs) PutHandled(handled bool) error {
+	hr, _, _ := i.vtbl.PutHandled.Call(
+		uintptr(unsafe.Pointer(i)),
+		boolToInt(handled),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuRequestedEventArgs", "PutHandled"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+type _ICoreWebView2ContextMenuTargetVtbl struct {
+	_IUnknownVtbl
+	GetKind                    ComProc
+	GetIsEditable              ComProc
+	GetIsRequestedForMainFrame ComProc
+	GetPageUri                 ComProc
+	GetFrameUri                ComProc
+	GetHasLinkUri              ComProc
+	GetLinkUri                 ComProc
+	GetHasLinkText             ComProc
+	GetLinkText                ComProc
+	GetHasSourceUri            ComProc
+	GetSourceUri               ComProc
+	GetHasSelection            ComProc
+	GetSelectionText           ComProc
+}
+
+type ICoreWebView2ContextMenuTarget struct {
+	vtbl *_ICoreWebView2ContextMenuTargetVtbl
+}
+
+func (i *ICoreWebView2ContextMenuTarget) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+// getString 调用返回 LPWSTR 的属性访问器并释放返回的字符串
+func getString(proc ComProc, this unsafe.Pointer) (string, error) {
+	var _value *uint16
+	hr, _, _ := proc.Call(
+		uintptr(this),
+		uintptr(unsafe.Pointer(&_value)),
+	)
+	if err := newError(hr, "", ""); err != nil {
+		return "", err
+	}
+	value := w32.Utf16PtrToString(_value)
+	windows.CoTaskMemFree(unsafe.Pointer(_value))
+	return value, nil
+}
+
+// getBool 调用返回 BOOL 的属性访问器
+func getBool(proc ComProc, this unsafe.Pointer) (bool, error) {
+	var value int32
+	hr, _, _ := proc.Call(
+		uintptr(this),
+		uintptr(unsafe.Pointer(&value)),
+	)
+	if err := newError(hr, "", ""); err != nil {
+		return false, err
+	}
+	return value != 0, nil
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetKind() (COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND, error) {
+	var kind COREWEBVIEW2_CONTEXT_MENU_TARGET_KIND
+	hr, _, _ := i.vtbl.GetKind.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&kind)),
+	)
+	if err := newError(hr, "ICoreWebView2ContextMenuTarget", "GetKind"); err != nil {
+		return 0, err
+	}
+	return kind, nil
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetIsEditable() (bool, error) {
+	return getBool(i.vtbl.GetIsEditable, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetIsRequestedForMainFrame() (bool, error) {
+	return getBool(i.vtbl.GetIsRequestedForMainFrame, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetPageUri() (string, error) {
+	return getString(i.vtbl.GetPageUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetFrameUri() (string, error) {
+	return getString(i.vtbl.GetFrameUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetHasLinkUri() (bool, error) {
+	return getBool(i.vtbl.GetHasLinkUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetLinkUri() (string, error) {
+	return getString(i.vtbl.GetLinkUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetHasLinkText() (bool, error) {
+	return getBool(i.vtbl.GetHasLinkText, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetLinkText() (string, error) {
+	return getString(i.vtbl.GetLinkText, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetHasSourceUri() (bool, error) {
+	return getBool(i.vtbl.GetHasSourceUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetSourceUri() (string, error) {
+	return getString(i.vtbl.GetSourceUri, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetHasSelection() (bool, error) {
+	return getBool(i.vtbl.GetHasSelection, unsafe.Pointer(i))
+}
+
+func (i *ICoreWebView2ContextMenuTarget) GetSelectionText() (string, error) {
+	return getString(i.vtbl.GetSelectionText, unsafe.Pointer(i))
+}
@@ -4,10 +4,10 @@
 package edge
 
 import (
+	"math"
 	"unsafe"
 
 	"github.com/yuaotian/go-win-webview2/internal/w32"
-	"golang.org/x/sys/windows"
 )
 
 type _ICoreWebView2ControllerVtbl struct {
@@ -47,52 +47,46 @@ func (i *ICoreWebView2Controller) AddRef() uintptr {
 }
 
 func (i *ICoreWebView2Controller) GetBounds() (*w32.Rect, error) {
-	var err error
 	var bounds w32.Rect
-	_, _, err = i.vtbl.GetBounds.Call(
+	hr, _, _ := i.vtbl.GetBounds.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&bounds)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "GetBounds"); err != nil {
 		return nil, err
 	}
 	return &bounds, nil
 }
 
 func (i *ICoreWebView2Controller) PutBounds(bounds w32.Rect) error {
-	var err error
-
-	_, _, err = i.vtbl.PutBounds.Call(
+	hr, _, _ := i.vtbl.PutBounds.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&bounds)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "PutBounds"); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (i *ICoreWebView2Controller) AddAcceleratorKeyPressed(eventHandler *ICoreWebView2AcceleratorKeyPressedEventHandler, token *_EventRegistrationToken) error {
-	var err error
-	_, _, err = i.vtbl.AddAcceleratorKeyPressed.Call(
+	hr, _, _ := i.vtbl.AddAcceleratorKeyPressed.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(eventHandler)),
 		uintptr(unsafe.Pointer(&token)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "AddAcceleratorKeyPressed"); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (i *ICoreWebView2Controller) PutIsVisible(isVisible bool) error {
-	var err error
-
-	_, _, err = i.vtbl.PutIsVisible.Call(
+	hr, _, _ := i.vtbl.PutIsVisible.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(boolToInt(isVisible)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "PutIsVisible"); err != nil {
 		return err
 	}
 	return nil
@@ -112,23 +106,56 @@ func (i *ICoreWebView2Controller) GetICoreWebView2Controller2() *ICoreWebView2Co
 }
 
 func (i *ICoreWebView2Controller) NotifyParentWindowPositionChanged() error {
-	var err error
-	_, _, err = i.vtbl.NotifyParentWindowPositionChanged.Call(
+	hr, _, _ := i.vtbl.NotifyParentWindowPositionChanged.Call(
 		uintptr(unsafe.Pointer(i)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "NotifyParentWindowPositionChanged"); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (i *ICoreWebView2Controller) MoveFocus(reason uintptr) error {
-	var err error
-	_, _, err = i.vtbl.MoveFocus.Call(
+	hr, _, _ := i.vtbl.MoveFocus.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(reason),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller", "MoveFocus"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2Controller) GetZoomFactor() (float64, error) {
+	var zoomFactor float64
+	hr, _, _ := i.vtbl.GetZoomFactor.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&zoomFactor)),
+	)
+	if err := newError(hr, "ICoreWebView2Controller", "GetZoomFactor"); err != nil {
+		return 0, err
+	}
+	return zoomFactor, nil
+}
+
+func (i *ICoreWebView2Controller) PutZoomFactor(zoomFactor float64) error {
+	hr, _, _ := i.vtbl.PutZoomFactor.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(math.Float64bits(zoomFactor)),
+	)
+	if err := newError(hr, "ICoreWebView2Controller", "PutZoomFactor"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2Controller) AddZoomFactorChanged(eventHandler *ICoreWebView2ZoomFactorChangedEventHandler, token *_EventRegistrationToken) error {
+	hr, _, _ := i.vtbl.AddZoomFactorChanged.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(eventHandler)),
+		uintptr(unsafe.Pointer(token)),
+	)
+	if err := newError(hr, "ICoreWebView2Controller", "AddZoomFactorChanged"); err != nil {
 		return err
 	}
 	return nil
@@ -5,8 +5,6 @@ package edge
 
 import (
 	"unsafe"
-
-	"golang.org/x/sys/windows"
 )
 
 type _ICoreWebView2Controller2Vtbl struct {
@@ -48,29 +46,27 @@ func (i *ICoreWebView2Controller2) AddRef() uintptr {
 }
 
 func (i *ICoreWebView2Controller2) GetDefaultBackgroundColor() (*COREWEBVIEW2_COLOR, error) {
-	var err error
 	var backgroundColor *COREWEBVIEW2_COLOR
-	_, _, err = i.vtbl.GetDefaultBackgroundColor.Call(
+	hr, _, _ := i.vtbl.GetDefaultBackgroundColor.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&backgroundColor)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller2", "GetDefaultBackgroundColor"); err != nil {
 		return nil, err
 	}
 	return backgroundColor, nil
 }
 
 func (i *ICoreWebView2Controller2) PutDefaultBackgroundColor(backgroundColor COREWEBVIEW2_COLOR) error {
-	var err error
 
 	// Cast to a uint32 as that's what the call is expecting
 	col := *(*uint32)(unsafe.Pointer(&backgroundColor))
 
-	_, _, err = i.vtbl.PutDefaultBackgroundColor.Call(
+	hr, _, _ := i.vtbl.PutDefaultBackgroundColor.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(col),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Controller2", "PutDefaultBackgroundColor"); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,63 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+)
+
+type iCoreWebView2Environment2Vtbl struct {
+	iCoreWebView2EnvironmentVtbl
+	CreateWebResourceRequest ComProc
+}
+
+type iCoreWebView2Environment3Vtbl struct {
+	iCoreWebView2Environment2Vtbl
+	CreateCoreWebView2CompositionController ComProc
+	CreateCoreWebView2PointerInfo           ComProc
+}
+
+type iCoreWebView2Environment4Vtbl struct {
+	iCoreWebView2Environment3Vtbl
+	GetAutomationProviderForWindow ComProc
+}
+
+type iCoreWebView2Environment5Vtbl struct {
+	iCoreWebView2Environment4Vtbl
+	AddBrowserProcessExited    ComProc
+	RemoveBrowserProcessExited ComProc
+}
+
+type iCoreWebView2Environment6Vtbl struct {
+	iCoreWebView2Environment5Vtbl
+	CreatePrintSettings ComProc
+}
+
+type ICoreWebView2Environment6 struct {
+	vtbl *iCoreWebView2Environment6Vtbl
+}
+
+func (e *ICoreWebView2Environment6) CreatePrintSettings() (*ICoreWebView2PrintSettings, error) {
+	var printSettings *ICoreWebView2PrintSettings
+	hr, _, _ := e.vtbl.CreatePrintSettings.Call(
+		uintptr(unsafe.Pointer(e)),
+		uintptr(unsafe.Pointer(&printSettings)),
+	)
+	if err := newError(hr, "ICoreWebView2Environment6", "CreatePrintSettings"); err != nil {
+		return nil, err
+	}
+	return printSettings, nil
+}
+
+func (e *ICoreWebView2Environment) GetICoreWebView2Environment6() *ICoreWebView2Environment6 {
+	var result *ICoreWebView2Environment6
+
+	iidICoreWebView2Environment6 := NewGUID("{e59ee362-acbd-4857-9a8e-d3644d9459a9}")
+	_, _, _ = e.vtbl.QueryInterface.Call(
+		uintptr(unsafe.Pointer(e)),
+		uintptr(unsafe.Pointer(iidICoreWebView2Environment6)),
+		uintptr(unsafe.Pointer(&result)))
+
+	return result
+}
@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+type iCoreWebView2Environment7Vtbl struct {
+	iCoreWebView2Environment6Vtbl
+	GetUserDataFolder ComProc
+}
+
+type iCoreWebView2Environment8Vtbl struct {
+	iCoreWebView2Environment7Vtbl
+	AddProcessInfosChanged    ComProc
+	RemoveProcessInfosChanged ComProc
+	GetProcessInfos           ComProc
+}
+
+type iCoreWebView2Environment9Vtbl struct {
+	iCoreWebView2Environment8Vtbl
+	CreateContextMenuItem ComProc
+}
+
+type ICoreWebView2Environment9 struct {
+	vtbl *iCoreWebView2Environment9Vtbl
+}
+
+// CreateContextMenuItem 创建自定义上下文菜单项，icon 为空时不显示图标
+func (e *ICoreWebView2Environment9) CreateContextMenuItem(label string, icon []byte, kind COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND) (*ICoreWebView2ContextMenuItem, error) {
+	var err error
+	var stream uintptr
+	if len(icon) > 0 {
+		stream, err = w32.SHCreateMemStream(icon)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_label, err := windows.UTF16PtrFromString(label)
+	if err != nil {
+		return nil, err
+	}
+	var item *ICoreWebView2ContextMenuItem
+	hr, _, _ := e.vtbl.CreateContextMenuItem.Call(
+		uintptr(unsafe.Pointer(e)),
+		uintptr(unsafe.Pointer(_label)),
+		stream,
+		uintptr(kind),
+		uintptr(unsafe.Pointer(&item)),
+	)
+	if err := newError(hr, "ICoreWebView2Environment9", "CreateContextMenuItem"); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (e *ICoreWebView2Environment) GetICoreWebView2Environment9() *ICoreWebView2Environment9 {
+	var result *ICoreWebView2Environment9
+
+	iidICoreWebView2Environment9 := NewGUID("{f06f41bf-4b5a-49d8-b9f6-fa16cd29f274}")
+	_, _, _ = e.vtbl.QueryInterface.Call(
+		uintptr(unsafe.Pointer(e)),
+		uintptr(unsafe.Pointer(iidICoreWebView2Environment9)),
+		uintptr(unsafe.Pointer(&result)))
+
+	return result
+}
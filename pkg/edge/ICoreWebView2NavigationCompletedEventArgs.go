@@ -3,6 +3,10 @@
 
 package edge
 
+import (
+	"unsafe"
+)
+
 type _ICoreWebView2NavigationCompletedEventArgsVtbl struct {
 	_IUnknownVtbl
 	GetIsSuccess      ComProc
@@ -18,3 +22,40 @@ func (i *ICoreWebView2NavigationCompletedEventArgs) AddRef() uintptr {
 	r, _, _ := i.vtbl.AddRef.Call()
 	return r
 }
+
+func (i *ICoreWebView2NavigationCompletedEventArgs) GetIsSuccess() (bool, error) {
+	var isSuccess int32
+	hr, _, _ := i.vtbl.GetIsSuccess.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&isSuccess)),
+	)
+	if err := newError(hr, "ICoreWebView2NavigationCompletedEventArgs", "GetIsSuccess"); err != nil {
+		return false, err
+	}
+	return isSuccess != 0, nil
+}
+
+// GetWebErrorStatus 返回导航失败的原因 (COREWEBVIEW2_WEB_ERROR_STATUS)
+func (i *ICoreWebView2NavigationCompletedEventArgs) GetWebErrorStatus() (uint32, error) {
+	var status uint32
+	hr, _, _ := i.vtbl.GetWebErrorStatus.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&status)),
+	)
+	if err := newError(hr, "ICoreWebView2NavigationCompletedEventArgs", "GetWebErrorStatus"); err != nil {
+		return 0, err
+	}
+	return status, nil
+}
+
+func (i *ICoreWebView2NavigationCompletedEventArgs) GetNavigationId() (uint64, error) {
+	var navigationID uint64
+	hr, _, _ := i.vtbl.GetNavigationId.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&navigationID)),
+	)
+	if err := newError(hr, "ICoreWebView2NavigationCompletedEventArgs", "GetNavigationId"); err != nil {
+		return 0, err
+	}
+	return navigationID, nil
+}
@@ -0,0 +1,204 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"math"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ICoreWebView2PrintSettings is the merged print settings class (ICoreWebView2PrintSettings + ICoreWebView2PrintSettings2)
+
+type _ICoreWebView2PrintSettingsVtbl struct {
+	_IUnknownVtbl
+	GetOrientation                ComProc
+	PutOrientation                ComProc
+	GetScaleFactor                ComProc
+	PutScaleFactor                ComProc
+	GetPageWidth                  ComProc
+	PutPageWidth                  ComProc
+	GetPageHeight                 ComProc
+	PutPageHeight                 ComProc
+	GetMarginTop                  ComProc
+	PutMarginTop                  ComProc
+	GetMarginBottom               ComProc
+	PutMarginBottom               ComProc
+	GetMarginLeft                 ComProc
+	PutMarginLeft                 ComProc
+	GetMarginRight                ComProc
+	PutMarginRight                ComProc
+	GetShouldPrintBackgrounds     ComProc
+	PutShouldPrintBackgrounds     ComProc
+	GetShouldPrintSelectionOnly   ComProc
+	PutShouldPrintSelectionOnly   ComProc
+	GetShouldPrintHeaderAndFooter ComProc
+	PutShouldPrintHeaderAndFooter ComProc
+	GetHeaderTitle                ComProc
+	PutHeaderTitle                ComProc
+	GetFooterUri                  ComProc
+	PutFooterUri                  ComProc
+	GetPageRanges                 ComProc
+	PutPageRanges                 ComProc
+	GetPagesPerSide               ComProc
+	PutPagesPerSide               ComProc
+	GetCopies                     ComProc
+	PutCopies                     ComProc
+	GetCollation                  ComProc
+	PutCollation                  ComProc
+	GetColorMode                  ComProc
+	PutColorMode                  ComProc
+	GetDuplex                     ComProc
+	PutDuplex                     ComProc
+	GetMediaSize                  ComProc
+	PutMediaSize                  ComProc
+	GetPrinterName                ComProc
+	PutPrinterName                ComProc
+}
+
+type ICoreWebView2PrintSettings struct {
+	vtbl *_ICoreWebView2PrintSettingsVtbl
+}
+
+func (i *ICoreWebView2PrintSettings) AddRef() uintptr {
+	r, _, _ := i.vtbl.AddRef.Call()
+	return r
+}
+
+func (i *ICoreWebView2PrintSettings) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+func (i *ICoreWebView2PrintSettings) PutOrientation(orientation COREWEBVIEW2_PRINT_ORIENTATION) error {
+	hr, _, _ := i.vtbl.PutOrientation.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(orientation),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutOrientation"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutScaleFactor(scaleFactor float64) error {
+	hr, _, _ := i.vtbl.PutScaleFactor.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(math.Float64bits(scaleFactor)),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutScaleFactor"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutShouldPrintBackgrounds(shouldPrintBackgrounds bool) error {
+	hr, _, _ := i.vtbl.PutShouldPrintBackgrounds.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(boolToInt(shouldPrintBackgrounds)),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutShouldPrintBackgrounds"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutShouldPrintHeaderAndFooter(shouldPrintHeaderAndFooter bool) error {
+	hr, _, _ := i.vtbl.PutShouldPrintHeaderAndFooter.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(boolToInt(shouldPrintHeaderAndFooter)),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutShouldPrintHeaderAndFooter"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutPageRanges(pageRanges string) error {
+	_pageRanges, err := windows.UTF16PtrFromString(pageRanges)
+	if err != nil {
+		return err
+	}
+
+	hr, _, _ := i.vtbl.PutPageRanges.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(_pageRanges)),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutPageRanges"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutPagesPerSide(pagesPerSide int32) error {
+	hr, _, _ := i.vtbl.PutPagesPerSide.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(pagesPerSide),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutPagesPerSide"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutCopies(copies int32) error {
+	hr, _, _ := i.vtbl.PutCopies.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(copies),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutCopies"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutCollation(collation COREWEBVIEW2_PRINT_COLLATION) error {
+	hr, _, _ := i.vtbl.PutCollation.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(collation),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutCollation"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutColorMode(colorMode COREWEBVIEW2_PRINT_COLOR_MODE) error {
+	hr, _, _ := i.vtbl.PutColorMode.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(colorMode),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutColorMode"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutDuplex(duplex COREWEBVIEW2_PRINT_DUPLEX) error {
+	hr, _, _ := i.vtbl.PutDuplex.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(duplex),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutDuplex"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2PrintSettings) PutPrinterName(printerName string) error {
+	_printerName, err := windows.UTF16PtrFromString(printerName)
+	if err != nil {
+		return err
+	}
+
+	hr, _, _ := i.vtbl.PutPrinterName.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(_printerName)),
+	)
+	if err := newError(hr, "ICoreWebView2PrintSettings", "PutPrinterName"); err != nil {
+		return err
+	}
+	return nil
+}
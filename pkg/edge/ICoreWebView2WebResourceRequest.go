@@ -30,17 +30,30 @@ func (i *ICoreWebView2WebResourceRequest) AddRef() uintptr {
 }
 
 func (i *ICoreWebView2WebResourceRequest) GetUri() (string, error) {
-	var err error
 	// Create *uint16 to hold result
 	var _uri *uint16
-	_, _, err = i.vtbl.GetUri.Call(
+	hr, _, _ := i.vtbl.GetUri.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&_uri)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2WebResourceRequest", "GetUri"); err != nil {
 		return "", err
 	} // Get result and cleanup
 	uri := windows.UTF16PtrToString(_uri)
 	windows.CoTaskMemFree(unsafe.Pointer(_uri))
 	return uri, nil
 }
+
+func (i *ICoreWebView2WebResourceRequest) GetMethod() (string, error) {
+	var _method *uint16
+	hr, _, _ := i.vtbl.GetMethod.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&_method)),
+	)
+	if err := newError(hr, "ICoreWebView2WebResourceRequest", "GetMethod"); err != nil {
+		return "", err
+	}
+	method := windows.UTF16PtrToString(_method)
+	windows.CoTaskMemFree(unsafe.Pointer(_method))
+	return method, nil
+}
@@ -5,8 +5,6 @@ package edge
 
 import (
 	"unsafe"
-
-	"golang.org/x/sys/windows"
 )
 
 type _ICoreWebView2WebResourceRequestedEventArgsVtbl struct {
@@ -23,32 +21,59 @@ type ICoreWebView2WebResourceRequestedEventArgs struct {
 }
 
 func (i *ICoreWebView2WebResourceRequestedEventArgs) AddRef() uintptr {
-	r, _, _ := i.vtbl.AddRef.Call()
+	r, _, _ := i.vtbl.AddRef.Call(uintptr(unsafe.Pointer(i)))
 	return r
 }
 
 func (i *ICoreWebView2WebResourceRequestedEventArgs) PutResponse(response *ICoreWebView2WebResourceResponse) error {
-	var err error
-
-	_, _, err = i.vtbl.PutResponse.Call(
+	hr, _, _ := i.vtbl.PutResponse.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(response)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2WebResourceRequestedEventArgs", "PutResponse"); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (i *ICoreWebView2WebResourceRequestedEventArgs) GetRequest() (*ICoreWebView2WebResourceRequest, error) {
-	var err error
 	var request *ICoreWebView2WebResourceRequest
-	_, _, err = i.vtbl.GetRequest.Call(
+	hr, _, _ := i.vtbl.GetRequest.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&request)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2WebResourceRequestedEventArgs", "GetRequest"); err != nil {
 		return nil, err
 	}
 	return request, nil
 }
+
+func (i *ICoreWebView2WebResourceRequestedEventArgs) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+// GetDeferral 推迟事件的完成，调用 Complete 前可以在其他时间设置响应
+func (i *ICoreWebView2WebResourceRequestedEventArgs) GetDeferral() (*ICoreWebView2Deferral, error) {
+	var deferral *ICoreWebView2Deferral
+	hr, _, _ := i.vtbl.GetDeferral.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&deferral)),
+	)
+	if err := newError(hr, "ICoreWebView2WebResourceRequestedEventArgs", "GetDeferral"); err != nil {
+		return nil, err
+	}
+	return deferral, nil
+}
+
+func (i *ICoreWebView2WebResourceRequestedEventArgs) GetResourceContext() (COREWEBVIEW2_WEB_RESOURCE_CONTEXT, error) {
+	var context COREWEBVIEW2_WEB_RESOURCE_CONTEXT
+	hr, _, _ := i.vtbl.GetResourceContext.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&context)),
+	)
+	if err := newError(hr, "ICoreWebView2WebResourceRequestedEventArgs", "GetResourceContext"); err != nil {
+		return 0, err
+	}
+	return context, nil
+}
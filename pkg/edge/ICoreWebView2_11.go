@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+)
+
+type iCoreWebView2_8Vtbl struct {
+	iCoreWebView2_7Vtbl
+	AddIsMutedChanged                   ComProc
+	RemoveIsMutedChanged                ComProc
+	GetIsMuted                          ComProc
+	PutIsMuted                          ComProc
+	AddIsDocumentPlayingAudioChanged    ComProc
+	RemoveIsDocumentPlayingAudioChanged ComProc
+	GetIsDocumentPlayingAudio           ComProc
+}
+
+type iCoreWebView2_9Vtbl struct {
+	iCoreWebView2_8Vtbl
+	AddIsDefaultDownloadDialogOpenChanged    ComProc
+	RemoveIsDefaultDownloadDialogOpenChanged ComProc
+	GetIsDefaultDownloadDialogOpen           ComProc
+	OpenDefaultDownloadDialog                ComProc
+	CloseDefaultDownloadDialog               ComProc
+	GetDefaultDownloadDialogCornerAlignment  ComProc
+	PutDefaultDownloadDialogCornerAlignment  ComProc
+	GetDefaultDownloadDialogMargin           ComProc
+	PutDefaultDownloadDialogMargin           ComProc
+}
+
+type iCoreWebView2_10Vtbl struct {
+	iCoreWebView2_9Vtbl
+	AddBasicAuthenticationRequested    ComProc
+	RemoveBasicAuthenticationRequested ComProc
+}
+
+type iCoreWebView2_11Vtbl struct {
+	iCoreWebView2_10Vtbl
+	CallDevToolsProtocolMethodForSession ComProc
+	AddContextMenuRequested              ComProc
+	RemoveContextMenuRequested           ComProc
+}
+
+type ICoreWebView2_11 struct {
+	vtbl *iCoreWebView2_11Vtbl
+}
+
+func (i *ICoreWebView2_11) AddContextMenuRequested(eventHandler *ICoreWebView2ContextMenuRequestedEventHandler, token *_EventRegistrationToken) error {
+	hr, _, _ := i.vtbl.AddContextMenuRequested.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(eventHandler)),
+		uintptr(unsafe.Pointer(token)),
+	)
+	if err := newError(hr, "ICoreWebView2_11", "AddContextMenuRequested"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2) GetICoreWebView2_11() *ICoreWebView2_11 {
+	var result *ICoreWebView2_11
+
+	iidICoreWebView2_11 := NewGUID("{0be78e56-c193-4051-b943-23b460c08bdb}")
+	_, _, _ = i.vtbl.QueryInterface.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(iidICoreWebView2_11)),
+		uintptr(unsafe.Pointer(&result)))
+
+	return result
+}
@@ -0,0 +1,81 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+)
+
+type iCoreWebView2_12Vtbl struct {
+	iCoreWebView2_11Vtbl
+	AddStatusBarTextChanged    ComProc
+	RemoveStatusBarTextChanged ComProc
+	GetStatusBarText           ComProc
+}
+
+type iCoreWebView2_13Vtbl struct {
+	iCoreWebView2_12Vtbl
+	GetProfile ComProc
+}
+
+type iCoreWebView2_14Vtbl struct {
+	iCoreWebView2_13Vtbl
+	AddServerCertificateErrorDetected    ComProc
+	RemoveServerCertificateErrorDetected ComProc
+	ClearServerCertificateErrorActions   ComProc
+}
+
+type iCoreWebView2_15Vtbl struct {
+	iCoreWebView2_14Vtbl
+	AddFaviconChanged    ComProc
+	RemoveFaviconChanged ComProc
+	GetFaviconUri        ComProc
+	GetFavicon           ComProc
+}
+
+type iCoreWebView2_16Vtbl struct {
+	iCoreWebView2_15Vtbl
+	Print            ComProc
+	ShowPrintUI      ComProc
+	PrintToPdfStream ComProc
+}
+
+type ICoreWebView2_16 struct {
+	vtbl *iCoreWebView2_16Vtbl
+}
+
+func (i *ICoreWebView2_16) Print(printSettings *ICoreWebView2PrintSettings, handler *ICoreWebView2PrintCompletedHandler) error {
+	hr, _, _ := i.vtbl.Print.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(printSettings)),
+		uintptr(unsafe.Pointer(handler)),
+	)
+	if err := newError(hr, "ICoreWebView2_16", "Print"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2_16) ShowPrintUI(printDialogKind COREWEBVIEW2_PRINT_DIALOG_KIND) error {
+	hr, _, _ := i.vtbl.ShowPrintUI.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(printDialogKind),
+	)
+	if err := newError(hr, "ICoreWebView2_16", "ShowPrintUI"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2) GetICoreWebView2_16() *ICoreWebView2_16 {
+	var result *ICoreWebView2_16
+
+	iidICoreWebView2_16 := NewGUID("{0EB34DC9-9F91-41E1-8639-95CD5943906B}")
+	_, _, _ = i.vtbl.QueryInterface.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(iidICoreWebView2_16)),
+		uintptr(unsafe.Pointer(&result)))
+
+	return result
+}
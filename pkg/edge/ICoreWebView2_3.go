@@ -33,13 +33,13 @@ func (i *ICoreWebView2_3) SetVirtualHostNameToFolderMapping(hostName, folderPath
 		return err
 	}
 
-	_, _, err = i.vtbl.SetVirtualHostNameToFolderMapping.Call(
+	hr, _, _ := i.vtbl.SetVirtualHostNameToFolderMapping.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(_hostName)),
 		uintptr(unsafe.Pointer(_folderPath)),
 		uintptr(accessKind),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2_3", "SetVirtualHostNameToFolderMapping"); err != nil {
 		return err
 	}
 
@@ -0,0 +1,68 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type iCoreWebView2_4Vtbl struct {
+	iCoreWebView2_3Vtbl
+	AddFrameCreated        ComProc
+	RemoveFrameCreated     ComProc
+	AddDownloadStarting    ComProc
+	RemoveDownloadStarting ComProc
+}
+
+type iCoreWebView2_5Vtbl struct {
+	iCoreWebView2_4Vtbl
+	AddClientCertificateRequested    ComProc
+	RemoveClientCertificateRequested ComProc
+}
+
+type iCoreWebView2_6Vtbl struct {
+	iCoreWebView2_5Vtbl
+	OpenTaskManagerWindow ComProc
+}
+
+type iCoreWebView2_7Vtbl struct {
+	iCoreWebView2_6Vtbl
+	PrintToPdf ComProc
+}
+
+type ICoreWebView2_7 struct {
+	vtbl *iCoreWebView2_7Vtbl
+}
+
+func (i *ICoreWebView2_7) PrintToPdf(resultFilePath string, printSettings *ICoreWebView2PrintSettings) error {
+	_resultFilePath, err := windows.UTF16PtrFromString(resultFilePath)
+	if err != nil {
+		return err
+	}
+
+	hr, _, _ := i.vtbl.PrintToPdf.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(_resultFilePath)),
+		uintptr(unsafe.Pointer(printSettings)),
+		0,
+	)
+	if err := newError(hr, "ICoreWebView2_7", "PrintToPdf"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2) GetICoreWebView2_7() *ICoreWebView2_7 {
+	var result *ICoreWebView2_7
+
+	iidICoreWebView2_7 := NewGUID("{79c24d83-09a3-45ae-9418-487f32a58740}")
+	_, _, _ = i.vtbl.QueryInterface.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(iidICoreWebView2_7)),
+		uintptr(unsafe.Pointer(&result)))
+
+	return result
+}
@@ -6,6 +6,7 @@ package edge
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"log"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"unsafe"
 
 	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/hresult"
 	"golang.org/x/sys/windows"
 )
 
@@ -25,19 +27,29 @@ type Chromium struct {
 	controller            *ICoreWebView2Controller
 	webview               *ICoreWebView2
 	inited                uintptr
+	initErr               error // 创建环境或控制器失败的原因，在 inited 置位前写入
 	envCompleted          *iCoreWebView2CreateCoreWebView2EnvironmentCompletedHandler
 	controllerCompleted   *iCoreWebView2CreateCoreWebView2ControllerCompletedHandler
 	webMessageReceived    *iCoreWebView2WebMessageReceivedEventHandler
 	permissionRequested   *iCoreWebView2PermissionRequestedEventHandler
 	webResourceRequested  *iCoreWebView2WebResourceRequestedEventHandler
 	acceleratorKeyPressed *ICoreWebView2AcceleratorKeyPressedEventHandler
+	navigationStarting    *ICoreWebView2NavigationStartingEventHandler
 	navigationCompleted   *ICoreWebView2NavigationCompletedEventHandler
+	printCompleted        *ICoreWebView2PrintCompletedHandler
+	capturePreview        *ICoreWebView2CapturePreviewCompletedHandler
+	zoomFactorChanged     *ICoreWebView2ZoomFactorChangedEventHandler
+	contextMenuRequested  *ICoreWebView2ContextMenuRequestedEventHandler
+	customItemSelected    *ICoreWebView2CustomItemSelectedEventHandler
 
 	environment *ICoreWebView2Environment
 
 	// Settings
 	DataPath string
 
+	// 默认背景色，在控制器创建前设置时会在创建后应用
+	backgroundColor *COREWEBVIEW2_COLOR
+
 	// permissions
 	permissions      map[CoreWebView2PermissionKind]CoreWebView2PermissionState
 	globalPermission *CoreWebView2PermissionState
@@ -48,6 +60,24 @@ type Chromium struct {
 	NavigationCompletedCallback  func(sender *ICoreWebView2, args *ICoreWebView2NavigationCompletedEventArgs)
 	AcceleratorKeyCallback       func(uint) bool
 	NavigationStartingCallback   func()
+	// NavigationStartedCallback 在导航开始时以导航 ID 和 URL 调用，ID 与 NavigationCompleted 的参数对应
+	NavigationStartedCallback    func(navigationID uint64, uri string)
+	ZoomFactorChangedCallback    func(zoomFactor float64)
+	ContextMenuRequestedCallback func(sender *ICoreWebView2, args *ICoreWebView2ContextMenuRequestedEventArgs)
+	CustomItemSelectedCallback   func(item *ICoreWebView2ContextMenuItem)
+
+	// 打印完成回调，仅在 Print 调用期间有效
+	printCallback func(errorCode uintptr, status COREWEBVIEW2_PRINT_STATUS)
+
+	// 截图完成回调和目标流，仅在 CapturePreview 调用期间有效
+	captureCallback func(data []byte, err error)
+	captureStream   uintptr
+	// 等待上一次截图完成的截图请求
+	captureQueue []captureRequest
+
+	// 等待完成的 ExecuteScript 调用，保持引用直到 WebView2 调用完成回调
+	scriptCalls   map[*executeScriptCall]bool
+	scriptCallsMu sync.Mutex
 
 	// 状态管理
 	state struct {
@@ -88,34 +118,57 @@ func NewChromium() *Chromium {
 	e.permissionRequested = newICoreWebView2PermissionRequestedEventHandler(e)
 	e.webResourceRequested = newICoreWebView2WebResourceRequestedEventHandler(e)
 	e.acceleratorKeyPressed = newICoreWebView2AcceleratorKeyPressedEventHandler(e)
+	e.navigationStarting = newICoreWebView2NavigationStartingEventHandler(e)
 	e.navigationCompleted = newICoreWebView2NavigationCompletedEventHandler(e)
+	e.printCompleted = newICoreWebView2PrintCompletedHandler(e)
+	e.capturePreview = newICoreWebView2CapturePreviewCompletedHandler(e)
+	e.zoomFactorChanged = newICoreWebView2ZoomFactorChangedEventHandler(e)
+	e.contextMenuRequested = newICoreWebView2ContextMenuRequestedEventHandler(e)
+	e.customItemSelected = newICoreWebView2CustomItemSelectedEventHandler(e)
 	e.permissions = make(map[CoreWebView2PermissionKind]CoreWebView2PermissionState)
 
 	return e
 }
 
-func (e *Chromium) Embed(hwnd uintptr) bool {
+// Embed 在窗口中创建 WebView2 并等待创建完成，失败时返回 *Error 或加载 WebView2Loader 的错误
+func (e *Chromium) Embed(hwnd uintptr) error {
 	e.hwnd = hwnd
+	if comInitErr != nil {
+		return comInitErr
+	}
 
 	dataPath := e.DataPath
 	if dataPath == "" {
 		currentExePath := make([]uint16, windows.MAX_PATH)
 		_, err := windows.GetModuleFileName(windows.Handle(0), &currentExePath[0], windows.MAX_PATH)
 		if err != nil {
-			// What to do here?
-			return false
+			return fmt.Errorf("webview2: failed to get executable path: %w", err)
 		}
 		currentExeName := filepath.Base(windows.UTF16ToString(currentExePath))
 		dataPath = filepath.Join(os.Getenv("AppData"), currentExeName)
 	}
 
+	if e.backgroundColor != nil {
+		// 通过环境变量让 WebView2 在创建控制器时即使用该背景色，避免首帧白屏。
+		// 环境变量是进程全局的，创建完成后恢复，之后创建的窗口不会继承；控制器创建后再单独设置背景色
+		const name = "WEBVIEW2_DEFAULT_BACKGROUND_COLOR"
+		c := e.backgroundColor
+		previous, hadPrevious := os.LookupEnv(name)
+		_ = os.Setenv(name, fmt.Sprintf("%02X%02X%02X%02X", c.A, c.R, c.G, c.B))
+		defer func() {
+			if hadPrevious {
+				_ = os.Setenv(name, previous)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}()
+	}
+
 	res, err := createCoreWebView2EnvironmentWithOptions(nil, windows.StringToUTF16Ptr(dataPath), 0, e.envCompleted)
 	if err != nil {
-		log.Printf("Error calling Webview2Loader: %v", err)
-		return false
-	} else if res != 0 {
-		log.Printf("Result: %08x", res)
-		return false
+		return fmt.Errorf("webview2: failed to load WebView2Loader: %w", err)
+	} else if err := newError(res, "", "CreateCoreWebView2EnvironmentWithOptions"); err != nil {
+		return err
 	}
 	var msg w32.Msg
 	for {
@@ -129,32 +182,54 @@ func (e *Chromium) Embed(hwnd uintptr) bool {
 			0,
 		)
 		if r == 0 {
-			break
+			return errors.New("webview2: message loop ended before WebView2 was created")
 		}
 		_, _, _ = w32.User32TranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
 		_, _, _ = w32.User32DispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
 	}
-	e.Init("window.external={invoke:s=>window.chrome.webview.postMessage(s)}")
-	return true
+	if e.initErr != nil {
+		return e.initErr
+	}
+	return e.Init("window.external={invoke:s=>window.chrome.webview.postMessage(s)}")
 }
 
 // 导航URL
-func (e *Chromium) Navigate(url string) {
-	_, _, _ = e.webview.vtbl.Navigate.Call(
+func (e *Chromium) Navigate(url string) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
+	_url, err := windows.UTF16PtrFromString(url)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := e.webview.vtbl.Navigate.Call(
 		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(url))),
+		uintptr(unsafe.Pointer(_url)),
 	)
+	return newError(hr, "ICoreWebView2", "Navigate")
 }
 
 // NavigateToString 将 HTML 内容注入到 WebView 中
-func (e *Chromium) NavigateToString(htmlContent string) {
-	_, _, _ = e.webview.vtbl.NavigateToString.Call(
+func (e *Chromium) NavigateToString(htmlContent string) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
+	_html, err := windows.UTF16PtrFromString(htmlContent)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := e.webview.vtbl.NavigateToString.Call(
 		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(htmlContent))),
+		uintptr(unsafe.Pointer(_html)),
 	)
+	return newError(hr, "ICoreWebView2", "NavigateToString")
 }
 
-func (e *Chromium) Init(script string) {
+// Init 注册在每个文档创建时执行的脚本
+func (e *Chromium) Init(script string) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
 	baseScript := `console.log('hello world')`
 
 	// 合并脚本
@@ -163,24 +238,102 @@ func (e *Chromium) Init(script string) {
 		fullScript += ";" + script
 	}
 
-	_, _, _ = e.webview.vtbl.AddScriptToExecuteOnDocumentCreated.Call(
+	_script, err := windows.UTF16PtrFromString(fullScript)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := e.webview.vtbl.AddScriptToExecuteOnDocumentCreated.Call(
 		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(fullScript))),
+		uintptr(unsafe.Pointer(_script)),
 		0,
 	)
+	return newError(hr, "ICoreWebView2", "AddScriptToExecuteOnDocumentCreated")
 }
 
-func (e *Chromium) Eval(script string) {
+// Eval 执行脚本，不等待结果
+func (e *Chromium) Eval(script string) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
 	_script, err := windows.UTF16PtrFromString(script)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
-	_, _, _ = e.webview.vtbl.ExecuteScript.Call(
+	hr, _, _ := e.webview.vtbl.ExecuteScript.Call(
 		uintptr(unsafe.Pointer(e.webview)),
 		uintptr(unsafe.Pointer(_script)),
 		0,
 	)
+	return newError(hr, "ICoreWebView2", "ExecuteScript")
+}
+
+// ExecuteScript 执行脚本，完成后在主线程调用 callback。
+// result 是脚本最后一个表达式的值序列化后的 JSON，无法序列化时为 null
+func (e *Chromium) ExecuteScript(script string, callback func(result string, err error)) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
+	_script, err := windows.UTF16PtrFromString(script)
+	if err != nil {
+		return err
+	}
+
+	call := &executeScriptCall{e: e, callback: callback}
+	call.handler = newICoreWebView2ExecuteScriptCompletedHandler(call)
+	e.scriptCallsMu.Lock()
+	if e.scriptCalls == nil {
+		e.scriptCalls = make(map[*executeScriptCall]bool)
+	}
+	e.scriptCalls[call] = true
+	e.scriptCallsMu.Unlock()
+
+	hr, _, _ := e.webview.vtbl.ExecuteScript.Call(
+		uintptr(unsafe.Pointer(e.webview)),
+		uintptr(unsafe.Pointer(_script)),
+		uintptr(unsafe.Pointer(call.handler)),
+	)
+	if err := newError(hr, "ICoreWebView2", "ExecuteScript"); err != nil {
+		e.scriptCallsMu.Lock()
+		delete(e.scriptCalls, call)
+		e.scriptCallsMu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// executeScriptCall 是一次 ExecuteScript 调用的完成回调
+type executeScriptCall struct {
+	e        *Chromium
+	handler  *ICoreWebView2ExecuteScriptCompletedHandler
+	callback func(result string, err error)
+}
+
+func (c *executeScriptCall) QueryInterface(_, _ uintptr) uintptr {
+	return 0
+}
+
+func (c *executeScriptCall) AddRef() uintptr {
+	return 1
+}
+
+func (c *executeScriptCall) Release() uintptr {
+	return 1
+}
+
+func (c *executeScriptCall) ExecuteScriptCompleted(errorCode uintptr, resultObjectAsJson *uint16) uintptr {
+	c.e.scriptCallsMu.Lock()
+	delete(c.e.scriptCalls, c)
+	c.e.scriptCallsMu.Unlock()
+	if c.callback == nil {
+		return 0
+	}
+	if err := newError(errorCode, "ICoreWebView2", "ExecuteScript"); err != nil {
+		c.callback("", err)
+		return 0
+	}
+	c.callback(w32.Utf16PtrToString(resultObjectAsJson), nil)
+	return 0
 }
 
 func (e *Chromium) Show() error {
@@ -204,57 +357,93 @@ func (e *Chromium) Release() uintptr {
 }
 
 func (e *Chromium) EnvironmentCompleted(res uintptr, env *ICoreWebView2Environment) uintptr {
-	if int64(res) < 0 {
-		log.Fatalf("Creating environment failed with %08x", res)
+	if err := newError(res, "", "CreateCoreWebView2EnvironmentWithOptions"); err != nil {
+		e.failInit(err)
+		return 0
 	}
 	_, _, _ = env.vtbl.AddRef.Call(uintptr(unsafe.Pointer(env)))
 	e.environment = env
 
-	_, _, _ = env.vtbl.CreateCoreWebView2Controller.Call(
+	hr, _, _ := env.vtbl.CreateCoreWebView2Controller.Call(
 		uintptr(unsafe.Pointer(env)),
 		e.hwnd,
 		uintptr(unsafe.Pointer(e.controllerCompleted)),
 	)
+	if err := newError(hr, "ICoreWebView2Environment", "CreateCoreWebView2Controller"); err != nil {
+		e.failInit(err)
+	}
 	return 0
 }
 
 func (e *Chromium) CreateCoreWebView2ControllerCompleted(res uintptr, controller *ICoreWebView2Controller) uintptr {
-	if int64(res) < 0 {
-		log.Fatalf("Creating controller failed with %08x", res)
+	if err := newError(res, "ICoreWebView2Environment", "CreateCoreWebView2Controller"); err != nil {
+		e.failInit(err)
+		return 0
 	}
 	_, _, _ = controller.vtbl.AddRef.Call(uintptr(unsafe.Pointer(controller)))
 	e.controller = controller
 
-	var token _EventRegistrationToken
-	_, _, _ = controller.vtbl.GetCoreWebView2.Call(
+	hr, _, _ := controller.vtbl.GetCoreWebView2.Call(
 		uintptr(unsafe.Pointer(controller)),
 		uintptr(unsafe.Pointer(&e.webview)),
 	)
+	if err := newError(hr, "ICoreWebView2Controller", "GetCoreWebView2"); err != nil {
+		e.failInit(err)
+		return 0
+	}
+	if e.webview == nil {
+		e.failInit(&Error{HResult: hresult.E_POINTER, Interface: "ICoreWebView2Controller", Method: "GetCoreWebView2", Description: "no webview returned"})
+		return 0
+	}
 	_, _, _ = e.webview.vtbl.AddRef.Call(
 		uintptr(unsafe.Pointer(e.webview)),
 	)
-	_, _, _ = e.webview.vtbl.AddWebMessageReceived.Call(
-		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(e.webMessageReceived)),
-		uintptr(unsafe.Pointer(&token)),
-	)
-	_, _, _ = e.webview.vtbl.AddPermissionRequested.Call(
-		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(e.permissionRequested)),
-		uintptr(unsafe.Pointer(&token)),
-	)
-	_, _, _ = e.webview.vtbl.AddWebResourceRequested.Call(
-		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(e.webResourceRequested)),
-		uintptr(unsafe.Pointer(&token)),
-	)
-	_, _, _ = e.webview.vtbl.AddNavigationCompleted.Call(
-		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(e.navigationCompleted)),
-		uintptr(unsafe.Pointer(&token)),
-	)
 
-	_ = e.controller.AddAcceleratorKeyPressed(e.acceleratorKeyPressed, &token)
+	var token _EventRegistrationToken
+	handlers := []struct {
+		method  string
+		add     ComProc
+		handler unsafe.Pointer
+	}{
+		{"AddWebMessageReceived", e.webview.vtbl.AddWebMessageReceived, unsafe.Pointer(e.webMessageReceived)},
+		{"AddPermissionRequested", e.webview.vtbl.AddPermissionRequested, unsafe.Pointer(e.permissionRequested)},
+		{"AddWebResourceRequested", e.webview.vtbl.AddWebResourceRequested, unsafe.Pointer(e.webResourceRequested)},
+		{"AddNavigationStarting", e.webview.vtbl.AddNavigationStarting, unsafe.Pointer(e.navigationStarting)},
+		{"AddNavigationCompleted", e.webview.vtbl.AddNavigationCompleted, unsafe.Pointer(e.navigationCompleted)},
+	}
+	for _, h := range handlers {
+		hr, _, _ := h.add.Call(
+			uintptr(unsafe.Pointer(e.webview)),
+			uintptr(h.handler),
+			uintptr(unsafe.Pointer(&token)),
+		)
+		if err := newError(hr, "ICoreWebView2", h.method); err != nil {
+			e.failInit(err)
+			return 0
+		}
+	}
+
+	if err := e.controller.AddAcceleratorKeyPressed(e.acceleratorKeyPressed, &token); err != nil {
+		e.failInit(err)
+		return 0
+	}
+	if err := e.controller.AddZoomFactorChanged(e.zoomFactorChanged, &token); err != nil {
+		e.failInit(err)
+		return 0
+	}
+	if webview11 := e.webview.GetICoreWebView2_11(); webview11 != nil {
+		// 旧版运行时没有 ICoreWebView2_11，此时不支持自定义右键菜单
+		if err := webview11.AddContextMenuRequested(e.contextMenuRequested, &token); err != nil {
+			e.failInit(err)
+			return 0
+		}
+	}
+
+	if e.backgroundColor != nil {
+		if err := e.putBackgroundColor(*e.backgroundColor); err != nil {
+			log.Printf("Warning: Failed to set default background color: %v", err)
+		}
+	}
 
 	atomic.StoreUintptr(&e.inited, 1)
 
@@ -265,6 +454,12 @@ func (e *Chromium) CreateCoreWebView2ControllerCompleted(res uintptr, controller
 	return 0
 }
 
+// failInit 记录创建失败的原因并结束 Embed 中的等待
+func (e *Chromium) failInit(err error) {
+	e.initErr = err
+	atomic.StoreUintptr(&e.inited, 1)
+}
+
 func (e *Chromium) MessageReceived(sender *ICoreWebView2, args *iCoreWebView2WebMessageReceivedEventArgs) uintptr {
 	var message *uint16
 	_, _, _ = args.vtbl.TryGetWebMessageAsString.Call(
@@ -316,7 +511,8 @@ func (e *Chromium) PermissionRequested(_ *ICoreWebView2, args *iCoreWebView2Perm
 func (e *Chromium) WebResourceRequested(sender *ICoreWebView2, args *ICoreWebView2WebResourceRequestedEventArgs) uintptr {
 	req, err := args.GetRequest()
 	if err != nil {
-		log.Fatal(err)
+		log.Printf("Warning: Failed to get web resource request: %v", err)
+		return 0
 	}
 	if e.WebResourceRequestedCallback != nil {
 		e.WebResourceRequestedCallback(req, args)
@@ -324,11 +520,12 @@ func (e *Chromium) WebResourceRequested(sender *ICoreWebView2, args *ICoreWebVie
 	return 0
 }
 
-func (e *Chromium) AddWebResourceRequestedFilter(filter string, ctx COREWEBVIEW2_WEB_RESOURCE_CONTEXT) {
-	err := e.webview.AddWebResourceRequestedFilter(filter, ctx)
-	if err != nil {
-		log.Fatal(err)
-	}
+func (e *Chromium) AddWebResourceRequestedFilter(filter string, ctx COREWEBVIEW2_WEB_RESOURCE_CONTEXT) error {
+	return e.webview.AddWebResourceRequestedFilter(filter, ctx)
+}
+
+func (e *Chromium) RemoveWebResourceRequestedFilter(filter string, ctx COREWEBVIEW2_WEB_RESOURCE_CONTEXT) error {
+	return e.webview.RemoveWebResourceRequestedFilter(filter, ctx)
 }
 
 func (e *Chromium) Environment() *ICoreWebView2Environment {
@@ -364,6 +561,92 @@ func (e *Chromium) GetController() *ICoreWebView2Controller {
 	return e.controller
 }
 
+// Source 返回当前文档的 URL
+func (e *Chromium) Source() string {
+	if e.webview == nil {
+		return ""
+	}
+	source, _ := e.webview.GetSource()
+	return source
+}
+
+// SetBackgroundColor 设置 WebView 的默认背景色，该颜色在导航之间保持不变。
+// WebView2 只支持完全透明 (A=0) 或完全不透明 (A=255) 的背景，其它透明度会被取整。
+func (e *Chromium) SetBackgroundColor(color COREWEBVIEW2_COLOR) error {
+	if color.A != 0 && color.A != 255 {
+		if color.A < 128 {
+			color.A = 0
+		} else {
+			color.A = 255
+		}
+	}
+	e.backgroundColor = &color
+	if e.controller == nil {
+		// 控制器创建完成后应用
+		return nil
+	}
+	return e.putBackgroundColor(color)
+}
+
+func (e *Chromium) putBackgroundColor(color COREWEBVIEW2_COLOR) error {
+	controller2 := e.controller.GetICoreWebView2Controller2()
+	if controller2 == nil {
+		return errors.New("background color is not supported by the installed WebView2 runtime")
+	}
+	return controller2.PutDefaultBackgroundColor(color)
+}
+
+// ZoomFactor 返回当前缩放比例
+func (e *Chromium) ZoomFactor() (float64, error) {
+	if e.controller == nil {
+		return 0, errors.New("webview not initialized")
+	}
+	return e.controller.GetZoomFactor()
+}
+
+// SetZoomFactor 设置缩放比例
+func (e *Chromium) SetZoomFactor(zoomFactor float64) error {
+	if e.controller == nil {
+		return errors.New("webview not initialized")
+	}
+	return e.controller.PutZoomFactor(zoomFactor)
+}
+
+// ZoomFactorChanged 在缩放比例变化时由 WebView2 调用（包括 Ctrl+滚轮 等用户操作）
+func (e *Chromium) ZoomFactorChanged(sender *ICoreWebView2Controller, _ uintptr) uintptr {
+	if e.ZoomFactorChangedCallback == nil {
+		return 0
+	}
+	zoomFactor, err := sender.GetZoomFactor()
+	if err != nil {
+		return 0
+	}
+	e.ZoomFactorChangedCallback(zoomFactor)
+	return 0
+}
+
+func (e *Chromium) NavigationStarting(sender *ICoreWebView2, args *ICoreWebView2NavigationStartingEventArgs) uintptr {
+	e.updateState(func(c *Chromium) {
+		c.state.isLoading = true
+	})
+
+	if e.NavigationStartingCallback != nil {
+		e.NavigationStartingCallback()
+	}
+	if e.NavigationStartedCallback != nil {
+		var navigationID uint64
+		args.GetNavigationId(&navigationID)
+		var _uri *uint16
+		var uri string
+		if args.GetUri(&_uri) == 0 && _uri != nil {
+			uri = w32.Utf16PtrToString(_uri)
+			windows.CoTaskMemFree(unsafe.Pointer(_uri))
+		}
+		e.NavigationStartedCallback(navigationID, uri)
+	}
+	return 0
+}
+
 func (e *Chromium) NavigationCompleted(sender *ICoreWebView2, args *ICoreWebView2NavigationCompletedEventArgs) uintptr {
 	e.updateState(func(c *Chromium) {
 		c.state.isLoading = false
@@ -398,32 +681,171 @@ func (e *Chromium) PrintToPDF(path string) error {
 		return errors.New("webview not initialized")
 	}
 
-	// 转换文件路径为 UTF16
-	_path, err := windows.UTF16PtrFromString(path)
-	if err != nil {
-		return err
+	webview7 := e.webview.GetICoreWebView2_7()
+	if webview7 == nil {
+		return errors.New("PrintToPdf is not supported by the installed WebView2 runtime")
 	}
 
-	// 调用 WebView2 的 PrintToPdf 方法
-	_, _, err = e.webview.vtbl.PrintToPdf.Call(
-		uintptr(unsafe.Pointer(e.webview)),
-		uintptr(unsafe.Pointer(_path)),
-		0, // 使用默认打印设置
-	)
+	// 使用默认打印设置
+	return webview7.PrintToPdf(path, nil)
+}
 
-	if err != windows.ERROR_SUCCESS {
+// CreatePrintSettings 创建一个新的打印设置对象
+func (e *Chromium) CreatePrintSettings() (*ICoreWebView2PrintSettings, error) {
+	if e.environment == nil {
+		return nil, errors.New("webview not initialized")
+	}
+
+	environment6 := e.environment.GetICoreWebView2Environment6()
+	if environment6 == nil {
+		return nil, errors.New("print settings are not supported by the installed WebView2 runtime")
+	}
+	return environment6.CreatePrintSettings()
+}
+
+// ErrPrintInProgress 表示已有打印任务正在进行
+var ErrPrintInProgress = errors.New("a print job is already in progress")
+
+// Print 使用给定的打印设置静默打印当前页面，打印完成后调用 callback。已有打印任务时返回 ErrPrintInProgress
+func (e *Chromium) Print(settings *ICoreWebView2PrintSettings, callback func(errorCode uintptr, status COREWEBVIEW2_PRINT_STATUS)) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
+	if e.printCallback != nil {
+		return ErrPrintInProgress
+	}
+
+	webview16 := e.webview.GetICoreWebView2_16()
+	if webview16 == nil {
+		return errors.New("Print is not supported by the installed WebView2 runtime")
+	}
+
+	e.printCallback = callback
+	if err := webview16.Print(settings, e.printCompleted); err != nil {
+		e.printCallback = nil
 		return err
 	}
+	return nil
+}
+
+// ShowPrintUI 打开浏览器或系统的打印对话框
+func (e *Chromium) ShowPrintUI(kind COREWEBVIEW2_PRINT_DIALOG_KIND) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
 
+	webview16 := e.webview.GetICoreWebView2_16()
+	if webview16 == nil {
+		return errors.New("ShowPrintUI is not supported by the installed WebView2 runtime")
+	}
+	return webview16.ShowPrintUI(kind)
+}
+
+// PrintCompleted 在 Print 调用完成时由 WebView2 调用
+func (e *Chromium) PrintCompleted(errorCode uintptr, status COREWEBVIEW2_PRINT_STATUS) uintptr {
+	callback := e.printCallback
+	e.printCallback = nil
+	if callback != nil {
+		callback(errorCode, status)
+	}
+	return 0
+}
+
+// captureRequest 是排队等待的截图请求
+type captureRequest struct {
+	format   COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT
+	callback func(data []byte, err error)
+}
+
+// CapturePreview 截取 WebView 当前显示的内容，完成后在主线程调用 callback。
+// 上一次截图未完成时排队，依次执行
+func (e *Chromium) CapturePreview(format COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT, callback func(data []byte, err error)) error {
+	if e.webview == nil {
+		return errors.New("webview not initialized")
+	}
+	if e.captureCallback != nil || len(e.captureQueue) > 0 {
+		e.captureQueue = append(e.captureQueue, captureRequest{format: format, callback: callback})
+		return nil
+	}
+	return e.startCapture(format, callback)
+}
+
+func (e *Chromium) startCapture(format COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT, callback func(data []byte, err error)) error {
+	stream, err := w32.NewMemoryStream()
+	if err != nil {
+		return err
+	}
+	e.captureCallback = callback
+	e.captureStream = stream
+	if err := e.webview.CapturePreview(format, stream, e.capturePreview); err != nil {
+		e.captureCallback = nil
+		e.captureStream = 0
+		w32.ReleaseStream(stream)
+		return err
+	}
 	return nil
 }
 
-// 添加打印相关的回调处理
-func (e *Chromium) handlePrintCompleted(success bool, errorCode int) {
-	// 处理打印成件
-	if !success {
-		log.Printf("Print failed with error code: %d", errorCode)
+// CapturePreviewCompleted 在 CapturePreview 调用完成时由 WebView2 调用
+func (e *Chromium) CapturePreviewCompleted(errorCode uintptr) uintptr {
+	callback, stream := e.captureCallback, e.captureStream
+	e.captureCallback, e.captureStream = nil, 0
+	if callback != nil {
+		if err := newError(errorCode, "ICoreWebView2", "CapturePreview"); err != nil {
+			callback(nil, err)
+		} else {
+			callback(w32.StreamBytes(stream))
+		}
+	}
+	w32.ReleaseStream(stream)
+
+	// 开始下一个排队的截图，callback 中可能已经开始了新的截图
+	for len(e.captureQueue) > 0 && e.captureCallback == nil {
+		next := e.captureQueue[0]
+		e.captureQueue = e.captureQueue[1:]
+		if err := e.startCapture(next.format, next.callback); err != nil {
+			next.callback(nil, err)
+		}
+	}
+	return 0
+}
+
+func (e *Chromium) ContextMenuRequested(sender *ICoreWebView2, args *ICoreWebView2ContextMenuRequestedEventArgs) uintptr {
+	if e.ContextMenuRequestedCallback != nil {
+		e.ContextMenuRequestedCallback(sender, args)
 	}
+	return 0
+}
+
+func (e *Chromium) CustomItemSelected(sender *ICoreWebView2ContextMenuItem, _ uintptr) uintptr {
+	if e.CustomItemSelectedCallback != nil {
+		e.CustomItemSelectedCallback(sender)
+	}
+	return 0
+}
+
+// CreateContextMenuItem 创建自定义上下文菜单项，菜单项被选中时调用 CustomItemSelectedCallback
+func (e *Chromium) CreateContextMenuItem(label string, icon []byte, kind COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND) (*ICoreWebView2ContextMenuItem, error) {
+	if e.environment == nil {
+		return nil, errors.New("webview not initialized")
+	}
+
+	environment9 := e.environment.GetICoreWebView2Environment9()
+	if environment9 == nil {
+		return nil, errors.New("custom context menu items are not supported by the installed WebView2 runtime")
+	}
+	item, err := environment9.CreateContextMenuItem(label, icon, kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind != COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SEPARATOR && kind != COREWEBVIEW2_CONTEXT_MENU_ITEM_KIND_SUBMENU {
+		var token _EventRegistrationToken
+		if err := item.AddCustomItemSelected(e.customItemSelected, &token); err != nil {
+			item.Release()
+			return nil, err
+		}
+	}
+	return item, nil
 }
 
 // DisableContextMenu 禁用上下文菜单
@@ -501,7 +923,9 @@ func (e *Chromium) HandleWebMessage(message string) {
 			log.Printf("Failed to parse navigate payload: %v", err)
 			return
 		}
-		e.Navigate(payload.URL)
+		if err := e.Navigate(payload.URL); err != nil {
+			log.Printf("Warning: Failed to navigate: %v", err)
+		}
 
 	case "stateChange":
 		var payload struct {
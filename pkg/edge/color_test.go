@@ -0,0 +1,80 @@
+package edge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want COREWEBVIEW2_COLOR
+	}{
+		// 十六进制
+		{"#fff", COREWEBVIEW2_COLOR{A: 255, R: 255, G: 255, B: 255}},
+		{"#1234", COREWEBVIEW2_COLOR{A: 0x44, R: 0x11, G: 0x22, B: 0x33}},
+		{"#1e90ff", COREWEBVIEW2_COLOR{A: 255, R: 0x1e, G: 0x90, B: 0xff}},
+		{"#1E90FF80", COREWEBVIEW2_COLOR{A: 0x80, R: 0x1e, G: 0x90, B: 0xff}},
+		{"  #000000  ", COREWEBVIEW2_COLOR{A: 255}},
+
+		// rgb() 和 rgba()
+		{"rgb(30, 144, 255)", COREWEBVIEW2_COLOR{A: 255, R: 30, G: 144, B: 255}},
+		{"RGB(30,144,255)", COREWEBVIEW2_COLOR{A: 255, R: 30, G: 144, B: 255}},
+		{"rgba(30, 144, 255, 0.5)", COREWEBVIEW2_COLOR{A: 128, R: 30, G: 144, B: 255}},
+		{"rgba(0, 0, 0, 0)", COREWEBVIEW2_COLOR{}},
+		{"rgb(30 144 255)", COREWEBVIEW2_COLOR{A: 255, R: 30, G: 144, B: 255}},
+		{"rgb(30 144 255 / 25%)", COREWEBVIEW2_COLOR{A: 64, R: 30, G: 144, B: 255}},
+		{"rgb(100% 50% 0%)", COREWEBVIEW2_COLOR{A: 255, R: 255, G: 128, B: 0}},
+		{"rgb(12.4, 12.5, 12.6)", COREWEBVIEW2_COLOR{A: 255, R: 12, G: 13, B: 13}},
+		// 超出范围的值被截断
+		{"rgba(300, -20, 128, 2)", COREWEBVIEW2_COLOR{A: 255, R: 255, G: 0, B: 128}},
+
+		// alpha 四舍五入到最近的整数
+		{"rgba(0, 0, 0, 0.1)", COREWEBVIEW2_COLOR{A: 26}},
+		{"rgba(0, 0, 0, 0.002)", COREWEBVIEW2_COLOR{A: 1}},
+		{"rgba(0, 0, 0, 0.001)", COREWEBVIEW2_COLOR{A: 0}},
+		{"rgba(0, 0, 0, 0.999)", COREWEBVIEW2_COLOR{A: 255}},
+		{"rgb(0 0 0 / 33.3%)", COREWEBVIEW2_COLOR{A: 85}},
+
+		// 颜色名称
+		{"transparent", COREWEBVIEW2_COLOR{}},
+		{"White", COREWEBVIEW2_COLOR{A: 255, R: 255, G: 255, B: 255}},
+		{"rebeccapurple", COREWEBVIEW2_COLOR{A: 255, R: 0x66, G: 0x33, B: 0x99}},
+		{"darkgrey", COREWEBVIEW2_COLOR{A: 255, R: 0xa9, G: 0xa9, B: 0xa9}},
+	}
+	for _, tt := range tests {
+		got, err := ParseColor(tt.in)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorInvalid(t *testing.T) {
+	tests := []struct {
+		in, err string
+	}{
+		{"", "empty string"},
+		{"   ", "empty string"},
+		{"#", "3, 4, 6 or 8 digits"},
+		{"#12345", "3, 4, 6 or 8 digits"},
+		{"#ggg", "invalid syntax"},
+		{"rgb(1, 2, 3", "missing ')'"},
+		{"rgb(1, 2)", "expected 3 color channels"},
+		{"rgba(1, 2, 3, 4, 5)", "expected 3 color channels"},
+		{"rgb(1 2 3 4)", "expected 3 color channels"},
+		{"rgb(red, 2, 3)", `bad component "red"`},
+		{"rgba(1, 2, 3, half)", `bad component "half"`},
+		{"notacolor", "unknown color name"},
+	}
+	for _, tt := range tests {
+		_, err := ParseColor(tt.in)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("ParseColor(%q) error = %v, want %q", tt.in, err, tt.err)
+		}
+	}
+}
@@ -18,11 +18,14 @@ func init() {
 	runtime.LockOSThread()
 
 	r, _, _ := w32.Ole32CoInitializeEx.Call(0, 2)
-	if int(r) < 0 {
-		log.Printf("Warning: CoInitializeEx call failed: E=%08x", r)
+	if comInitErr = newError(r, "", "CoInitializeEx"); comInitErr != nil {
+		log.Printf("Warning: %v", comInitErr)
 	}
 }
 
+// comInitErr 是主线程初始化 COM 的结果，失败时 Embed 直接返回该错误
+var comInitErr error
+
 type _EventRegistrationToken struct {
 	Value int64
 }
@@ -158,12 +161,6 @@ type iCoreWebView2Vtbl struct {
 	// 窗口关闭处理
 	AddWindowCloseRequested    ComProc
 	RemoveWindowCloseRequested ComProc
-
-	// 打印功能
-	PrintToPdf           ComProc
-	ShowPrintUI          ComProc
-	AddPrintCompleted    ComProc
-	RemovePrintCompleted ComProc
 }
 
 type ICoreWebView2 struct {
@@ -171,13 +168,12 @@ type ICoreWebView2 struct {
 }
 
 func (i *ICoreWebView2) GetSettings() (*ICoreWebViewSettings, error) {
-	var err error
 	var settings *ICoreWebViewSettings
-	_, _, err = i.vtbl.GetSettings.Call(
+	hr, _, _ := i.vtbl.GetSettings.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(&settings)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2", "GetSettings"); err != nil {
 		return nil, err
 	}
 	return settings, nil
@@ -221,7 +217,7 @@ func (e *ICoreWebView2Environment) CreateWebResourceResponse(content []byte, sta
 		return nil, err
 	}
 	var response *ICoreWebView2WebResourceResponse
-	_, _, err = e.vtbl.CreateWebResourceResponse.Call(
+	hr, _, _ := e.vtbl.CreateWebResourceResponse.Call(
 		uintptr(unsafe.Pointer(e)),
 		stream,
 		uintptr(statusCode),
@@ -229,7 +225,7 @@ func (e *ICoreWebView2Environment) CreateWebResourceResponse(content []byte, sta
 		uintptr(unsafe.Pointer(_headers)),
 		uintptr(unsafe.Pointer(&response)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2Environment", "CreateWebResourceResponse"); err != nil {
 		return nil, err
 	}
 	return response, nil
@@ -419,35 +415,44 @@ func (i *ICoreWebView2) AddWebResourceRequestedFilter(uri string, resourceContex
 	if err != nil {
 		return err
 	}
-	_, _, err = i.vtbl.AddWebResourceRequestedFilter.Call(
+	hr, _, _ := i.vtbl.AddWebResourceRequestedFilter.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(_uri)),
+		uintptr(resourceContext),
+	)
+	if err := newError(hr, "ICoreWebView2", "AddWebResourceRequestedFilter"); err != nil {
+		return err
+	}
+	return nil
+}
+func (i *ICoreWebView2) RemoveWebResourceRequestedFilter(uri string, resourceContext COREWEBVIEW2_WEB_RESOURCE_CONTEXT) error {
+	_uri, err := windows.UTF16PtrFromString(uri)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := i.vtbl.RemoveWebResourceRequestedFilter.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(_uri)),
 		uintptr(resourceContext),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2", "RemoveWebResourceRequestedFilter"); err != nil {
 		return err
 	}
 	return nil
 }
+
 func (i *ICoreWebView2) AddNavigationCompleted(eventHandler *ICoreWebView2NavigationCompletedEventHandler, token *_EventRegistrationToken) error {
-	var err error
-	_, _, err = i.vtbl.AddNavigationCompleted.Call(
+	hr, _, _ := i.vtbl.AddNavigationCompleted.Call(
 		uintptr(unsafe.Pointer(i)),
 		uintptr(unsafe.Pointer(eventHandler)),
 		uintptr(unsafe.Pointer(&token)),
 	)
-	if err != windows.ERROR_SUCCESS {
+	if err := newError(hr, "ICoreWebView2", "AddNavigationCompleted"); err != nil {
 		return err
 	}
 	return nil
 }
 
-// ICoreWebView2PrintCompletedHandler
-
-type ICoreWebView2PrintCompletedHandler interface {
-	Invoke(errorCode int, printStatus int) uintptr
-}
-
 // ICoreWebView2Deferral 定义
 type ICoreWebView2Deferral struct {
 	vtbl *iCoreWebView2DeferralVtbl
@@ -457,3 +462,44 @@ type iCoreWebView2DeferralVtbl struct {
 	_IUnknownVtbl
 	Complete ComProc
 }
+
+func (i *ICoreWebView2Deferral) Release() uintptr {
+	r, _, _ := i.vtbl.Release.Call(uintptr(unsafe.Pointer(i)))
+	return r
+}
+
+// Complete 完成推迟的事件
+func (i *ICoreWebView2Deferral) Complete() error {
+	hr, _, _ := i.vtbl.Complete.Call(uintptr(unsafe.Pointer(i)))
+	if err := newError(hr, "ICoreWebView2Deferral", "Complete"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *ICoreWebView2) GetSource() (string, error) {
+	var _uri *uint16
+	hr, _, _ := i.vtbl.GetSource.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(unsafe.Pointer(&_uri)),
+	)
+	if err := newError(hr, "ICoreWebView2", "GetSource"); err != nil {
+		return "", err
+	}
+	uri := w32.Utf16PtrToString(_uri)
+	windows.CoTaskMemFree(unsafe.Pointer(_uri))
+	return uri, nil
+}
+
+func (i *ICoreWebView2) CapturePreview(imageFormat COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT, imageStream uintptr, handler *ICoreWebView2CapturePreviewCompletedHandler) error {
+	hr, _, _ := i.vtbl.CapturePreview.Call(
+		uintptr(unsafe.Pointer(i)),
+		uintptr(imageFormat),
+		imageStream,
+		uintptr(unsafe.Pointer(handler)),
+	)
+	if err := newError(hr, "ICoreWebView2", "CapturePreview"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build windows
+// +build windows
+
+package edge
+
+import (
+	"os"
+
+	"github.com/yuaotian/go-win-webview2/pkg/hresult"
+	"golang.org/x/sys/windows"
+)
+
+// Error 是 WebView2 COM 调用失败时返回的错误，记录了失败的接口、方法和 HRESULT。
+// errors.Is(err, hresult.E_ACCESSDENIED) 等可直接按 HRESULT 判断。
+type Error struct {
+	HResult     hresult.HRESULT // 调用返回的 HRESULT
+	Interface   string          // 接口名，如 ICoreWebView2Environment
+	Method      string          // 方法名，如 CreateCoreWebView2Controller
+	Description string          // 额外说明
+}
+
+func (e *Error) Error() string {
+	msg := "webview2"
+	name := e.Interface
+	if e.Method != "" {
+		if name != "" {
+			name += "."
+		}
+		name += e.Method
+	}
+	if name != "" {
+		msg += ": " + name
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg + " (" + e.HResult.Error() + ")"
+}
+
+// Unwrap 返回 HRESULT
+func (e *Error) Unwrap() error {
+	return e.HResult
+}
+
+// Is 让 errors.Is 按 HRESULT 匹配 ErrAccessDenied 等哨兵错误，
+// 文件或路径不存在时也匹配 os.ErrNotExist
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case *Error:
+		return t.HResult == e.HResult && t.Interface == "" && t.Method == ""
+	case windows.Errno:
+		return e.HResult == hresult.FromWin32(uint32(t))
+	}
+	if target == os.ErrNotExist {
+		return e.HResult == hresult.HRESULT_FILE_NOT_FOUND || e.HResult == hresult.HRESULT_PATH_NOT_FOUND
+	}
+	return false
+}
+
+// 可与 errors.Is 一起使用的哨兵错误
+var (
+	// ErrAccessDenied 通常表示用户数据目录不可写
+	ErrAccessDenied = &Error{HResult: hresult.E_ACCESSDENIED}
+	// ErrChangedMode 表示线程已以多线程模式初始化 COM，WebView2 需要单线程单元 (STA)
+	ErrChangedMode = &Error{HResult: hresult.RPC_E_CHANGED_MODE}
+	// ErrFileNotFound 通常表示未安装 WebView2 运行时
+	ErrFileNotFound = &Error{HResult: hresult.HRESULT_FILE_NOT_FOUND}
+	// ErrInvalidState 通常表示 WebView 已关闭
+	ErrInvalidState = &Error{HResult: hresult.HRESULT_INVALID_STATE}
+)
+
+// newError 在 COM 方法返回的 HRESULT 表示失败时返回 *Error，否则返回 nil
+func newError(hr uintptr, iface, method string) error {
+	if h := hresult.FromUintptr(hr); h.Failed() {
+		return &Error{HResult: h, Interface: iface, Method: method}
+	}
+	return nil
+}
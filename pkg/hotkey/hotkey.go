@@ -0,0 +1,195 @@
+// Package hotkey 解析和格式化热键字符串，如 "Ctrl+Shift+K"、"F5"、"Cmd+Alt+Left"。
+//
+// 热键由零个或多个修饰符和一个键组成，以 "+" 分隔，不区分大小写，两侧的空格被忽略：
+//
+//	修饰符  Ctrl (Control、Ctl)、Alt (Option、Opt)、Shift、Win (Windows、Cmd、Command、Super、Meta)、
+//	        NoRepeat (按住时不重复触发，只对全局热键有效)
+//	键      字母 A-Z、数字 0-9、F1-F24、Num0-Num9、方向键 Left/Up/Right/Down、Home、End、PageUp、
+//	        PageDown、Insert、Delete、媒体键 (MediaPlayPause、VolumeUp 等)、标点 (; = , - . / ` [ \ ] ')、
+//	        以及十六进制的虚拟键码，如 0xE2
+//
+// "+" 键写作 "Plus" 或以 "++" 结尾，如 "Ctrl++"，与 "Ctrl+=" 相同 (同一个键)。
+// HotKey.String 返回规范形式，修饰符按 Ctrl、Alt、Shift、Win、NoRepeat 排列，Parse 可以解析回相同的 HotKey。
+//
+// 以空格分隔的多个热键组成组合键序列 (Sequence)，如 "Ctrl+K Ctrl+S"。
+// Keymap 是 JSON 格式的快捷键绑定，可以按页面的源和焦点是否在输入框中设置条件，
+// Matcher 是按 Keymap 匹配按键的状态机。
+//
+// 修饰符和键码与 Windows RegisterHotKey 的参数相同，本包不依赖 Windows，可在任何平台使用。
+package hotkey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 修饰符，与 Windows 的 MOD_* 相同
+const (
+	ModAlt      = 0x0001
+	ModControl  = 0x0002
+	ModShift    = 0x0004
+	ModWin      = 0x0008
+	ModNoRepeat = 0x4000
+)
+
+// modifierOrder 是 String 中修饰符的顺序和规范名称
+var modifierOrder = []struct {
+	mod  int
+	name string
+}{
+	{ModControl, "Ctrl"},
+	{ModAlt, "Alt"},
+	{ModShift, "Shift"},
+	{ModWin, "Win"},
+	{ModNoRepeat, "NoRepeat"},
+}
+
+// modifierNames 是可以解析的修饰符名称 (小写)
+var modifierNames = map[string]int{
+	"ctrl":     ModControl,
+	"control":  ModControl,
+	"ctl":      ModControl,
+	"alt":      ModAlt,
+	"option":   ModAlt,
+	"opt":      ModAlt,
+	"shift":    ModShift,
+	"win":      ModWin,
+	"windows":  ModWin,
+	"cmd":      ModWin,
+	"command":  ModWin,
+	"super":    ModWin,
+	"meta":     ModWin,
+	"norepeat": ModNoRepeat,
+}
+
+// HotKey 表示一个键组合
+type HotKey struct {
+	Modifiers int // 修饰符
+	KeyCode   int // 键码
+}
+
+// Parse 将热键字符串解析为 HotKey 结构
+// 例如: "Ctrl+Alt+Q" -> HotKey{ModControl|ModAlt, 'Q'}
+func Parse(s string) (HotKey, error) {
+	parts := strings.Split(s, "+")
+	// 以 "++" 结尾时最后的 "+" 是键
+	if n := len(parts); n >= 2 && strings.TrimSpace(parts[n-1]) == "" && strings.TrimSpace(parts[n-2]) == "" {
+		parts = append(parts[:n-2], "+")
+	}
+
+	var h HotKey
+	for i, part := range parts {
+		name := strings.TrimSpace(part)
+		if name == "" {
+			return HotKey{}, fmt.Errorf("hotkey %q: empty key", s)
+		}
+		if i == len(parts)-1 {
+			code, ok := LookupKey(name)
+			if !ok {
+				if _, isMod := modifierNames[strings.ToLower(name)]; isMod {
+					return HotKey{}, fmt.Errorf("hotkey %q: missing key after %s", s, name)
+				}
+				return HotKey{}, fmt.Errorf("hotkey %q: unknown key %q", s, name)
+			}
+			h.KeyCode = code
+			break
+		}
+		mod, ok := modifierNames[strings.ToLower(name)]
+		if !ok {
+			return HotKey{}, fmt.Errorf("hotkey %q: unknown modifier %q", s, name)
+		}
+		if h.Modifiers&mod != 0 {
+			return HotKey{}, fmt.Errorf("hotkey %q: duplicate modifier %q", s, name)
+		}
+		h.Modifiers |= mod
+	}
+	return h, nil
+}
+
+// MustParse 与 Parse 相同，解析失败时 panic，用于初始化包级变量
+func MustParse(s string) HotKey {
+	h, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// String 返回热键的规范形式，如 "Ctrl+Shift+K"。KeyCode 为 0 时返回空字符串
+func (h HotKey) String() string {
+	if h.KeyCode == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, m := range modifierOrder {
+		if h.Modifiers&m.mod != 0 {
+			b.WriteString(m.name)
+			b.WriteByte('+')
+		}
+	}
+	b.WriteString(KeyName(h.KeyCode))
+	return b.String()
+}
+
+// KeyName 返回键码的规范名称，没有名称的键码返回十六进制形式，如 "0xE2"
+func KeyName(code int) string {
+	switch {
+	case code >= 'A' && code <= 'Z', code >= '0' && code <= '9':
+		return string(rune(code))
+	case code >= KeyF1 && code <= KeyF24:
+		return "F" + strconv.Itoa(code-KeyF1+1)
+	case code >= KeyNumpad0 && code <= KeyNumpad0+9:
+		return "Num" + strconv.Itoa(code-KeyNumpad0)
+	}
+	if name, ok := keyNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", code)
+}
+
+// LookupKey 返回键名对应的键码，不区分大小写
+func LookupKey(name string) (int, bool) {
+	lower := strings.ToLower(name)
+	if len(lower) == 1 {
+		c := lower[0]
+		switch {
+		case c >= 'a' && c <= 'z':
+			return int(c - 'a' + 'A'), true
+		case c >= '0' && c <= '9':
+			return int(c), true
+		}
+	}
+	if code, ok := keyCodes[lower]; ok {
+		return code, true
+	}
+	if n, ok := numberSuffix(lower, "f"); ok && n >= 1 && n <= 24 {
+		return KeyF1 + n - 1, true
+	}
+	for _, prefix := range []string{"numpad", "num"} {
+		if n, ok := numberSuffix(lower, prefix); ok && n <= 9 {
+			return KeyNumpad0 + n, true
+		}
+	}
+	if strings.HasPrefix(lower, "0x") {
+		if n, err := strconv.ParseUint(lower[2:], 16, 8); err == nil && n > 0 && n < 0xFF {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// numberSuffix 解析 prefix 之后的十进制数字
+func numberSuffix(s, prefix string) (int, bool) {
+	if !strings.HasPrefix(s, prefix) || len(s) == len(prefix) {
+		return 0, false
+	}
+	digits := s[len(prefix):]
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
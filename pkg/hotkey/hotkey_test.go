@@ -0,0 +1,170 @@
+package hotkey
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want HotKey
+	}{
+		{"A", HotKey{0, 'A'}},
+		{"ctrl+shift+k", HotKey{ModControl | ModShift, 'K'}},
+		{" Ctrl + Alt + Q ", HotKey{ModControl | ModAlt, 'Q'}},
+		{"Shift+Ctrl+1", HotKey{ModControl | ModShift, '1'}},
+
+		// 修饰符的别名
+		{"Control+A", HotKey{ModControl, 'A'}},
+		{"Ctl+A", HotKey{ModControl, 'A'}},
+		{"Option+A", HotKey{ModAlt, 'A'}},
+		{"Opt+A", HotKey{ModAlt, 'A'}},
+		{"Cmd+A", HotKey{ModWin, 'A'}},
+		{"Command+A", HotKey{ModWin, 'A'}},
+		{"Super+A", HotKey{ModWin, 'A'}},
+		{"Meta+A", HotKey{ModWin, 'A'}},
+		{"Windows+A", HotKey{ModWin, 'A'}},
+		{"Win+NoRepeat+A", HotKey{ModWin | ModNoRepeat, 'A'}},
+
+		// 功能键和小键盘
+		{"F1", HotKey{0, KeyF1}},
+		{"F12", HotKey{0, KeyF1 + 11}},
+		{"F13", HotKey{0, 0x7C}},
+		{"f24", HotKey{0, KeyF24}},
+		{"Num0", HotKey{0, KeyNumpad0}},
+		{"Numpad9", HotKey{0, KeyNumpad0 + 9}},
+		{"NumAdd", HotKey{0, KeyNumAdd}},
+		{"NumPlus", HotKey{0, KeyNumAdd}},
+		{"NumDivide", HotKey{0, KeyNumDivide}},
+
+		// 媒体键
+		{"MediaPlayPause", HotKey{0, KeyMediaPlayPause}},
+		{"PlayPause", HotKey{0, KeyMediaPlayPause}},
+		{"VolumeUp", HotKey{0, KeyVolumeUp}},
+		{"Mute", HotKey{0, KeyVolumeMute}},
+		{"MediaNextTrack", HotKey{0, KeyMediaNext}},
+		{"BrowserBack", HotKey{0, KeyBrowserBack}},
+
+		// 其他键和别名
+		{"Esc", HotKey{0, KeyEscape}},
+		{"Escape", HotKey{0, KeyEscape}},
+		{"Return", HotKey{0, KeyEnter}},
+		{"ArrowLeft", HotKey{0, KeyLeft}},
+		{"Alt+PgDn", HotKey{ModAlt, KeyPageDown}},
+		{"Del", HotKey{0, KeyDelete}},
+
+		// 标点
+		{"Ctrl+;", HotKey{ModControl, KeySemicolon}},
+		{"Ctrl+=", HotKey{ModControl, KeyEqual}},
+		{"Ctrl+,", HotKey{ModControl, KeyComma}},
+		{"Ctrl+-", HotKey{ModControl, KeyMinus}},
+		{"Ctrl+.", HotKey{ModControl, KeyPeriod}},
+		{"Ctrl+/", HotKey{ModControl, KeySlash}},
+		{"Ctrl+`", HotKey{ModControl, KeyBackquote}},
+		{"Ctrl+[", HotKey{ModControl, KeyBracketLeft}},
+		{`Ctrl+\`, HotKey{ModControl, KeyBackslash}},
+		{"Ctrl+]", HotKey{ModControl, KeyBracketRight}},
+		{"Ctrl+'", HotKey{ModControl, KeyQuote}},
+		{"Ctrl+Minus", HotKey{ModControl, KeyMinus}},
+		{"Ctrl+Backtick", HotKey{ModControl, KeyBackquote}},
+
+		// "+" 键
+		{"Ctrl++", HotKey{ModControl, KeyEqual}},
+		{"Ctrl+Shift++", HotKey{ModControl | ModShift, KeyEqual}},
+		{"Ctrl+Plus", HotKey{ModControl, KeyEqual}},
+		{"+", HotKey{0, KeyEqual}},
+
+		// 十六进制键码
+		{"0xE2", HotKey{0, 0xE2}},
+		{"Alt+0x41", HotKey{ModAlt, 'A'}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		in, err string
+	}{
+		{"", "empty key"},
+		{"Ctrl+", "empty key"},
+		{"Ctrl++A", "empty key"},
+		{"+A", "empty key"},
+		{"Shift", "missing key after Shift"},
+		{"Ctrl+Alt", "missing key after Alt"},
+		{"Ctrl+Ctrl+A", "duplicate modifier"},
+		{"Cmd+Win+A", "duplicate modifier"},
+		{"Hyper+A", "unknown modifier"},
+		{"A+B", "unknown modifier"},
+		{"Ctrl+Foo", "unknown key"},
+		{"F0", "unknown key"},
+		{"F25", "unknown key"},
+		{"Num10", "unknown key"},
+		{"0x00", "unknown key"},
+		{"0xFF", "unknown key"},
+		{"0x100", "unknown key"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.in)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		h    HotKey
+		want string
+	}{
+		{HotKey{}, ""},
+		{HotKey{0, 'A'}, "A"},
+		{HotKey{ModWin | ModShift | ModAlt | ModControl, 'K'}, "Ctrl+Alt+Shift+Win+K"},
+		{HotKey{ModNoRepeat | ModControl, KeyF1 + 12}, "Ctrl+NoRepeat+F13"},
+		{HotKey{ModControl, KeyEqual}, "Ctrl+="},
+		{HotKey{0, KeyNumpad0 + 5}, "Num5"},
+		{HotKey{0, KeyEscape}, "Esc"},
+		{HotKey{0, 0xE2}, "0xE2"},
+		{HotKey{0, 0x07}, "0x07"},
+	}
+	for _, tt := range tests {
+		if got := tt.h.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.h, got, tt.want)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	mods := []int{0, ModControl, ModAlt | ModShift, ModControl | ModWin | ModNoRepeat}
+	for code := 1; code < 0xFF; code++ {
+		for _, mod := range mods {
+			h := HotKey{mod, code}
+			got, err := Parse(h.String())
+			if err != nil {
+				t.Errorf("Parse(%q) error: %v", h.String(), err)
+				continue
+			}
+			if got != h {
+				t.Errorf("Parse(%q) = %+v, want %+v", h.String(), got, h)
+			}
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse(Ctrl+) did not panic")
+		}
+	}()
+	MustParse("Ctrl+")
+}
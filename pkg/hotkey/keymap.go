@@ -0,0 +1,119 @@
+package hotkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Binding 是快捷键与命令的绑定，JSON 格式与 VS Code 的 keybindings.json 相同:
+//
+//	[
+//		{"key": "ctrl+k ctrl+s", "command": "app.openShortcuts"},
+//		{"key": "f5", "command": "webview.reload", "when": "!textInputFocus"},
+//		{"key": "ctrl+s", "command": "editor.save", "when": "origin == 'https://app.example'"},
+//		{"key": "ctrl+k ctrl+s", "command": "-app.openShortcuts"}
+//	]
+//
+// Command 以 "-" 开头时移除之前相同命令的绑定，Key 和 When 不为空时只移除相同按键和条件的绑定。
+//
+// WebView2 只在按住 Ctrl 或 Alt，或者按键不产生字符时报告按键，所以序列中的每个键都要带 Ctrl 或 Alt，
+// 或者是 F1、Esc、方向键这样不产生字符的键。"ctrl+k s"、"shift+a" 和 "g g" 这样的绑定永远不会触发，
+// 解析时返回错误
+type Binding struct {
+	Key     string `json:"key"`
+	Command string `json:"command"`
+	When    string `json:"when,omitempty"`
+}
+
+// Keymap 是按顺序排列的绑定，后面的绑定优先
+type Keymap []Binding
+
+// ParseKeymap 解析 JSON 格式的 Keymap 并检查每个绑定
+func ParseKeymap(data []byte) (Keymap, error) {
+	var keymap Keymap
+	if err := json.Unmarshal(data, &keymap); err != nil {
+		return nil, fmt.Errorf("keymap: %w", err)
+	}
+	if _, err := keymap.compile(); err != nil {
+		return nil, err
+	}
+	return keymap, nil
+}
+
+// LoadKeymap 读取 JSON 格式的 Keymap 文件
+func LoadKeymap(path string) (Keymap, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeymap(data)
+}
+
+// Merge 返回在 k 之后追加 overrides 的 Keymap，用户的 Keymap 可以覆盖或移除默认的绑定
+func (k Keymap) Merge(overrides Keymap) Keymap {
+	merged := make(Keymap, 0, len(k)+len(overrides))
+	merged = append(merged, k...)
+	return append(merged, overrides...)
+}
+
+// compiledBinding 是解析后的绑定
+type compiledBinding struct {
+	Binding
+	seq  Sequence
+	when []condition
+}
+
+// compile 解析所有绑定并处理移除
+func (k Keymap) compile() ([]compiledBinding, error) {
+	var bindings []compiledBinding
+	for i, b := range k {
+		command := strings.TrimSpace(b.Command)
+		if command == "" || command == "-" {
+			return nil, fmt.Errorf("keymap[%d] %q: command is required", i, b.Key)
+		}
+		when, err := parseWhen(b.When)
+		if err != nil {
+			return nil, fmt.Errorf("keymap[%d] %q: %v", i, b.Key, err)
+		}
+
+		if strings.HasPrefix(command, "-") {
+			var seq Sequence
+			if strings.TrimSpace(b.Key) != "" {
+				if seq, err = ParseSequence(b.Key); err != nil {
+					return nil, fmt.Errorf("keymap[%d] %q: %v", i, b.Key, err)
+				}
+			}
+			bindings = removeBindings(bindings, command[1:], seq, strings.TrimSpace(b.When))
+			continue
+		}
+
+		seq, err := ParseSequence(b.Key)
+		if err != nil {
+			return nil, fmt.Errorf("keymap[%d] %q: %v", i, b.Key, err)
+		}
+		for _, key := range seq {
+			if key.Modifiers&(ModControl|ModAlt) == 0 && IsCharacterKey(key.KeyCode) {
+				return nil, fmt.Errorf("keymap[%d] %q: %s types a character and needs Ctrl or Alt, WebView2 does not report it", i, b.Key, key)
+			}
+		}
+		b.Command = command
+		bindings = append(bindings, compiledBinding{Binding: b, seq: seq, when: when})
+	}
+	return bindings, nil
+}
+
+// removeBindings 移除命令为 command 的绑定，seq 和 when 不为空时还要求按键和条件相同
+func removeBindings(bindings []compiledBinding, command string, seq Sequence, when string) []compiledBinding {
+	kept := bindings[:0]
+	for _, b := range bindings {
+		if b.Command == command &&
+			(seq == nil || b.seq.Equal(seq)) &&
+			(when == "" || strings.TrimSpace(b.When) == when) {
+			continue
+		}
+		kept = append(kept, b)
+	}
+	return kept
+}
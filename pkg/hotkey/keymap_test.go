@@ -0,0 +1,140 @@
+package hotkey
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// commands 返回编译后的绑定的按键和命令
+func commands(t *testing.T, k Keymap) []string {
+	t.Helper()
+	bindings, err := k.compile()
+	if err != nil {
+		t.Fatalf("compile() error: %v", err)
+	}
+	var got []string
+	for _, b := range bindings {
+		got = append(got, b.seq.String()+" "+b.Command)
+	}
+	return got
+}
+
+func TestParseKeymap(t *testing.T) {
+	k, err := ParseKeymap([]byte(`[
+		{"key": "ctrl+k ctrl+s", "command": "app.openShortcuts"},
+		{"key": "f5", "command": " webview.reload ", "when": "!textInputFocus"}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseKeymap() error: %v", err)
+	}
+	want := Keymap{
+		{Key: "ctrl+k ctrl+s", Command: "app.openShortcuts"},
+		{Key: "f5", Command: " webview.reload ", When: "!textInputFocus"},
+	}
+	if !reflect.DeepEqual(k, want) {
+		t.Errorf("ParseKeymap() = %+v, want %+v", k, want)
+	}
+	if got := commands(t, k); !reflect.DeepEqual(got, []string{"Ctrl+K Ctrl+S app.openShortcuts", "F5 webview.reload"}) {
+		t.Errorf("compile() = %q", got)
+	}
+
+	// 带 Ctrl 或 Alt 的字符键和不产生字符的键可以不带修饰符
+	k = Keymap{
+		{Key: "ctrl+k ctrl+shift+s", Command: "a"},
+		{Key: "alt+g alt+g", Command: "b"},
+		{Key: "shift+f5 esc", Command: "c"},
+		{Key: "ctrl+k left", Command: "d"},
+		// 移除不存在的绑定不会出错
+		{Key: "g", Command: "-b"},
+	}
+	if got := commands(t, k); len(got) != 4 {
+		t.Errorf("compile() = %q, want 4 bindings", got)
+	}
+}
+
+func TestParseKeymapInvalid(t *testing.T) {
+	tests := []struct {
+		json, err string
+	}{
+		{`{"key": "f5"}`, "keymap: json"},
+		{`[{"key": "f5"}]`, `keymap[0] "f5": command is required`},
+		{`[{"key": "f5", "command": "-"}]`, "command is required"},
+		{`[{"key": "f5", "command": "a"}, {"key": "", "command": "b"}]`, `keymap[1] "": key sequence "": empty`},
+		{`[{"key": "ctrl+", "command": "a"}]`, "empty key"},
+		{`[{"key": "hyper+a", "command": "a"}]`, "unknown modifier"},
+		{`[{"key": "f5", "command": "a", "when": "focus"}]`, `unknown condition "focus"`},
+		{`[{"key": "f5", "command": "a", "when": "origin == x"}]`, "must be quoted"},
+		{`[{"key": "ctrl+", "command": "-a"}]`, "empty key"},
+		// WebView2 不报告不带 Ctrl 或 Alt 的字符键
+		{`[{"key": "ctrl+k s", "command": "a"}]`, `keymap[0] "ctrl+k s": S types a character and needs Ctrl or Alt`},
+		{`[{"key": "shift+a", "command": "a"}]`, "Shift+A types a character"},
+		{`[{"key": "g g", "command": "a"}]`, "G types a character"},
+		{`[{"key": "space", "command": "a"}]`, "Space types a character"},
+		{`[{"key": "win+1", "command": "a"}]`, "Win+1 types a character"},
+		{`[{"key": "shift+;", "command": "a"}]`, "Shift+; types a character"},
+		{`[{"key": "numadd", "command": "a"}]`, "NumAdd types a character"},
+	}
+	for _, tt := range tests {
+		_, err := ParseKeymap([]byte(tt.json))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("ParseKeymap(%s) error = %v, want %q", tt.json, err, tt.err)
+		}
+	}
+}
+
+func TestKeymapRemove(t *testing.T) {
+	defaults := Keymap{
+		{Key: "ctrl+s", Command: "save"},
+		{Key: "ctrl+shift+s", Command: "save"},
+		{Key: "f5", Command: "reload"},
+		{Key: "f5", Command: "reload", When: "!textInputFocus"},
+	}
+	tests := []struct {
+		name      string
+		overrides Keymap
+		want      []string
+	}{
+		{"none", nil, []string{"Ctrl+S save", "Ctrl+Shift+S save", "F5 reload", "F5 reload"}},
+		{"command", Keymap{{Command: "-save"}}, []string{"F5 reload", "F5 reload"}},
+		{"key", Keymap{{Key: "Ctrl + S", Command: "-save"}}, []string{"Ctrl+Shift+S save", "F5 reload", "F5 reload"}},
+		{"when", Keymap{{Key: "f5", Command: "-reload", When: " !textInputFocus "}}, []string{"Ctrl+S save", "Ctrl+Shift+S save", "F5 reload"}},
+		{"other key", Keymap{{Key: "f6", Command: "-reload"}}, []string{"Ctrl+S save", "Ctrl+Shift+S save", "F5 reload", "F5 reload"}},
+		{"rebind", Keymap{{Key: "ctrl+s", Command: "-save"}, {Key: "ctrl+alt+s", Command: "save"}},
+			[]string{"Ctrl+Shift+S save", "F5 reload", "F5 reload", "Ctrl+Alt+S save"}},
+		// 移除只影响之前的绑定
+		{"order", Keymap{{Command: "-run"}, {Key: "f9", Command: "run"}}, []string{"Ctrl+S save", "Ctrl+Shift+S save", "F5 reload", "F5 reload", "F9 run"}},
+	}
+	for _, tt := range tests {
+		got := commands(t, defaults.Merge(tt.overrides))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: compile() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	// Merge 不修改原来的 Keymap
+	if len(defaults) != 4 {
+		t.Errorf("Merge() modified the receiver: %+v", defaults)
+	}
+}
+
+func TestParseSequence(t *testing.T) {
+	seq, err := ParseSequence("  ctrl + k   Ctrl+Shift+S ")
+	if err != nil {
+		t.Fatalf("ParseSequence() error: %v", err)
+	}
+	if got, want := seq.String(), "Ctrl+K Ctrl+Shift+S"; got != want {
+		t.Errorf("ParseSequence().String() = %q, want %q", got, want)
+	}
+	// NoRepeat 不影响比较
+	if !seq.Equal(Sequence{MustParse("Ctrl+NoRepeat+K"), MustParse("Ctrl+Shift+S")}) {
+		t.Error("Equal() ignoring NoRepeat = false")
+	}
+	if !seq.HasPrefix(seq[:1]) || seq[:1].HasPrefix(seq) {
+		t.Error("HasPrefix() is wrong")
+	}
+	for _, in := range []string{"", "   ", "Ctrl+K Ctrl+"} {
+		if _, err := ParseSequence(in); err == nil {
+			t.Errorf("ParseSequence(%q) succeeded, want error", in)
+		}
+	}
+}
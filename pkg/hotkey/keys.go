@@ -0,0 +1,240 @@
+package hotkey
+
+import "strings"
+
+// 虚拟键码，与 Windows 的 VK_* 相同。字母和数字键的键码是对应的大写 ASCII 字符
+const (
+	KeyBackspace    = 0x08
+	KeyTab          = 0x09
+	KeyClear        = 0x0C
+	KeyEnter        = 0x0D
+	KeyPause        = 0x13
+	KeyCapsLock     = 0x14
+	KeyEscape       = 0x1B
+	KeySpace        = 0x20
+	KeyPageUp       = 0x21
+	KeyPageDown     = 0x22
+	KeyEnd          = 0x23
+	KeyHome         = 0x24
+	KeyLeft         = 0x25
+	KeyUp           = 0x26
+	KeyRight        = 0x27
+	KeyDown         = 0x28
+	KeySelect       = 0x29
+	KeyPrint        = 0x2A
+	KeyExecute      = 0x2B
+	KeyPrintScreen  = 0x2C
+	KeyInsert       = 0x2D
+	KeyDelete       = 0x2E
+	KeyHelp         = 0x2F
+	KeyApps         = 0x5D
+	KeySleep        = 0x5F
+	KeyNumpad0      = 0x60 // Numpad1 到 Numpad9 依次为 0x61 到 0x69
+	KeyNumMultiply  = 0x6A
+	KeyNumAdd       = 0x6B
+	KeyNumSeparator = 0x6C
+	KeyNumSubtract  = 0x6D
+	KeyNumDecimal   = 0x6E
+	KeyNumDivide    = 0x6F
+	KeyF1           = 0x70 // F2 到 F24 依次为 0x71 到 0x87
+	KeyF24          = 0x87
+	KeyNumLock      = 0x90
+	KeyScrollLock   = 0x91
+
+	KeyBrowserBack      = 0xA6
+	KeyBrowserForward   = 0xA7
+	KeyBrowserRefresh   = 0xA8
+	KeyBrowserStop      = 0xA9
+	KeyBrowserSearch    = 0xAA
+	KeyBrowserFavorites = 0xAB
+	KeyBrowserHome      = 0xAC
+	KeyVolumeMute       = 0xAD
+	KeyVolumeDown       = 0xAE
+	KeyVolumeUp         = 0xAF
+	KeyMediaNext        = 0xB0
+	KeyMediaPrev        = 0xB1
+	KeyMediaStop        = 0xB2
+	KeyMediaPlayPause   = 0xB3
+	KeyLaunchMail       = 0xB4
+	KeyLaunchMedia      = 0xB5
+	KeyLaunchApp1       = 0xB6
+	KeyLaunchApp2       = 0xB7
+
+	KeySemicolon    = 0xBA // ;:
+	KeyEqual        = 0xBB // =+ (VK_OEM_PLUS)
+	KeyComma        = 0xBC // ,<
+	KeyMinus        = 0xBD // -_
+	KeyPeriod       = 0xBE // .>
+	KeySlash        = 0xBF // /?
+	KeyBackquote    = 0xC0 // `~
+	KeyBracketLeft  = 0xDB // [{
+	KeyBackslash    = 0xDC // \|
+	KeyBracketRight = 0xDD // ]}
+	KeyQuote        = 0xDE // '"
+)
+
+// 修饰键的键码，不能作为热键的键
+const (
+	KeyShift    = 0x10
+	KeyControl  = 0x11
+	KeyAlt      = 0x12 // VK_MENU
+	KeyLeftWin  = 0x5B
+	KeyRightWin = 0x5C
+)
+
+// IsModifierKey 判断键码是否为修饰键 (包括区分左右的 VK_LSHIFT 到 VK_RMENU)
+func IsModifierKey(code int) bool {
+	switch code {
+	case KeyShift, KeyControl, KeyAlt, KeyLeftWin, KeyRightWin:
+		return true
+	}
+	return code >= 0xA0 && code <= 0xA5
+}
+
+// IsCharacterKey 判断键码是否为输入字符的键: 字母、数字、空格、标点和小键盘的数字和运算符
+func IsCharacterKey(code int) bool {
+	switch {
+	case code == KeySpace,
+		code >= '0' && code <= '9',
+		code >= 'A' && code <= 'Z',
+		code >= KeyNumpad0 && code <= KeyNumDivide,
+		code >= KeySemicolon && code <= KeyBackquote,
+		code >= KeyBracketLeft && code <= KeyQuote:
+		return true
+	}
+	return false
+}
+
+// keyNames 是键码的规范名称，String 使用这些名称
+var keyNames = map[int]string{
+	KeyBackspace:    "Backspace",
+	KeyTab:          "Tab",
+	KeyClear:        "Clear",
+	KeyEnter:        "Enter",
+	KeyPause:        "Pause",
+	KeyCapsLock:     "CapsLock",
+	KeyEscape:       "Esc",
+	KeySpace:        "Space",
+	KeyPageUp:       "PageUp",
+	KeyPageDown:     "PageDown",
+	KeyEnd:          "End",
+	KeyHome:         "Home",
+	KeyLeft:         "Left",
+	KeyUp:           "Up",
+	KeyRight:        "Right",
+	KeyDown:         "Down",
+	KeySelect:       "Select",
+	KeyPrint:        "Print",
+	KeyExecute:      "Execute",
+	KeyPrintScreen:  "PrintScreen",
+	KeyInsert:       "Insert",
+	KeyDelete:       "Delete",
+	KeyHelp:         "Help",
+	KeyApps:         "Apps",
+	KeySleep:        "Sleep",
+	KeyNumMultiply:  "NumMultiply",
+	KeyNumAdd:       "NumAdd",
+	KeyNumSeparator: "NumSeparator",
+	KeyNumSubtract:  "NumSubtract",
+	KeyNumDecimal:   "NumDecimal",
+	KeyNumDivide:    "NumDivide",
+	KeyNumLock:      "NumLock",
+	KeyScrollLock:   "ScrollLock",
+
+	KeyBrowserBack:      "BrowserBack",
+	KeyBrowserForward:   "BrowserForward",
+	KeyBrowserRefresh:   "BrowserRefresh",
+	KeyBrowserStop:      "BrowserStop",
+	KeyBrowserSearch:    "BrowserSearch",
+	KeyBrowserFavorites: "BrowserFavorites",
+	KeyBrowserHome:      "BrowserHome",
+	KeyVolumeMute:       "VolumeMute",
+	KeyVolumeDown:       "VolumeDown",
+	KeyVolumeUp:         "VolumeUp",
+	KeyMediaNext:        "MediaNext",
+	KeyMediaPrev:        "MediaPrev",
+	KeyMediaStop:        "MediaStop",
+	KeyMediaPlayPause:   "MediaPlayPause",
+	KeyLaunchMail:       "LaunchMail",
+	KeyLaunchMedia:      "LaunchMedia",
+	KeyLaunchApp1:       "LaunchApp1",
+	KeyLaunchApp2:       "LaunchApp2",
+
+	// 标点键使用美式键盘上的字符，"+" 是分隔符，所以 =+ 键写作 "="
+	KeySemicolon:    ";",
+	KeyEqual:        "=",
+	KeyComma:        ",",
+	KeyMinus:        "-",
+	KeyPeriod:       ".",
+	KeySlash:        "/",
+	KeyBackquote:    "`",
+	KeyBracketLeft:  "[",
+	KeyBackslash:    `\`,
+	KeyBracketRight: "]",
+	KeyQuote:        "'",
+}
+
+// keyAliases 是规范名称之外可以解析的名称 (小写)
+var keyAliases = map[string]int{
+	"back":              KeyBackspace,
+	"return":            KeyEnter,
+	"escape":            KeyEscape,
+	"pgup":              KeyPageUp,
+	"pageup":            KeyPageUp,
+	"pgdn":              KeyPageDown,
+	"pgdown":            KeyPageDown,
+	"arrowleft":         KeyLeft,
+	"arrowup":           KeyUp,
+	"arrowright":        KeyRight,
+	"arrowdown":         KeyDown,
+	"prtsc":             KeyPrintScreen,
+	"snapshot":          KeyPrintScreen,
+	"ins":               KeyInsert,
+	"del":               KeyDelete,
+	"menu":              KeyApps,
+	"contextmenu":       KeyApps,
+	"capital":           KeyCapsLock,
+	"scroll":            KeyScrollLock,
+	"break":             KeyPause,
+	"nummul":            KeyNumMultiply,
+	"numplus":           KeyNumAdd,
+	"numsub":            KeyNumSubtract,
+	"numminus":          KeyNumSubtract,
+	"numdiv":            KeyNumDivide,
+	"numdot":            KeyNumDecimal,
+	"mute":              KeyVolumeMute,
+	"medianexttrack":    KeyMediaNext,
+	"mediaprevtrack":    KeyMediaPrev,
+	"mediaprevious":     KeyMediaPrev,
+	"playpause":         KeyMediaPlayPause,
+	"mediaplay":         KeyMediaPlayPause,
+	"launchmediaselect": KeyLaunchMedia,
+
+	"semicolon":    KeySemicolon,
+	"equal":        KeyEqual,
+	"equals":       KeyEqual,
+	"plus":         KeyEqual,
+	"+":            KeyEqual,
+	"comma":        KeyComma,
+	"minus":        KeyMinus,
+	"period":       KeyPeriod,
+	"slash":        KeySlash,
+	"backquote":    KeyBackquote,
+	"backtick":     KeyBackquote,
+	"bracketleft":  KeyBracketLeft,
+	"backslash":    KeyBackslash,
+	"bracketright": KeyBracketRight,
+	"quote":        KeyQuote,
+}
+
+// keyCodes 是所有可以解析的名称 (小写) 到键码的映射
+var keyCodes = func() map[string]int {
+	codes := make(map[string]int, len(keyNames)+len(keyAliases))
+	for code, name := range keyNames {
+		codes[strings.ToLower(name)] = code
+	}
+	for name, code := range keyAliases {
+		codes[name] = code
+	}
+	return codes
+}()
@@ -0,0 +1,131 @@
+package hotkey
+
+import "time"
+
+// DefaultChordTimeout 是组合键序列中两次按键之间的默认最长间隔
+const DefaultChordTimeout = 2 * time.Second
+
+// Result 是 Matcher.Press 的结果
+type Result int
+
+const (
+	// Unhandled 表示按键不属于任何快捷键，应交给页面
+	Unhandled Result = iota
+	// Pending 表示按键是组合键序列的前缀，等待下一个键
+	Pending
+	// Matched 表示按键完成了一个快捷键
+	Matched
+	// Cancelled 表示组合键序列中按下了不匹配的键，序列被取消，按键不应再交给页面
+	Cancelled
+)
+
+func (r Result) String() string {
+	switch r {
+	case Unhandled:
+		return "unhandled"
+	case Pending:
+		return "pending"
+	case Matched:
+		return "matched"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// Matcher 是匹配快捷键和组合键序列的状态机，不能在多个 goroutine 中同时使用。
+//
+// 按下的键是某个绑定的前缀时进入等待状态，超过 Timeout 没有按下一个键时序列被丢弃。
+// 同一个按键既是完整的绑定又是更长序列的前缀时等待更长的序列 (与 VS Code 相同)，
+// 同一序列有多个满足条件的绑定时后面的优先
+type Matcher struct {
+	// Timeout 是两次按键之间的最长间隔，为 0 时使用 DefaultChordTimeout
+	Timeout time.Duration
+	// Now 返回当前时间，为 nil 时使用 time.Now
+	Now func() time.Time
+
+	bindings []compiledBinding
+	pending  Sequence
+	last     time.Time
+}
+
+// NewMatcher 返回匹配 keymap 的 Matcher，keymap 中有无效的绑定时返回错误
+func NewMatcher(keymap Keymap) (*Matcher, error) {
+	bindings, err := keymap.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{bindings: bindings}, nil
+}
+
+// Pending 返回已按下的组合键序列前缀，不在等待状态时返回 nil
+func (m *Matcher) Pending() Sequence {
+	if len(m.pending) == 0 {
+		return nil
+	}
+	return append(Sequence(nil), m.pending...)
+}
+
+// Reset 丢弃已按下的序列前缀
+func (m *Matcher) Reset() {
+	m.pending = nil
+}
+
+// Press 处理一次按键，返回结果和匹配的绑定 (仅 Matched 时不为 nil)。
+// 单独按下的修饰键 (Ctrl、Shift 等) 返回 Unhandled，不影响等待状态
+func (m *Matcher) Press(key HotKey, ctx Context) (Result, *Binding) {
+	if IsModifierKey(key.KeyCode) {
+		return Unhandled, nil
+	}
+	now := m.now()
+	if len(m.pending) > 0 && now.Sub(m.last) > m.timeout() {
+		m.pending = nil
+	}
+	wasPending := len(m.pending) > 0
+
+	seq := append(append(Sequence(nil), m.pending...), key)
+	var exact *compiledBinding
+	prefix := false
+	for i := range m.bindings {
+		b := &m.bindings[i]
+		if !b.seq.HasPrefix(seq) || !matchWhen(b.when, ctx) {
+			continue
+		}
+		if len(b.seq) > len(seq) {
+			prefix = true
+		} else {
+			exact = b
+		}
+	}
+
+	switch {
+	case prefix:
+		m.pending = seq
+		m.last = now
+		return Pending, nil
+	case exact != nil:
+		m.pending = nil
+		binding := exact.Binding
+		return Matched, &binding
+	case wasPending:
+		m.pending = nil
+		return Cancelled, nil
+	default:
+		return Unhandled, nil
+	}
+}
+
+func (m *Matcher) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+func (m *Matcher) timeout() time.Duration {
+	if m.Timeout > 0 {
+		return m.Timeout
+	}
+	return DefaultChordTimeout
+}
@@ -0,0 +1,203 @@
+package hotkey
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock 是可以手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestMatcher(t *testing.T, keymap Keymap) (*Matcher, *fakeClock) {
+	t.Helper()
+	m, err := NewMatcher(keymap)
+	if err != nil {
+		t.Fatalf("NewMatcher() error: %v", err)
+	}
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m.Now = clock.Now
+	return m, clock
+}
+
+// press 按下 key 并检查结果和匹配的命令
+func press(t *testing.T, m *Matcher, key string, ctx Context, want Result, command string) {
+	t.Helper()
+	got, b := m.Press(MustParse(key), ctx)
+	if got != want {
+		t.Fatalf("Press(%s) = %v, want %v", key, got, want)
+	}
+	switch {
+	case command == "" && b != nil:
+		t.Fatalf("Press(%s) matched %q, want no binding", key, b.Command)
+	case command != "" && (b == nil || b.Command != command):
+		t.Fatalf("Press(%s) matched %v, want %q", key, b, command)
+	}
+}
+
+func TestMatcherChord(t *testing.T) {
+	m, _ := newTestMatcher(t, Keymap{
+		{Key: "ctrl+k ctrl+s", Command: "app.openShortcuts"},
+		{Key: "ctrl+s", Command: "editor.save"},
+	})
+	var ctx Context
+
+	press(t, m, "Ctrl+S", ctx, Matched, "editor.save")
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	if got := m.Pending().String(); got != "Ctrl+K" {
+		t.Errorf("Pending() = %q, want Ctrl+K", got)
+	}
+	// 单独按下的修饰键不影响等待状态
+	press(t, m, "Ctrl+0x11", ctx, Unhandled, "")
+	press(t, m, "Ctrl+S", ctx, Matched, "app.openShortcuts")
+	if m.Pending() != nil {
+		t.Errorf("Pending() = %v after match, want nil", m.Pending())
+	}
+
+	// 第二个键不匹配时取消序列，之后的按键重新开始匹配
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	press(t, m, "Ctrl+X", ctx, Cancelled, "")
+	press(t, m, "Ctrl+X", ctx, Unhandled, "")
+	press(t, m, "Ctrl+S", ctx, Matched, "editor.save")
+
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	m.Reset()
+	press(t, m, "Ctrl+S", ctx, Matched, "editor.save")
+
+	// NoRepeat 不影响匹配
+	press(t, m, "Ctrl+NoRepeat+S", ctx, Matched, "editor.save")
+}
+
+func TestMatcherTimeout(t *testing.T) {
+	m, clock := newTestMatcher(t, Keymap{
+		{Key: "ctrl+k ctrl+s", Command: "app.openShortcuts"},
+		{Key: "ctrl+s", Command: "editor.save"},
+	})
+	var ctx Context
+
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	clock.Advance(DefaultChordTimeout)
+	press(t, m, "Ctrl+S", ctx, Matched, "app.openShortcuts")
+
+	// 超时后序列被丢弃，按键按单独的快捷键匹配
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	clock.Advance(DefaultChordTimeout + time.Millisecond)
+	press(t, m, "Ctrl+S", ctx, Matched, "editor.save")
+
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	clock.Advance(DefaultChordTimeout + time.Millisecond)
+	press(t, m, "Ctrl+X", ctx, Unhandled, "")
+
+	m.Timeout = 100 * time.Millisecond
+	press(t, m, "Ctrl+K", ctx, Pending, "")
+	clock.Advance(101 * time.Millisecond)
+	press(t, m, "Ctrl+S", ctx, Matched, "editor.save")
+}
+
+func TestMatcherPrefixBinding(t *testing.T) {
+	m, clock := newTestMatcher(t, Keymap{
+		{Key: "f7", Command: "goto"},
+		{Key: "f7 f7", Command: "top"},
+	})
+	var ctx Context
+
+	// 既是完整的绑定又是前缀时等待更长的序列
+	press(t, m, "F7", ctx, Pending, "")
+	press(t, m, "F7", ctx, Matched, "top")
+
+	// 超时后前缀本身不会被触发，下一次按键重新开始
+	press(t, m, "F7", ctx, Pending, "")
+	clock.Advance(DefaultChordTimeout + time.Millisecond)
+	press(t, m, "F7", ctx, Pending, "")
+	press(t, m, "F8", ctx, Cancelled, "")
+}
+
+func TestMatcherPriority(t *testing.T) {
+	m, _ := newTestMatcher(t, Keymap{
+		{Key: "f5", Command: "webview.reload"},
+		{Key: "f5", Command: "app.run", When: "origin == 'https://app.example'"},
+	})
+	press(t, m, "F5", Context{Origin: "https://app.example"}, Matched, "app.run")
+	press(t, m, "F5", Context{Origin: "https://other.example"}, Matched, "webview.reload")
+}
+
+func TestMatcherWhen(t *testing.T) {
+	m, _ := newTestMatcher(t, Keymap{
+		{Key: "f5", Command: "webview.reload", When: "!textInputFocus"},
+		{Key: "ctrl+s", Command: "editor.save", When: "origin == 'https://*.example.com'"},
+		{Key: "ctrl+k ctrl+f", Command: "format", When: "textInputFocus && origin != 'https://untrusted.example.com'"},
+	})
+	app := Context{Origin: "https://app.example.com"}
+	input := Context{Origin: "https://app.example.com", TextInputFocus: true}
+	untrusted := Context{Origin: "https://untrusted.example.com", TextInputFocus: true}
+
+	press(t, m, "F5", app, Matched, "webview.reload")
+	press(t, m, "F5", input, Unhandled, "")
+
+	press(t, m, "Ctrl+S", app, Matched, "editor.save")
+	press(t, m, "Ctrl+S", Context{Origin: "https://example.com"}, Unhandled, "")
+	press(t, m, "Ctrl+S", Context{Origin: "null"}, Unhandled, "")
+
+	press(t, m, "Ctrl+K", app, Unhandled, "")
+	press(t, m, "Ctrl+K", untrusted, Unhandled, "")
+	press(t, m, "Ctrl+K", input, Pending, "")
+	press(t, m, "Ctrl+F", input, Matched, "format")
+}
+
+func TestParseWhen(t *testing.T) {
+	tests := []struct {
+		when string
+		ctx  Context
+		want bool
+	}{
+		{"", Context{}, true},
+		{"textInputFocus", Context{TextInputFocus: true}, true},
+		{"textInputFocus", Context{}, false},
+		{"!textInputFocus", Context{}, true},
+		{" ! textInputFocus ", Context{TextInputFocus: true}, false},
+		{`origin == "https://app.example"`, Context{Origin: "https://app.example"}, true},
+		{"origin=='https://app.example'", Context{Origin: "HTTPS://APP.EXAMPLE"}, true},
+		{"origin == 'https://app.example'", Context{Origin: "https://app.example.evil"}, false},
+		{"origin != 'https://app.example'", Context{Origin: "https://other.example"}, true},
+		{"origin == '*'", Context{Origin: "file://"}, true},
+		{"origin == 'http://localhost:*'", Context{Origin: "http://localhost:8080"}, true},
+		{"origin == 'http://localhost:*'", Context{Origin: "https://localhost:8080"}, false},
+		{"origin == 'https://*.example.com'", Context{Origin: "https://a.b.example.com"}, true},
+		{"origin == 'https://*.example.com'", Context{Origin: "https://example.com"}, false},
+		{"origin == 'https://*.example.*'", Context{Origin: "https://app.example.org"}, true},
+		{"origin == 'https://app.example' && !textInputFocus", Context{Origin: "https://app.example"}, true},
+		{"origin == 'https://app.example' && !textInputFocus", Context{Origin: "https://app.example", TextInputFocus: true}, false},
+	}
+	for _, tt := range tests {
+		conds, err := parseWhen(tt.when)
+		if err != nil {
+			t.Errorf("parseWhen(%q) error: %v", tt.when, err)
+			continue
+		}
+		if got := matchWhen(conds, tt.ctx); got != tt.want {
+			t.Errorf("when %q with %+v = %t, want %t", tt.when, tt.ctx, got, tt.want)
+		}
+	}
+}
+
+func TestParseWhenInvalid(t *testing.T) {
+	for _, when := range []string{
+		"editorFocus",
+		"textInputFocus &&",
+		"&& textInputFocus",
+		"textInputFocus || !textInputFocus",
+		"origin = 'https://app.example'",
+		"origin == https://app.example",
+		"origin == 'https://app.example\"",
+		"origin",
+	} {
+		if _, err := parseWhen(when); err == nil {
+			t.Errorf("parseWhen(%q) succeeded, want error", when)
+		}
+	}
+}
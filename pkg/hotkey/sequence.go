@@ -0,0 +1,62 @@
+package hotkey
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Sequence 是依次按下的热键，如 "Ctrl+K Ctrl+S"。只有一个热键的 Sequence 是普通的快捷键
+type Sequence []HotKey
+
+// plusSpaces 匹配 "+" 两侧的空格，"Ctrl + K" 与 "Ctrl+K" 相同
+var plusSpaces = regexp.MustCompile(`\s*\+\s*`)
+
+// ParseSequence 解析以空格分隔的热键序列，如 "Ctrl+K Ctrl+S"
+func ParseSequence(s string) (Sequence, error) {
+	fields := strings.Fields(plusSpaces.ReplaceAllString(strings.TrimSpace(s), "+"))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("key sequence %q: empty", s)
+	}
+	seq := make(Sequence, 0, len(fields))
+	for _, field := range fields {
+		h, err := Parse(field)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, h)
+	}
+	return seq, nil
+}
+
+// String 返回序列的规范形式，热键之间以一个空格分隔
+func (s Sequence) String() string {
+	parts := make([]string, len(s))
+	for i, h := range s {
+		parts[i] = h.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// HasPrefix 判断 prefix 是否为序列的前缀，忽略 NoRepeat
+func (s Sequence) HasPrefix(prefix Sequence) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i, h := range prefix {
+		if !h.Equal(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 判断两个序列是否相同，忽略 NoRepeat
+func (s Sequence) Equal(other Sequence) bool {
+	return len(s) == len(other) && s.HasPrefix(other)
+}
+
+// Equal 判断两个热键是否为同一个键组合，忽略 NoRepeat
+func (h HotKey) Equal(other HotKey) bool {
+	return h.KeyCode == other.KeyCode && h.Modifiers&^ModNoRepeat == other.Modifiers&^ModNoRepeat
+}
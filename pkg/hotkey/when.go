@@ -0,0 +1,110 @@
+package hotkey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context 是按键时的页面状态，用于判断快捷键的 when 条件
+type Context struct {
+	Origin         string // 页面的源，如 "https://app.example"
+	TextInputFocus bool   // 焦点是否在文本输入框或可编辑元素中
+}
+
+// condition 是 when 条件中以 && 连接的一项
+type condition struct {
+	name   string // "textInputFocus" 或 "origin"
+	negate bool
+	value  string // origin 比较的值，可包含通配符 *
+}
+
+// parseWhen 解析 when 条件，支持:
+//
+//	textInputFocus、!textInputFocus
+//	origin == 'https://app.example'、origin != 'https://*.example.com'
+//
+// 多个条件以 && 连接，全部满足时快捷键生效
+func parseWhen(s string) ([]condition, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var conds []condition
+	for _, clause := range strings.Split(s, "&&") {
+		clause = strings.TrimSpace(clause)
+		switch {
+		case clause == "":
+			return nil, fmt.Errorf("when %q: empty condition", s)
+		case strings.HasPrefix(clause, "origin"):
+			c, err := parseOrigin(strings.TrimSpace(strings.TrimPrefix(clause, "origin")))
+			if err != nil {
+				return nil, fmt.Errorf("when %q: %v", s, err)
+			}
+			conds = append(conds, c)
+		default:
+			negate := strings.HasPrefix(clause, "!")
+			name := strings.TrimSpace(strings.TrimPrefix(clause, "!"))
+			if name != "textInputFocus" {
+				return nil, fmt.Errorf("when %q: unknown condition %q", s, name)
+			}
+			conds = append(conds, condition{name: name, negate: negate})
+		}
+	}
+	return conds, nil
+}
+
+// parseOrigin 解析 origin 之后的 "== 'value'" 或 "!= 'value'"
+func parseOrigin(rest string) (condition, error) {
+	c := condition{name: "origin"}
+	switch {
+	case strings.HasPrefix(rest, "=="):
+	case strings.HasPrefix(rest, "!="):
+		c.negate = true
+	default:
+		return condition{}, fmt.Errorf("origin must be compared with == or !=")
+	}
+	value := strings.TrimSpace(rest[2:])
+	if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+		return condition{}, fmt.Errorf("origin value %s must be quoted", value)
+	}
+	c.value = value[1 : len(value)-1]
+	return c, nil
+}
+
+// matchWhen 判断 ctx 是否满足所有条件
+func matchWhen(conds []condition, ctx Context) bool {
+	for _, c := range conds {
+		var ok bool
+		switch c.name {
+		case "textInputFocus":
+			ok = ctx.TextInputFocus
+		case "origin":
+			ok = matchWildcard(c.value, ctx.Origin)
+		}
+		if ok == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWildcard 判断 s 是否匹配 pattern，* 匹配任意字符 (包括 / 和 .)，不区分大小写
+func matchWildcard(pattern, s string) bool {
+	pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
@@ -0,0 +1,194 @@
+// Package hresult 解码 COM 和 WebView2 方法返回的 HRESULT。
+// 不依赖 Windows API，可在任意平台编译和使用。
+package hresult
+
+import "fmt"
+
+// HRESULT 是 COM 方法的返回值。最高位为 1 表示失败，
+// 16-28 位为 facility，低 16 位为错误码
+type HRESULT uint32
+
+// 常见的 HRESULT
+const (
+	S_OK    HRESULT = 0x00000000
+	S_FALSE HRESULT = 0x00000001
+
+	E_NOTIMPL             HRESULT = 0x80004001
+	E_NOINTERFACE         HRESULT = 0x80004002
+	E_POINTER             HRESULT = 0x80004003
+	E_ABORT               HRESULT = 0x80004004
+	E_FAIL                HRESULT = 0x80004005
+	E_UNEXPECTED          HRESULT = 0x8000FFFF
+	E_ACCESSDENIED        HRESULT = 0x80070005
+	E_HANDLE              HRESULT = 0x80070006
+	E_OUTOFMEMORY         HRESULT = 0x8007000E
+	E_INVALIDARG          HRESULT = 0x80070057
+	RPC_E_CHANGED_MODE    HRESULT = 0x80010106
+	RPC_E_WRONG_THREAD    HRESULT = 0x8001010E
+	CO_E_NOTINITIALIZED   HRESULT = 0x800401F0
+	REGDB_E_CLASSNOTREG   HRESULT = 0x80040154
+	CLASS_E_NOAGGREGATION HRESULT = 0x80040110
+
+	// 以下为 HRESULT_FROM_WIN32 转换后的 Win32 错误，WebView2 常用这些值报告失败
+	HRESULT_FILE_NOT_FOUND        HRESULT = 0x80070002 // 通常表示未安装 WebView2 运行时
+	HRESULT_PATH_NOT_FOUND        HRESULT = 0x80070003
+	HRESULT_NOT_SUPPORTED         HRESULT = 0x80070032
+	HRESULT_FILE_EXISTS           HRESULT = 0x80070050
+	HRESULT_DISK_FULL             HRESULT = 0x80070070
+	HRESULT_ALREADY_EXISTS        HRESULT = 0x800700B7
+	HRESULT_BAD_EXE_FORMAT        HRESULT = 0x800700C1
+	HRESULT_NOT_FOUND             HRESULT = 0x80070490
+	HRESULT_CANCELLED             HRESULT = 0x800704C7
+	HRESULT_INVALID_WINDOW_HANDLE HRESULT = 0x80070578
+	HRESULT_INVALID_STATE         HRESULT = 0x8007139F // 通常表示 WebView 已关闭或不在创建它的线程上调用
+)
+
+// Facility 表示错误来源
+type Facility uint16
+
+const (
+	FacilityNull     Facility = 0
+	FacilityRPC      Facility = 1
+	FacilityDispatch Facility = 2
+	FacilityStorage  Facility = 3
+	FacilityITF      Facility = 4
+	FacilityWin32    Facility = 7
+	FacilityWindows  Facility = 8
+)
+
+func (f Facility) String() string {
+	switch f {
+	case FacilityNull:
+		return "NULL"
+	case FacilityRPC:
+		return "RPC"
+	case FacilityDispatch:
+		return "DISPATCH"
+	case FacilityStorage:
+		return "STORAGE"
+	case FacilityITF:
+		return "ITF"
+	case FacilityWin32:
+		return "WIN32"
+	case FacilityWindows:
+		return "WINDOWS"
+	}
+	return fmt.Sprintf("Facility(%d)", uint16(f))
+}
+
+// FromUintptr 将 syscall 的第一个返回值转换为 HRESULT。
+// 64 位系统上只有低 32 位有效。
+func FromUintptr(r uintptr) HRESULT {
+	return HRESULT(uint32(r))
+}
+
+// FromWin32 相当于 HRESULT_FROM_WIN32
+func FromWin32(code uint32) HRESULT {
+	if code == 0 || code&0x80000000 != 0 {
+		return HRESULT(code)
+	}
+	return HRESULT(code&0xFFFF | uint32(FacilityWin32)<<16 | 0x80000000)
+}
+
+// Failed 相当于 FAILED(hr)
+func (hr HRESULT) Failed() bool {
+	return hr&0x80000000 != 0
+}
+
+// Succeeded 相当于 SUCCEEDED(hr)
+func (hr HRESULT) Succeeded() bool {
+	return !hr.Failed()
+}
+
+// Severity 返回严重性位，1 表示失败
+func (hr HRESULT) Severity() int {
+	return int(hr >> 31)
+}
+
+// Facility 返回错误来源
+func (hr HRESULT) Facility() Facility {
+	return Facility((hr >> 16) & 0x1FFF)
+}
+
+// Code 返回低 16 位的错误码，Win32 facility 下即 Win32 错误码
+func (hr HRESULT) Code() uint16 {
+	return uint16(hr)
+}
+
+// Err 在失败时返回 hr 本身作为 error，成功时返回 nil
+func (hr HRESULT) Err() error {
+	if hr.Failed() {
+		return hr
+	}
+	return nil
+}
+
+type info struct {
+	name    string
+	message string
+}
+
+var table = map[HRESULT]info{
+	S_OK:                          {"S_OK", "success"},
+	S_FALSE:                       {"S_FALSE", "success (false)"},
+	E_NOTIMPL:                     {"E_NOTIMPL", "not implemented"},
+	E_NOINTERFACE:                 {"E_NOINTERFACE", "interface not supported"},
+	E_POINTER:                     {"E_POINTER", "invalid pointer"},
+	E_ABORT:                       {"E_ABORT", "operation aborted"},
+	E_FAIL:                        {"E_FAIL", "unspecified failure"},
+	E_UNEXPECTED:                  {"E_UNEXPECTED", "catastrophic failure"},
+	E_ACCESSDENIED:                {"E_ACCESSDENIED", "access denied"},
+	E_HANDLE:                      {"E_HANDLE", "invalid handle"},
+	E_OUTOFMEMORY:                 {"E_OUTOFMEMORY", "out of memory"},
+	E_INVALIDARG:                  {"E_INVALIDARG", "invalid argument"},
+	RPC_E_CHANGED_MODE:            {"RPC_E_CHANGED_MODE", "COM already initialized in a different threading mode"},
+	RPC_E_WRONG_THREAD:            {"RPC_E_WRONG_THREAD", "called from the wrong thread"},
+	CO_E_NOTINITIALIZED:           {"CO_E_NOTINITIALIZED", "COM is not initialized"},
+	REGDB_E_CLASSNOTREG:           {"REGDB_E_CLASSNOTREG", "class not registered"},
+	CLASS_E_NOAGGREGATION:         {"CLASS_E_NOAGGREGATION", "class does not support aggregation"},
+	HRESULT_FILE_NOT_FOUND:        {"ERROR_FILE_NOT_FOUND", "file not found, is the WebView2 runtime installed?"},
+	HRESULT_PATH_NOT_FOUND:        {"ERROR_PATH_NOT_FOUND", "path not found"},
+	HRESULT_NOT_SUPPORTED:         {"ERROR_NOT_SUPPORTED", "not supported"},
+	HRESULT_FILE_EXISTS:           {"ERROR_FILE_EXISTS", "file exists"},
+	HRESULT_DISK_FULL:             {"ERROR_DISK_FULL", "disk full"},
+	HRESULT_ALREADY_EXISTS:        {"ERROR_ALREADY_EXISTS", "already exists"},
+	HRESULT_BAD_EXE_FORMAT:        {"ERROR_BAD_EXE_FORMAT", "bad executable format, wrong architecture?"},
+	HRESULT_NOT_FOUND:             {"ERROR_NOT_FOUND", "element not found"},
+	HRESULT_CANCELLED:             {"ERROR_CANCELLED", "operation cancelled"},
+	HRESULT_INVALID_WINDOW_HANDLE: {"ERROR_INVALID_WINDOW_HANDLE", "invalid window handle"},
+	HRESULT_INVALID_STATE:         {"ERROR_INVALID_STATE", "invalid state, was the WebView closed?"},
+}
+
+// Name 返回 HRESULT 的符号名，如 "E_ACCESSDENIED"；
+// 表中没有的 Win32 错误返回 "WIN32(code)"，其他未知值返回空字符串
+func (hr HRESULT) Name() string {
+	if i, ok := table[hr]; ok {
+		return i.name
+	}
+	if hr.Failed() && hr.Facility() == FacilityWin32 {
+		return fmt.Sprintf("WIN32(%d)", hr.Code())
+	}
+	return ""
+}
+
+// Message 返回 HRESULT 的说明文字
+func (hr HRESULT) Message() string {
+	if i, ok := table[hr]; ok {
+		return i.message
+	}
+	if hr.Succeeded() {
+		return "success"
+	}
+	if hr.Facility() == FacilityWin32 {
+		return fmt.Sprintf("win32 error %d", hr.Code())
+	}
+	return fmt.Sprintf("unknown error (facility %s, code 0x%04X)", hr.Facility(), hr.Code())
+}
+
+// Error 返回形如 "E_ACCESSDENIED (0x80070005): access denied" 的字符串
+func (hr HRESULT) Error() string {
+	if name := hr.Name(); name != "" {
+		return fmt.Sprintf("%s (0x%08X): %s", name, uint32(hr), hr.Message())
+	}
+	return fmt.Sprintf("HRESULT 0x%08X: %s", uint32(hr), hr.Message())
+}
@@ -0,0 +1,162 @@
+package hresult
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+func TestFromUintptr(t *testing.T) {
+	if got := FromUintptr(0x80070005); got != E_ACCESSDENIED {
+		t.Errorf("FromUintptr(0x80070005) = 0x%08X, want E_ACCESSDENIED", uint32(got))
+	}
+	if unsafe.Sizeof(uintptr(0)) < 8 {
+		return
+	}
+	// 64 位系统上高 32 位是垃圾值，如符号扩展后的 0xFFFFFFFF80070005
+	high := uint64(0xFFFFFFFF00000000)
+	if got := FromUintptr(uintptr(high) | 0x80070005); got != E_ACCESSDENIED {
+		t.Errorf("FromUintptr(sign extended) = 0x%08X, want E_ACCESSDENIED", uint32(got))
+	}
+	high = 0x1234567800000000
+	if got := FromUintptr(uintptr(high)); got != S_OK {
+		t.Errorf("FromUintptr(high bits only) = 0x%08X, want S_OK", uint32(got))
+	}
+}
+
+func TestFromWin32(t *testing.T) {
+	tests := []struct {
+		code uint32
+		want HRESULT
+	}{
+		{0, S_OK},
+		{2, HRESULT_FILE_NOT_FOUND},
+		{5, E_ACCESSDENIED},
+		{87, E_INVALIDARG},
+		{5023, HRESULT_INVALID_STATE},
+		// 已经是 HRESULT 的值保持不变
+		{0x80004005, E_FAIL},
+		{0x80070005, E_ACCESSDENIED},
+	}
+	for _, tt := range tests {
+		if got := FromWin32(tt.code); got != tt.want {
+			t.Errorf("FromWin32(%d) = 0x%08X, want 0x%08X", tt.code, uint32(got), uint32(tt.want))
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		hr        HRESULT
+		severity  int
+		facility  Facility
+		code      uint16
+		succeeded bool
+	}{
+		{S_OK, 0, FacilityNull, 0, true},
+		{S_FALSE, 0, FacilityNull, 1, true},
+		{E_FAIL, 1, FacilityNull, 0x4005, false},
+		{E_ACCESSDENIED, 1, FacilityWin32, 5, false},
+		{RPC_E_WRONG_THREAD, 1, FacilityRPC, 0x010E, false},
+		{CO_E_NOTINITIALIZED, 1, FacilityITF, 0x01F0, false},
+		{0x80080005, 1, FacilityWindows, 5, false},
+		{0x9FFF0001, 1, Facility(0x1FFF), 1, false},
+	}
+	for _, tt := range tests {
+		if got := tt.hr.Severity(); got != tt.severity {
+			t.Errorf("0x%08X Severity() = %d, want %d", uint32(tt.hr), got, tt.severity)
+		}
+		if got := tt.hr.Facility(); got != tt.facility {
+			t.Errorf("0x%08X Facility() = %v, want %v", uint32(tt.hr), got, tt.facility)
+		}
+		if got := tt.hr.Code(); got != tt.code {
+			t.Errorf("0x%08X Code() = 0x%04X, want 0x%04X", uint32(tt.hr), got, tt.code)
+		}
+		if got := tt.hr.Succeeded(); got != tt.succeeded {
+			t.Errorf("0x%08X Succeeded() = %t, want %t", uint32(tt.hr), got, tt.succeeded)
+		}
+		if got := tt.hr.Failed(); got == tt.succeeded {
+			t.Errorf("0x%08X Failed() = %t", uint32(tt.hr), got)
+		}
+	}
+}
+
+func TestFacilityString(t *testing.T) {
+	tests := []struct {
+		f    Facility
+		want string
+	}{
+		{FacilityNull, "NULL"},
+		{FacilityRPC, "RPC"},
+		{FacilityWin32, "WIN32"},
+		{FacilityWindows, "WINDOWS"},
+		{Facility(42), "Facility(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("Facility(%d).String() = %q, want %q", uint16(tt.f), got, tt.want)
+		}
+	}
+}
+
+func TestNameMessage(t *testing.T) {
+	tests := []struct {
+		hr      HRESULT
+		name    string
+		message string
+	}{
+		{S_OK, "S_OK", "success"},
+		{E_ACCESSDENIED, "E_ACCESSDENIED", "access denied"},
+		{HRESULT_FILE_NOT_FOUND, "ERROR_FILE_NOT_FOUND", "file not found, is the WebView2 runtime installed?"},
+		{HRESULT_INVALID_STATE, "ERROR_INVALID_STATE", "invalid state, was the WebView closed?"},
+		// 表中没有的 Win32 错误
+		{FromWin32(1401), "WIN32(1401)", "win32 error 1401"},
+		// 未知的成功值
+		{0x00000002, "", "success"},
+		// 未知 facility 的失败
+		{0x80290001, "", "unknown error (facility Facility(41), code 0x0001)"},
+		{0x80040200, "", "unknown error (facility ITF, code 0x0200)"},
+	}
+	for _, tt := range tests {
+		if got := tt.hr.Name(); got != tt.name {
+			t.Errorf("0x%08X Name() = %q, want %q", uint32(tt.hr), got, tt.name)
+		}
+		if got := tt.hr.Message(); got != tt.message {
+			t.Errorf("0x%08X Message() = %q, want %q", uint32(tt.hr), got, tt.message)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		hr   HRESULT
+		want string
+	}{
+		{E_ACCESSDENIED, "E_ACCESSDENIED (0x80070005): access denied"},
+		{FromWin32(1400), "ERROR_INVALID_WINDOW_HANDLE (0x80070578): invalid window handle"},
+		{FromWin32(1401), "WIN32(1401) (0x80070579): win32 error 1401"},
+		{0x80290001, "HRESULT 0x80290001: unknown error (facility Facility(41), code 0x0001)"},
+	}
+	for _, tt := range tests {
+		if got := tt.hr.Error(); got != tt.want {
+			t.Errorf("0x%08X Error() = %q, want %q", uint32(tt.hr), got, tt.want)
+		}
+	}
+}
+
+func TestErr(t *testing.T) {
+	if err := S_OK.Err(); err != nil {
+		t.Errorf("S_OK.Err() = %v, want nil", err)
+	}
+	if err := S_FALSE.Err(); err != nil {
+		t.Errorf("S_FALSE.Err() = %v, want nil", err)
+	}
+	err := E_INVALIDARG.Err()
+	var hr HRESULT
+	if !errors.As(err, &hr) || hr != E_INVALIDARG {
+		t.Errorf("E_INVALIDARG.Err() = %v, want E_INVALIDARG", err)
+	}
+	if !errors.Is(err, E_INVALIDARG) {
+		t.Error("errors.Is(E_INVALIDARG.Err(), E_INVALIDARG) = false")
+	}
+}
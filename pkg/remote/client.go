@@ -0,0 +1,337 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrClosed 表示客户端连接已关闭
+var ErrClosed = errors.New("remote: connection closed")
+
+// Options 是 Dial 的选项
+type Options struct {
+	Token       string            // WebSocket 服务的连接令牌，见 WebView.WebSocketToken
+	RemoteToken string            // 远程控制令牌，见 WebView.RemoteControlToken
+	Client      string            // 客户端名称，服务端仅用于日志
+	Header      http.Header       // 额外的握手请求头
+	Dialer      *websocket.Dialer // 为 nil 时使用 websocket.DefaultDialer
+}
+
+// Client 是远程控制协议的客户端，可在多个 goroutine 中使用
+type Client struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	connID  string
+	hello   HelloResult
+
+	mu      sync.Mutex
+	pending map[string]chan frame
+	nextID  uint64
+	onEvent func(Event)
+	err     error
+	done    chan struct{}
+}
+
+// Dial 连接到 url (如 ws://127.0.0.1:8080/ws) 并完成 remote.hello 认证
+func Dial(ctx context.Context, url string, opts Options) (*Client, error) {
+	dialer := opts.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	header := http.Header{}
+	for k, v := range opts.Header {
+		header[k] = v
+	}
+	if opts.Token != "" {
+		header.Set("Authorization", "Bearer "+opts.Token)
+	}
+
+	conn, _, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, fmt.Errorf("remote: dial %s: %w", url, err)
+	}
+
+	// 连接建立后服务端先发送 welcome 消息。ReadJSON 不接受 ctx，ctx 结束时关闭连接以中断读取
+	var welcome frame
+	interrupted := make(chan bool, 1)
+	read := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			interrupted <- true
+		case <-read:
+			interrupted <- false
+		}
+	}()
+	err = conn.ReadJSON(&welcome)
+	close(read)
+	if <-interrupted {
+		return nil, fmt.Errorf("remote: read welcome: %w", ctx.Err())
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("remote: read welcome: %w", err)
+	}
+	if welcome.Type != frameWelcome {
+		conn.Close()
+		return nil, fmt.Errorf("remote: unexpected %q message before welcome", welcome.Type)
+	}
+
+	c := &Client{
+		conn:    conn,
+		connID:  welcome.ID,
+		pending: make(map[string]chan frame),
+		done:    make(chan struct{}),
+	}
+	go c.readLoop()
+
+	params := HelloParams{Version: ProtocolVersion, Token: opts.RemoteToken, Client: opts.Client}
+	if err := c.Call(ctx, MethodHello, params, &c.hello); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// ConnID 返回服务端分配的连接 ID
+func (c *Client) ConnID() string {
+	return c.connID
+}
+
+// Server 返回 remote.hello 的结果，包括服务端支持的方法和事件
+func (c *Client) Server() HelloResult {
+	return c.hello
+}
+
+// Call 发送请求并将结果解码到 result，result 为 nil 时忽略结果。
+// 服务端返回的错误为 *Error
+func (c *Client) Call(ctx context.Context, method string, params, result interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := strconv.FormatUint(c.nextID, 10)
+	ch := make(chan frame, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(frame{Type: frameRequest, ID: id, Method: method, Data: data}); err != nil {
+		return err
+	}
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return c.Err()
+		}
+		if resp.Error != "" {
+			return &Error{Method: method, Message: resp.Error}
+		}
+		if result == nil || len(resp.Data) == 0 {
+			return nil
+		}
+		return json.Unmarshal(resp.Data, result)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Navigate 导航到 url
+func (c *Client) Navigate(ctx context.Context, url string) error {
+	return c.Call(ctx, MethodNavigate, NavigateParams{URL: url}, nil)
+}
+
+// Eval 在页面中执行脚本并返回 JSON 格式的结果，脚本抛出的异常作为 *Error 返回
+func (c *Client) Eval(ctx context.Context, script string) (json.RawMessage, error) {
+	var result EvalResult
+	if err := c.Call(ctx, MethodEval, EvalParams{Script: script, Timeout: timeoutMillis(ctx)}, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// CallBinding 调用通过 Bind 绑定的函数，args 逐个序列化为 JSON
+func (c *Client) CallBinding(ctx context.Context, name string, args ...interface{}) (json.RawMessage, error) {
+	params := CallParams{Name: name, Args: make([]json.RawMessage, 0, len(args)), Timeout: timeoutMillis(ctx)}
+	for _, arg := range args {
+		b, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		params.Args = append(params.Args, b)
+	}
+	var result CallResult
+	if err := c.Call(ctx, MethodCall, params, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// Screenshot 截取页面，format 为 FormatPNG 或 FormatJPEG
+func (c *Client) Screenshot(ctx context.Context, format string) ([]byte, error) {
+	var result ScreenshotResult
+	if err := c.Call(ctx, MethodScreenshot, ScreenshotParams{Format: format, Timeout: timeoutMillis(ctx)}, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// WindowState 读取窗口状态
+func (c *Client) WindowState(ctx context.Context) (WindowState, error) {
+	var state WindowState
+	err := c.Call(ctx, MethodGetWindowState, struct{}{}, &state)
+	return state, err
+}
+
+// SetWindowState 修改窗口状态
+func (c *Client) SetWindowState(ctx context.Context, params SetWindowStateParams) error {
+	return c.Call(ctx, MethodSetWindowState, params, nil)
+}
+
+// Events 列出可订阅的事件
+func (c *Client) Events(ctx context.Context) ([]string, error) {
+	var result EventsResult
+	if err := c.Call(ctx, MethodEvents, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// Subscribe 订阅事件，事件交给 OnEvent 设置的回调
+func (c *Client) Subscribe(ctx context.Context, events ...string) error {
+	return c.Call(ctx, MethodSubscribe, SubscribeParams{Events: events}, nil)
+}
+
+// Unsubscribe 取消订阅事件
+func (c *Client) Unsubscribe(ctx context.Context, events ...string) error {
+	return c.Call(ctx, MethodUnsubscribe, SubscribeParams{Events: events}, nil)
+}
+
+// OnEvent 设置事件回调。回调在读取消息的 goroutine 中按顺序调用，不应长时间阻塞
+func (c *Client) OnEvent(handler func(Event)) {
+	c.mu.Lock()
+	c.onEvent = handler
+	c.mu.Unlock()
+}
+
+// Done 返回在连接关闭时关闭的通道
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err 返回连接关闭的原因，连接未关闭时返回 nil
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	c.writeMu.Lock()
+	_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+func (c *Client) send(f frame) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.WriteJSON(f); err != nil {
+		return fmt.Errorf("remote: write: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) readLoop() {
+	var err error
+	for {
+		var message []byte
+		if _, message, err = c.conn.ReadMessage(); err != nil {
+			break
+		}
+		// 服务端的其他功能可能广播非 JSON 或其他格式的消息，忽略即可
+		var f frame
+		if json.Unmarshal(message, &f) != nil {
+			continue
+		}
+		switch f.Type {
+		case frameResponse:
+			c.mu.Lock()
+			ch := c.pending[f.ID]
+			delete(c.pending, f.ID)
+			c.mu.Unlock()
+			if ch != nil {
+				ch <- f
+			}
+		case frameRequest:
+			// 客户端不处理服务端发起的请求
+			go func(id, method string) {
+				_ = c.send(frame{Type: frameResponse, ID: id, Error: fmt.Sprintf("%s: %s", ErrUnknownMethod, method)})
+			}(f.ID, f.Method)
+		case framePublish:
+			if f.Room != EventRoom {
+				continue
+			}
+			var event Event
+			if json.Unmarshal(f.Data, &event) != nil {
+				continue
+			}
+			c.mu.Lock()
+			handler := c.onEvent
+			c.mu.Unlock()
+			if handler != nil {
+				handler(event)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.err = ErrClosed
+	if err != nil && !errors.Is(err, net.ErrClosed) && !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		c.err = fmt.Errorf("%w: %v", ErrClosed, err)
+	}
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
+
+// timeoutMillis 将 ctx 的截止时间转换为请求中的超时，让服务端与客户端同时放弃
+func timeoutMillis(ctx context.Context) int {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	ms := int(time.Until(deadline).Milliseconds())
+	if ms < 1 {
+		ms = 1
+	}
+	return ms
+}
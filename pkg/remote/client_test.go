@@ -0,0 +1,388 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yuaotian/go-win-webview2/pkg/remote"
+)
+
+// fakeTarget 是测试用的 remote.Target，记录收到的调用
+type fakeTarget struct {
+	mu        sync.Mutex
+	url       string
+	state     remote.WindowState
+	deadlines map[string]bool // 方法收到的 ctx 是否带有截止时间
+	block     chan struct{}   // 不为 nil 时 Navigate 阻塞到 ctx 结束或通道关闭
+}
+
+func newFakeTarget() *fakeTarget {
+	return &fakeTarget{
+		state:     remote.WindowState{State: remote.StateNormal, DPI: 96, Bounds: remote.Bounds{Width: 800, Height: 600}},
+		deadlines: map[string]bool{},
+	}
+}
+
+func (t *fakeTarget) record(method string, ctx context.Context) {
+	_, ok := ctx.Deadline()
+	t.mu.Lock()
+	t.deadlines[method] = ok
+	t.mu.Unlock()
+}
+
+func (t *fakeTarget) Navigate(ctx context.Context, url string) error {
+	t.record("navigate", ctx)
+	if t.block != nil {
+		select {
+		case <-t.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	t.mu.Lock()
+	t.url = url
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *fakeTarget) Eval(ctx context.Context, script string) (json.RawMessage, error) {
+	t.record("eval", ctx)
+	if script == "throw" {
+		return nil, errors.New("Error: boom")
+	}
+	return json.Marshal(len(script))
+}
+
+func (t *fakeTarget) Call(ctx context.Context, name string, args []json.RawMessage) (json.RawMessage, error) {
+	t.record("call", ctx)
+	if name != "add" {
+		return nil, fmt.Errorf("%w: %s", remote.ErrBindingNotFound, name)
+	}
+	sum := 0
+	for _, arg := range args {
+		var n int
+		if err := json.Unmarshal(arg, &n); err != nil {
+			return nil, err
+		}
+		sum += n
+	}
+	return json.Marshal(sum)
+}
+
+func (t *fakeTarget) Screenshot(ctx context.Context, format string) ([]byte, error) {
+	t.record("screenshot", ctx)
+	return []byte("image/" + format), nil
+}
+
+func (t *fakeTarget) WindowState(ctx context.Context) (remote.WindowState, error) {
+	t.record("window.get", ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.state
+	state.URL = t.url
+	return state, nil
+}
+
+func (t *fakeTarget) SetWindowState(ctx context.Context, params remote.SetWindowStateParams) error {
+	t.record("window.set", ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if params.Bounds != nil {
+		t.state.Bounds = *params.Bounds
+	}
+	if params.State != "" {
+		t.state.State = params.State
+	}
+	return nil
+}
+
+func (t *fakeTarget) Events() []string {
+	return []string{remote.EventNavigationCompleted, remote.EventZoomChanged}
+}
+
+func (t *fakeTarget) hasDeadline(method string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.deadlines[method]
+}
+
+// startServer 启动 httptest 服务，返回 ws:// 地址
+func startServer(t *testing.T, target remote.Target) (*remote.Server, string) {
+	t.Helper()
+	srv := &remote.Server{Target: target, Token: "secret"}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return srv, "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+}
+
+func dial(t *testing.T, url string) *remote.Client {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := remote.Dial(ctx, url, remote.Options{RemoteToken: "secret", Client: "test"})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestDial(t *testing.T) {
+	_, url := startServer(t, newFakeTarget())
+	c1 := dial(t, url)
+	c2 := dial(t, url)
+
+	if c1.ConnID() != "c1" || c2.ConnID() != "c2" {
+		t.Errorf("ConnID = %q, %q, want c1, c2", c1.ConnID(), c2.ConnID())
+	}
+	hello := c1.Server()
+	if hello.Version != remote.ProtocolVersion {
+		t.Errorf("Server().Version = %d, want %d", hello.Version, remote.ProtocolVersion)
+	}
+	if len(hello.Methods) != len(remote.Methods) {
+		t.Errorf("Server().Methods = %v, want %v", hello.Methods, remote.Methods)
+	}
+	if len(hello.Events) != 2 {
+		t.Errorf("Server().Events = %v", hello.Events)
+	}
+}
+
+func TestDialUnauthorized(t *testing.T) {
+	_, url := startServer(t, newFakeTarget())
+	ctx := testContext(t)
+	for _, token := range []string{"", "wrong"} {
+		c, err := remote.Dial(ctx, url, remote.Options{RemoteToken: token})
+		if err == nil {
+			c.Close()
+			t.Errorf("Dial with token %q succeeded", token)
+			continue
+		}
+		if !errors.Is(err, remote.ErrUnauthorized) {
+			t.Errorf("Dial with token %q error = %v, want ErrUnauthorized", token, err)
+		}
+	}
+}
+
+func TestDialWelcomeTimeout(t *testing.T) {
+	// 服务端接受连接但不发送 welcome
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		<-release
+	}))
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	c, err := remote.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http"), remote.Options{})
+	if err == nil {
+		c.Close()
+		t.Fatal("Dial succeeded without a welcome message")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dial error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Dial returned after %v", elapsed)
+	}
+}
+
+func TestNavigateAndWindowState(t *testing.T) {
+	target := newFakeTarget()
+	_, url := startServer(t, target)
+	c := dial(t, url)
+	ctx := testContext(t)
+
+	if err := c.Navigate(ctx, "https://example.com/"); err != nil {
+		t.Fatalf("Navigate: %v", err)
+	}
+	if err := c.Navigate(ctx, ""); err == nil {
+		t.Error("Navigate with empty url succeeded")
+	}
+
+	bounds := remote.Bounds{X: 10, Y: 20, Width: 1024, Height: 768}
+	if err := c.SetWindowState(ctx, remote.SetWindowStateParams{Bounds: &bounds, State: remote.StateMaximized}); err != nil {
+		t.Fatalf("SetWindowState: %v", err)
+	}
+	state, err := c.WindowState(ctx)
+	if err != nil {
+		t.Fatalf("WindowState: %v", err)
+	}
+	if state.URL != "https://example.com/" || state.State != remote.StateMaximized || state.Bounds != bounds {
+		t.Errorf("WindowState = %+v", state)
+	}
+
+	err = c.SetWindowState(ctx, remote.SetWindowStateParams{State: "docked"})
+	if !errors.Is(err, remote.ErrInvalidWindowState) {
+		t.Errorf("SetWindowState(docked) error = %v, want ErrInvalidWindowState", err)
+	}
+	zero := remote.Bounds{Width: 0, Height: 100}
+	err = c.SetWindowState(ctx, remote.SetWindowStateParams{Bounds: &zero})
+	if !errors.Is(err, remote.ErrInvalidWindowState) {
+		t.Errorf("SetWindowState(zero width) error = %v, want ErrInvalidWindowState", err)
+	}
+
+	// 没有超时参数的方法也使用服务端默认超时，避免窗口卡住时永远等待
+	for _, method := range []string{"navigate", "window.get", "window.set"} {
+		if !target.hasDeadline(method) {
+			t.Errorf("%s: target ctx has no deadline", method)
+		}
+	}
+}
+
+func TestNavigateCancelled(t *testing.T) {
+	target := newFakeTarget()
+	target.block = make(chan struct{})
+	defer close(target.block)
+	_, url := startServer(t, target)
+	c := dial(t, url)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.Navigate(ctx, "https://example.com/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Navigate error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestEvalAndCall(t *testing.T) {
+	target := newFakeTarget()
+	_, url := startServer(t, target)
+	c := dial(t, url)
+	ctx := testContext(t)
+
+	value, err := c.Eval(ctx, "1 + 2")
+	if err != nil || string(value) != "5" {
+		t.Errorf("Eval = %s, %v, want 5", value, err)
+	}
+	_, err = c.Eval(ctx, "throw")
+	var remoteErr *remote.Error
+	if !errors.As(err, &remoteErr) || remoteErr.Method != remote.MethodEval || remoteErr.Message != "Error: boom" {
+		t.Errorf("Eval(throw) error = %#v", err)
+	}
+	if !target.hasDeadline("eval") {
+		t.Error("eval: target ctx has no deadline")
+	}
+
+	value, err = c.CallBinding(ctx, "add", 1, 2, 3)
+	if err != nil || string(value) != "6" {
+		t.Errorf("CallBinding(add) = %s, %v, want 6", value, err)
+	}
+	if _, err := c.CallBinding(ctx, "missing"); !errors.Is(err, remote.ErrBindingNotFound) {
+		t.Errorf("CallBinding(missing) error = %v, want ErrBindingNotFound", err)
+	}
+	if err := c.Call(ctx, "remote.unknown", nil, nil); !errors.Is(err, remote.ErrUnknownMethod) {
+		t.Errorf("Call(remote.unknown) error = %v, want ErrUnknownMethod", err)
+	}
+}
+
+func TestScreenshot(t *testing.T) {
+	_, url := startServer(t, newFakeTarget())
+	c := dial(t, url)
+	ctx := testContext(t)
+
+	// 并发的截图请求都应完成
+	var wg sync.WaitGroup
+	for _, format := range []string{remote.FormatPNG, remote.FormatJPEG, ""} {
+		wg.Add(1)
+		go func(format string) {
+			defer wg.Done()
+			data, err := c.Screenshot(ctx, format)
+			want := format
+			if want == "" {
+				want = remote.FormatPNG
+			}
+			if err != nil || string(data) != "image/"+want {
+				t.Errorf("Screenshot(%q) = %q, %v", format, data, err)
+			}
+		}(format)
+	}
+	wg.Wait()
+
+	if _, err := c.Screenshot(ctx, "gif"); err == nil {
+		t.Error("Screenshot(gif) succeeded")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	srv, url := startServer(t, newFakeTarget())
+	c := dial(t, url)
+	ctx := testContext(t)
+
+	events := make(chan remote.Event, 4)
+	c.OnEvent(func(e remote.Event) { events <- e })
+
+	list, err := c.Events(ctx)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("Events = %v, %v", list, err)
+	}
+	if err := c.Subscribe(ctx, "unknownEvent"); !errors.Is(err, remote.ErrUnknownEvent) {
+		t.Errorf("Subscribe(unknownEvent) error = %v, want ErrUnknownEvent", err)
+	}
+	if err := c.Subscribe(ctx, remote.EventZoomChanged); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// 未订阅的事件不推送
+	if n, err := srv.Emit(remote.EventNavigationCompleted, "https://example.com/"); err != nil || n != 0 {
+		t.Errorf("Emit(navigationCompleted) = %d, %v, want 0", n, err)
+	}
+	if n, err := srv.Emit(remote.EventZoomChanged, 1.5); err != nil || n != 1 {
+		t.Errorf("Emit(zoomChanged) = %d, %v, want 1", n, err)
+	}
+	select {
+	case e := <-events:
+		if e.Name != remote.EventZoomChanged || string(e.Data) != "1.5" {
+			t.Errorf("event = %s %s", e.Name, e.Data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+
+	if err := c.Unsubscribe(ctx, remote.EventZoomChanged); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+	if n, _ := srv.Emit(remote.EventZoomChanged, 2); n != 0 {
+		t.Errorf("Emit after Unsubscribe = %d, want 0", n)
+	}
+}
+
+func TestClose(t *testing.T) {
+	_, url := startServer(t, newFakeTarget())
+	c := dial(t, url)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Error("Done not closed after Close")
+	}
+	if !errors.Is(c.Err(), remote.ErrClosed) {
+		t.Errorf("Err = %v, want ErrClosed", c.Err())
+	}
+	if err := c.Navigate(context.Background(), "https://example.com/"); !errors.Is(err, remote.ErrClosed) {
+		t.Errorf("Navigate after Close error = %v, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,214 @@
+// Package remote 定义通过 WebSocket 服务远程控制 WebView 的协议，并提供 Go 客户端。
+//
+// 协议基于 WebSocket 服务已有的消息格式：客户端发送
+// {"type":"request","id":"1","method":"remote.navigate","data":{...}}，
+// 服务端回复 {"type":"response","id":"1","data":{...}} 或带 error 字段的响应。
+// 连接后必须先调用 remote.hello 完成版本协商和认证，其他方法才可用。
+// 订阅的事件以 {"type":"publish","room":"remote.events","data":{"name":...,"data":...}} 推送。
+//
+// 该包不依赖 Windows API，可以在任意平台上使用 Client 和 Server 编写测试。
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ProtocolVersion 是协议版本，不兼容的修改会增加版本号
+const ProtocolVersion = 1
+
+// 协议方法
+const (
+	MethodHello          = "remote.hello"       // 版本协商和认证
+	MethodNavigate       = "remote.navigate"    // 导航到 URL
+	MethodEval           = "remote.eval"        // 执行脚本并返回结果
+	MethodCall           = "remote.call"        // 调用 Bind 绑定的函数
+	MethodScreenshot     = "remote.screenshot"  // 截取页面
+	MethodGetWindowState = "remote.window.get"  // 读取窗口状态
+	MethodSetWindowState = "remote.window.set"  // 修改窗口状态
+	MethodEvents         = "remote.events"      // 列出可订阅的事件
+	MethodSubscribe      = "remote.subscribe"   // 订阅事件
+	MethodUnsubscribe    = "remote.unsubscribe" // 取消订阅
+)
+
+// Methods 是当前版本支持的所有方法
+var Methods = []string{
+	MethodHello, MethodNavigate, MethodEval, MethodCall, MethodScreenshot,
+	MethodGetWindowState, MethodSetWindowState, MethodEvents, MethodSubscribe, MethodUnsubscribe,
+}
+
+// 可订阅的事件
+const (
+	EventNavigationCompleted = "navigationCompleted" // 导航完成，data 为 URL
+	EventFullscreenChanged   = "fullscreenChanged"   // 全屏状态变化，data 为 bool
+	EventZoomChanged         = "zoomChanged"         // 缩放比例变化，data 为 float64
+	EventDPIChanged          = "dpiChanged"          // DPI 变化，data 为 int
+)
+
+// EventRoom 是推送事件时 publish 消息的 room 字段
+const EventRoom = "remote.events"
+
+// 消息类型，与 WebSocket 服务的消息格式一致
+const (
+	frameWelcome  = "welcome"
+	framePublish  = "publish"
+	frameRequest  = "request"
+	frameResponse = "response"
+)
+
+// frame 是 WebSocket 消息的 JSON 格式
+type frame struct {
+	Type   string          `json:"type"`
+	ID     string          `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Room   string          `json:"room,omitempty"`
+	From   string          `json:"from,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// 服务端返回的错误信息，客户端收到的 *Error 可用 errors.Is 与之比较
+var (
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrVersionMismatch    = errors.New("protocol version mismatch")
+	ErrUnknownMethod      = errors.New("unknown method")
+	ErrUnknownEvent       = errors.New("unknown event")
+	ErrBindingNotFound    = errors.New("binding not found")
+	ErrInvalidWindowState = errors.New("invalid window state")
+)
+
+var wireErrors = []error{
+	ErrUnauthorized, ErrVersionMismatch, ErrUnknownMethod, ErrUnknownEvent,
+	ErrBindingNotFound, ErrInvalidWindowState,
+}
+
+// Error 是服务端处理请求失败时返回的错误
+type Error struct {
+	Method  string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("remote %s: %s", e.Method, e.Message)
+}
+
+// Is 按错误信息的前缀匹配本包定义的错误
+func (e *Error) Is(target error) bool {
+	for _, err := range wireErrors {
+		if target == err {
+			msg := err.Error()
+			return e.Message == msg || len(e.Message) > len(msg) && e.Message[:len(msg)+1] == msg+":"
+		}
+	}
+	return false
+}
+
+// HelloParams 是 remote.hello 的参数
+type HelloParams struct {
+	Version int    `json:"version"`          // 客户端使用的协议版本
+	Token   string `json:"token"`            // 远程控制令牌
+	Client  string `json:"client,omitempty"` // 客户端名称，仅用于日志
+}
+
+// HelloResult 是 remote.hello 的结果
+type HelloResult struct {
+	Version int      `json:"version"` // 服务端的协议版本
+	Methods []string `json:"methods"` // 服务端支持的方法
+	Events  []string `json:"events"`  // 可订阅的事件
+}
+
+// NavigateParams 是 remote.navigate 的参数
+type NavigateParams struct {
+	URL string `json:"url"`
+}
+
+// EvalParams 是 remote.eval 的参数。脚本作为表达式求值，
+// 结果是 Promise 时等待其完成，结果序列化为 JSON
+type EvalParams struct {
+	Script  string `json:"script"`
+	Timeout int    `json:"timeout,omitempty"` // 毫秒，0 表示使用服务端默认值
+}
+
+// EvalResult 是 remote.eval 的结果
+type EvalResult struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// CallParams 是 remote.call 的参数
+type CallParams struct {
+	Name    string            `json:"name"`
+	Args    []json.RawMessage `json:"args"`
+	Timeout int               `json:"timeout,omitempty"` // 毫秒，0 表示使用服务端默认值
+}
+
+// CallResult 是 remote.call 的结果
+type CallResult struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// 截图格式
+const (
+	FormatPNG  = "png"
+	FormatJPEG = "jpeg"
+)
+
+// ScreenshotParams 是 remote.screenshot 的参数
+type ScreenshotParams struct {
+	Format  string `json:"format,omitempty"`  // png 或 jpeg，默认 png
+	Timeout int    `json:"timeout,omitempty"` // 毫秒，0 表示使用服务端默认值
+}
+
+// ScreenshotResult 是 remote.screenshot 的结果
+type ScreenshotResult struct {
+	Format string `json:"format"`
+	Data   []byte `json:"data"` // base64 编码的图片
+}
+
+// 窗口显示状态
+const (
+	StateNormal     = "normal"
+	StateMaximized  = "maximized"
+	StateMinimized  = "minimized"
+	StateFullscreen = "fullscreen"
+)
+
+// Bounds 是窗口在屏幕上的位置和大小，单位为物理像素
+type Bounds struct {
+	X      int32 `json:"x"`
+	Y      int32 `json:"y"`
+	Width  int32 `json:"width"`
+	Height int32 `json:"height"`
+}
+
+// WindowState 是 remote.window.get 的结果
+type WindowState struct {
+	Bounds  Bounds `json:"bounds"`  // 还原状态下的位置和大小
+	State   string `json:"state"`   // normal、maximized、minimized 或 fullscreen
+	Monitor string `json:"monitor"` // 所在显示器的设备名
+	DPI     int    `json:"dpi"`     // 所在显示器的 DPI
+	URL     string `json:"url"`     // 当前页面的 URL
+}
+
+// SetWindowStateParams 是 remote.window.set 的参数，未设置的字段保持不变
+type SetWindowStateParams struct {
+	Bounds *Bounds `json:"bounds,omitempty"`
+	State  string  `json:"state,omitempty"`
+	Title  *string `json:"title,omitempty"`
+}
+
+// EventsResult 是 remote.events 的结果
+type EventsResult struct {
+	Events []string `json:"events"`
+}
+
+// SubscribeParams 是 remote.subscribe 和 remote.unsubscribe 的参数
+type SubscribeParams struct {
+	Events []string `json:"events"`
+}
+
+// Event 是订阅的事件
+type Event struct {
+	Name string          `json:"name"`
+	Data json.RawMessage `json:"data"`
+}
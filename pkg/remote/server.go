@@ -0,0 +1,138 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Server 是独立的协议服务端，将 Target 暴露在一个 http.Handler 上。
+// WebView 通过 EnableRemoteControl 在自身的 WebSocket 服务上提供协议，
+// Server 用于在没有 WebView 的环境中用假的 Target 测试客户端，或嵌入其他程序。
+// Server 不检查 WebSocket 服务的连接令牌，只检查 remote.hello 中的远程控制令牌
+type Server struct {
+	Target   Target
+	Token    string // 远程控制令牌
+	Upgrader websocket.Upgrader
+
+	mu     sync.Mutex
+	conns  map[*serverConn]bool
+	nextID uint64
+}
+
+type serverConn struct {
+	conn    *websocket.Conn
+	session *Session
+	writeMu sync.Mutex
+}
+
+func (c *serverConn) send(f frame) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(f)
+}
+
+// ServeHTTP 升级连接并处理请求，直到连接关闭
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &serverConn{conn: conn, session: NewSession(s.Target, s.Token)}
+
+	s.mu.Lock()
+	if s.conns == nil {
+		s.conns = make(map[*serverConn]bool)
+	}
+	s.conns[c] = true
+	s.nextID++
+	id := "c" + strconv.FormatUint(s.nextID, 10)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	// 与 WebSocket 服务相同，连接 ID 在 welcome 消息的 id 字段中
+	if err := c.send(frame{Type: frameWelcome, ID: id}); err != nil {
+		return
+	}
+
+	for {
+		var f frame
+		if err := conn.ReadJSON(&f); err != nil {
+			return
+		}
+		if f.Type != frameRequest {
+			continue
+		}
+		go func(f frame) {
+			resp := frame{Type: frameResponse, ID: f.ID}
+			result, err := c.session.Handle(context.Background(), f.Method, f.Data)
+			if err == nil {
+				resp.Data, err = json.Marshal(result)
+			}
+			if err != nil {
+				resp.Data = nil
+				resp.Error = err.Error()
+			}
+			if err := c.send(resp); err != nil {
+				log.Printf("Warning: remote reply failed: %v", err)
+			}
+		}(f)
+	}
+}
+
+// Emit 向订阅了事件的连接推送事件，返回推送成功的连接数
+func (s *Server) Emit(event string, data interface{}) (int, error) {
+	f, err := eventFrame(event, data)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	conns := make([]*serverConn, 0, len(s.conns))
+	for c := range s.conns {
+		if c.session.Subscribed(event) {
+			conns = append(conns, c)
+		}
+	}
+	s.mu.Unlock()
+
+	sent := 0
+	for _, c := range conns {
+		if c.send(f) == nil {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// EventFrame 返回推送事件的 WebSocket 消息
+func EventFrame(event string, data interface{}) ([]byte, error) {
+	f, err := eventFrame(event, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(f)
+}
+
+func eventFrame(event string, data interface{}) (frame, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return frame{}, err
+	}
+	payload, err := json.Marshal(Event{Name: event, Data: raw})
+	if err != nil {
+		return frame{}, err
+	}
+	return frame{Type: framePublish, Room: EventRoom, Data: payload}, nil
+}
@@ -0,0 +1,223 @@
+package remote
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout 是请求未指定超时时 eval、call 和截图的超时时间，也是导航和窗口操作的超时时间
+const DefaultTimeout = 30 * time.Second
+
+// Target 是被控制的 WebView，由服务端实现
+type Target interface {
+	Navigate(ctx context.Context, url string) error
+	Eval(ctx context.Context, script string) (json.RawMessage, error)
+	Call(ctx context.Context, name string, args []json.RawMessage) (json.RawMessage, error)
+	Screenshot(ctx context.Context, format string) ([]byte, error)
+	WindowState(ctx context.Context) (WindowState, error)
+	SetWindowState(ctx context.Context, params SetWindowStateParams) error
+	Events() []string
+}
+
+// Session 是一个连接上的协议状态，负责认证、参数解码和方法分发。
+// 每个连接使用一个 Session，可在多个 goroutine 中使用
+type Session struct {
+	target Target
+	token  string
+
+	mu     sync.Mutex
+	authed bool
+	client string
+	events map[string]bool
+}
+
+// NewSession 创建会话，token 为空时任何请求都不会通过认证
+func NewSession(target Target, token string) *Session {
+	return &Session{target: target, token: token}
+}
+
+// Authenticated 报告会话是否已通过 remote.hello 认证
+func (s *Session) Authenticated() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authed
+}
+
+// Client 返回 remote.hello 中的客户端名称
+func (s *Session) Client() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+// Subscribed 报告会话是否订阅了事件
+func (s *Session) Subscribed(event string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authed && s.events[event]
+}
+
+// Handle 处理一个请求，返回值序列化为 JSON 作为响应
+func (s *Session) Handle(ctx context.Context, method string, params json.RawMessage) (interface{}, error) {
+	if method == MethodHello {
+		return s.hello(params)
+	}
+	if !s.Authenticated() {
+		return nil, ErrUnauthorized
+	}
+
+	switch method {
+	case MethodNavigate:
+		var p NavigateParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		if p.URL == "" {
+			return nil, errors.New("invalid params: url is required")
+		}
+		ctx, cancel := withTimeout(ctx, 0)
+		defer cancel()
+		return struct{}{}, s.target.Navigate(ctx, p.URL)
+
+	case MethodEval:
+		var p EvalParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		ctx, cancel := withTimeout(ctx, p.Timeout)
+		defer cancel()
+		value, err := s.target.Eval(ctx, p.Script)
+		if err != nil {
+			return nil, err
+		}
+		return EvalResult{Value: value}, nil
+
+	case MethodCall:
+		var p CallParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		ctx, cancel := withTimeout(ctx, p.Timeout)
+		defer cancel()
+		value, err := s.target.Call(ctx, p.Name, p.Args)
+		if err != nil {
+			return nil, err
+		}
+		return CallResult{Value: value}, nil
+
+	case MethodScreenshot:
+		var p ScreenshotParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Format == "" {
+			p.Format = FormatPNG
+		}
+		if p.Format != FormatPNG && p.Format != FormatJPEG {
+			return nil, fmt.Errorf("unsupported screenshot format %q", p.Format)
+		}
+		ctx, cancel := withTimeout(ctx, p.Timeout)
+		defer cancel()
+		data, err := s.target.Screenshot(ctx, p.Format)
+		if err != nil {
+			return nil, err
+		}
+		return ScreenshotResult{Format: p.Format, Data: data}, nil
+
+	case MethodGetWindowState:
+		ctx, cancel := withTimeout(ctx, 0)
+		defer cancel()
+		return s.target.WindowState(ctx)
+
+	case MethodSetWindowState:
+		var p SetWindowStateParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		switch p.State {
+		case "", StateNormal, StateMaximized, StateMinimized, StateFullscreen:
+		default:
+			return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidWindowState, p.State)
+		}
+		if p.Bounds != nil && (p.Bounds.Width <= 0 || p.Bounds.Height <= 0) {
+			return nil, fmt.Errorf("%w: width and height must be positive", ErrInvalidWindowState)
+		}
+		ctx, cancel := withTimeout(ctx, 0)
+		defer cancel()
+		return struct{}{}, s.target.SetWindowState(ctx, p)
+
+	case MethodEvents:
+		return EventsResult{Events: s.target.Events()}, nil
+
+	case MethodSubscribe, MethodUnsubscribe:
+		var p SubscribeParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		known := map[string]bool{}
+		for _, event := range s.target.Events() {
+			known[event] = true
+		}
+		for _, event := range p.Events {
+			if !known[event] {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event)
+			}
+		}
+		s.mu.Lock()
+		if s.events == nil {
+			s.events = make(map[string]bool)
+		}
+		for _, event := range p.Events {
+			if method == MethodSubscribe {
+				s.events[event] = true
+			} else {
+				delete(s.events, event)
+			}
+		}
+		s.mu.Unlock()
+		return struct{}{}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMethod, method)
+}
+
+func (s *Session) hello(params json.RawMessage) (interface{}, error) {
+	var p HelloParams
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Version != ProtocolVersion {
+		return nil, fmt.Errorf("%w: client %d, server %d", ErrVersionMismatch, p.Version, ProtocolVersion)
+	}
+	if s.token == "" || subtle.ConstantTimeCompare([]byte(p.Token), []byte(s.token)) != 1 {
+		return nil, ErrUnauthorized
+	}
+
+	s.mu.Lock()
+	s.authed = true
+	s.client = p.Client
+	s.mu.Unlock()
+	return HelloResult{Version: ProtocolVersion, Methods: Methods, Events: s.target.Events()}, nil
+}
+
+func decode(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("invalid params: %v", err)
+	}
+	return nil
+}
+
+func withTimeout(ctx context.Context, ms int) (context.Context, context.CancelFunc) {
+	timeout := DefaultTimeout
+	if ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+	return context.WithTimeout(ctx, timeout)
+}
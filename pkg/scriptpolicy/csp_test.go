@@ -0,0 +1,99 @@
+package scriptpolicy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseCSP(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"default-src 'self'", "default-src 'self'"},
+		{"default-src 'self'; script-src 'self' https://cdn.example.com", "default-src 'self'; script-src 'self' https://cdn.example.com"},
+		// 多余的空白和空指令被忽略
+		{"  default-src   'self' ;; ;img-src data: ", "default-src 'self'; img-src data:"},
+		// 指令名不区分大小写，同名指令只保留第一个
+		{"Script-Src 'self'; script-src 'unsafe-inline'", "script-src 'self'"},
+		{"upgrade-insecure-requests; object-src 'none'", "upgrade-insecure-requests; object-src 'none'"},
+	}
+	for _, tt := range tests {
+		got := ParseCSP(tt.in).String()
+		if got != tt.want {
+			t.Errorf("ParseCSP(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		if again := ParseCSP(got).String(); again != got {
+			t.Errorf("ParseCSP(%q).String() = %q, want %q", got, again, got)
+		}
+	}
+}
+
+func TestDefaultCSP(t *testing.T) {
+	want := "default-src 'self'; script-src 'self'; object-src 'none'; base-uri 'self'"
+	if got := DefaultCSP().String(); got != want {
+		t.Errorf("DefaultCSP() = %q, want %q", got, want)
+	}
+}
+
+func TestCSPEdit(t *testing.T) {
+	c := ParseCSP("default-src 'self'; script-src 'self'")
+	c.Add("script-src", "'self'", "https://cdn.example.com").
+		Set("img-src", "data:").
+		Set("DEFAULT-SRC", "'none'").
+		Del("missing")
+	want := "default-src 'none'; script-src 'self' https://cdn.example.com; img-src data:"
+	if got := c.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	sources, ok := c.Get("Script-Src")
+	if !ok || !reflect.DeepEqual(sources, []string{"'self'", "https://cdn.example.com"}) {
+		t.Errorf("Get(script-src) = %v, %t", sources, ok)
+	}
+	// Get 返回副本
+	sources[0] = "changed"
+	if again, _ := c.Get("script-src"); again[0] != "'self'" {
+		t.Errorf("Get() returned the internal slice")
+	}
+
+	c.Del("img-src")
+	if _, ok := c.Get("img-src"); ok {
+		t.Error("Get(img-src) after Del succeeded")
+	}
+	if got := new(CSP).Add("style-src", "'self'").String(); got != "style-src 'self'" {
+		t.Errorf("Add() on a missing directive = %q", got)
+	}
+}
+
+func TestAllowScript(t *testing.T) {
+	// echo -n 'alert(1)' | openssl dgst -sha256 -binary | base64
+	const hash = "'sha256-bhHHL3z2vDgxUt0W3dWQOrprscmda2Y5pLsLg4GF+pI='"
+	if got := ScriptHash("alert(1)"); got != hash {
+		t.Errorf("ScriptHash() = %q, want %q", got, hash)
+	}
+	got := DefaultCSP().AllowScript("alert(1)").AllowScript("alert(1)")
+	if sources, _ := got.Get("script-src"); !reflect.DeepEqual(sources, []string{"'self'", hash}) {
+		t.Errorf("script-src = %v", sources)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(HeaderCSP, "frame-ancestors 'none'")
+	})
+	rec := httptest.NewRecorder()
+	Handler("default-src 'self'", false, next).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	want := []string{"default-src 'self'", "frame-ancestors 'none'"}
+	if got := rec.Header().Values(HeaderCSP); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", HeaderCSP, got, want)
+	}
+
+	h := http.Header{}
+	AddHeader(h, "default-src 'self'", true)
+	if h.Get(HeaderCSPReportOnly) != "default-src 'self'" || h.Get(HeaderCSP) != "" {
+		t.Errorf("AddHeader(reportOnly) = %v", h)
+	}
+}
@@ -0,0 +1,363 @@
+package scriptpolicy
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind 是词法单元的类型
+type tokenKind int
+
+const (
+	tokIdent    tokenKind = iota // 标识符和关键字
+	tokString                    // 字符串字面量，text 为解码后的值
+	tokTemplate                  // 模板字符串，text 为第一段的原文
+	tokNumber                    // 数字
+	tokRegexp                    // 正则表达式字面量
+	tokPunct                     // 标点和运算符
+)
+
+// token 是一个词法单元，pos 和 end 是在源码中的字节偏移
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	end  int
+}
+
+// puncts 按长度从长到短排列，取最长匹配
+var puncts = []string{
+	">>>=",
+	"===", "!==", "**=", "<<=", ">>=", ">>>", "...", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "**", "<<", ">>",
+}
+
+// regexpKeywords 之后的 / 是正则表达式的开始而不是除号
+var regexpKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// lexer 是宽松的 JavaScript 词法分析器，只识别扫描规则需要的结构：
+// 跳过注释，识别字符串、模板和正则表达式，使其中的内容不会被当作代码。
+// 遇到语法错误时尽量继续，不返回错误
+type lexer struct {
+	src    string
+	toks   []token
+	depth  int   // 花括号深度
+	braces []int // 进入模板插值时的花括号深度
+	code   []byte
+}
+
+// tokenize 返回 src 的词法单元和去掉注释的源码，注释替换为空格，偏移和行号不变
+func tokenize(src string) ([]token, string) {
+	l := &lexer{src: src, code: []byte(src)}
+	l.run()
+	return l.toks, string(l.code)
+}
+
+func (l *lexer) emit(kind tokenKind, text string, pos, end int) {
+	l.toks = append(l.toks, token{kind: kind, text: text, pos: pos, end: end})
+}
+
+func (l *lexer) blank(from, to int) {
+	for i := from; i < to && i < len(l.code); i++ {
+		if l.code[i] != '\n' && l.code[i] != '\r' {
+			l.code[i] = ' '
+		}
+	}
+}
+
+func (l *lexer) run() {
+	src := l.src
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			l.blank(i, i+end)
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i
+			} else {
+				end += 4
+			}
+			l.blank(i, i+end)
+			i += end
+		case c == '\'' || c == '"':
+			i = l.readString(i)
+		case c == '`':
+			i = l.readTemplate(i, i+1, true)
+		case c == '}' && len(l.braces) > 0 && l.depth == l.braces[len(l.braces)-1]:
+			// 模板插值结束，继续读取模板
+			l.braces = l.braces[:len(l.braces)-1]
+			i = l.readTemplate(i, i+1, false)
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			i = l.readNumber(i)
+		case c == '/' && l.regexpAllowed():
+			i = l.readRegexp(i)
+		case isIdentStart(src, i):
+			i = l.readIdent(i)
+		default:
+			i = l.readPunct(i)
+		}
+	}
+}
+
+func (l *lexer) readString(start int) int {
+	src := l.src
+	quote := src[start]
+	var b strings.Builder
+	i := start + 1
+	for i < len(src) {
+		c := src[i]
+		if c == quote {
+			i++
+			break
+		}
+		if c == '\n' {
+			// 未结束的字符串
+			break
+		}
+		if c == '\\' {
+			var s string
+			s, i = unescape(src, i)
+			b.WriteString(s)
+			continue
+		}
+		b.WriteByte(c)
+		i++
+	}
+	l.emit(tokString, b.String(), start, i)
+	return i
+}
+
+// readTemplate 从 i 开始读取模板的一段，遇到 ${ 时进入插值。
+// first 为 true 时是模板的开始，为插值之后的部分时不产生新的词法单元
+func (l *lexer) readTemplate(start, i int, first bool) int {
+	src := l.src
+	for i < len(src) {
+		switch {
+		case src[i] == '\\':
+			i += 2
+		case src[i] == '`':
+			if first {
+				l.emit(tokTemplate, src[start+1:i], start, i+1)
+			}
+			return i + 1
+		case strings.HasPrefix(src[i:], "${"):
+			if first {
+				l.emit(tokTemplate, src[start+1:i], start, i+2)
+			}
+			l.braces = append(l.braces, l.depth)
+			return i + 2
+		default:
+			i++
+		}
+	}
+	if first {
+		l.emit(tokTemplate, src[start+1:], start, len(src))
+	}
+	return len(src)
+}
+
+func (l *lexer) readNumber(start int) int {
+	src := l.src
+	i := start
+	for i < len(src) {
+		c := src[i]
+		if isDigit(c) || isLetter(c) || c == '_' || c == '.' {
+			i++
+			continue
+		}
+		// 指数的符号，如 1e+5
+		if (c == '+' || c == '-') && (src[i-1] == 'e' || src[i-1] == 'E') && !strings.HasPrefix(strings.ToLower(src[start:]), "0x") {
+			i++
+			continue
+		}
+		break
+	}
+	l.emit(tokNumber, src[start:i], start, i)
+	return i
+}
+
+func (l *lexer) readRegexp(start int) int {
+	src := l.src
+	i := start + 1
+	inClass := false
+	for i < len(src) {
+		c := src[i]
+		if c == '\\' {
+			i += 2
+			continue
+		}
+		if c == '\n' {
+			break
+		}
+		i++
+		if c == '[' {
+			inClass = true
+		} else if c == ']' {
+			inClass = false
+		} else if c == '/' && !inClass {
+			break
+		}
+	}
+	if i > len(src) {
+		i = len(src)
+	}
+	for i < len(src) && (isLetter(src[i]) || isDigit(src[i])) {
+		i++
+	}
+	l.emit(tokRegexp, src[start:i], start, i)
+	return i
+}
+
+func (l *lexer) readIdent(start int) int {
+	src := l.src
+	var b strings.Builder
+	i := start
+	for i < len(src) {
+		c := src[i]
+		if c == '\\' && i+1 < len(src) && src[i+1] == 'u' {
+			// 标识符中的 Unicode 转义，如 \u0065val
+			var s string
+			s, i = unescape(src, i)
+			b.WriteString(s)
+			continue
+		}
+		if c < utf8.RuneSelf {
+			if !isLetter(c) && !isDigit(c) && c != '_' && c != '$' {
+				break
+			}
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(src[i:])
+		b.WriteRune(r)
+		i += size
+	}
+	l.emit(tokIdent, b.String(), start, i)
+	return i
+}
+
+func (l *lexer) readPunct(start int) int {
+	src := l.src
+	for _, p := range puncts {
+		if strings.HasPrefix(src[start:], p) {
+			// ?. 之后是数字时是三元运算符，如 a?.5:1
+			if p == "?." && start+2 < len(src) && isDigit(src[start+2]) {
+				continue
+			}
+			l.emit(tokPunct, p, start, start+len(p))
+			return start + len(p)
+		}
+	}
+	c := src[start]
+	switch c {
+	case '{':
+		l.depth++
+	case '}':
+		l.depth--
+	}
+	l.emit(tokPunct, string(c), start, start+1)
+	return start + 1
+}
+
+// regexpAllowed 根据前一个词法单元判断 / 是否是正则表达式的开始
+func (l *lexer) regexpAllowed() bool {
+	if len(l.toks) == 0 {
+		return true
+	}
+	prev := l.toks[len(l.toks)-1]
+	switch prev.kind {
+	case tokIdent:
+		return regexpKeywords[prev.text]
+	case tokPunct:
+		switch prev.text {
+		case ")", "]", "}", "++", "--":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// unescape 解码从 i 开始的转义序列，返回解码后的字符串和之后的位置
+func unescape(src string, i int) (string, int) {
+	if i+1 >= len(src) {
+		return "", len(src)
+	}
+	c := src[i+1]
+	switch c {
+	case 'n':
+		return "\n", i + 2
+	case 't':
+		return "\t", i + 2
+	case 'r':
+		return "\r", i + 2
+	case 'b':
+		return "\b", i + 2
+	case 'f':
+		return "\f", i + 2
+	case 'v':
+		return "\v", i + 2
+	case '0':
+		return "\x00", i + 2
+	case '\r':
+		// 续行
+		if i+2 < len(src) && src[i+2] == '\n' {
+			return "", i + 3
+		}
+		return "", i + 2
+	case '\n':
+		return "", i + 2
+	case 'x':
+		if i+4 <= len(src) {
+			if v, err := strconv.ParseUint(src[i+2:i+4], 16, 8); err == nil {
+				return string(rune(v)), i + 4
+			}
+		}
+	case 'u':
+		if i+2 < len(src) && src[i+2] == '{' {
+			end := strings.IndexByte(src[i+3:], '}')
+			if end >= 0 {
+				if v, err := strconv.ParseUint(src[i+3:i+3+end], 16, 32); err == nil {
+					return string(rune(v)), i + 4 + end
+				}
+			}
+		} else if i+6 <= len(src) {
+			if v, err := strconv.ParseUint(src[i+2:i+6], 16, 16); err == nil {
+				return string(rune(v)), i + 6
+			}
+		}
+	}
+	r, size := utf8.DecodeRuneInString(src[i+1:])
+	return string(r), i + 1 + size
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentStart(src string, i int) bool {
+	c := src[i]
+	return isLetter(c) || c == '_' || c == '$' || c >= utf8.RuneSelf ||
+		(c == '\\' && i+1 < len(src) && src[i+1] == 'u')
+}
@@ -0,0 +1,334 @@
+// Package scriptpolicy 提供与平台无关的脚本策略：按规则拒绝危险用法的扫描器、
+// ed25519 脚本签名和 Content-Security-Policy 构造。
+// webview2 包中的 NewDenyPatternHook、NewSignedScriptHook 和 EnableCSP 将它们用于 WebView
+package scriptpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rule 描述一种被禁止的用法。设置 Pattern 时对去掉注释的源码按正则匹配，忽略其他匹配字段
+type Rule struct {
+	Name string
+
+	// Path 是成员访问路径，如 "eval"、"document.write"。
+	// 源码中的 window.、self.、globalThis. 等全局对象前缀会被去掉，
+	// 方括号中的字符串 (如 window["eval"]) 和 Unicode 转义也按成员访问处理
+	Path string
+	// AnyReceiver 为 true 时匹配任意对象上的该成员，如 "innerHTML" 匹配 el.innerHTML
+	AnyReceiver bool
+	// Call 为 true 时仅在调用时匹配，包括 new、.call、.apply、.bind 和带标签的模板
+	Call bool
+	// StringArg 为 true 时仅在以字符串或模板为第一个参数调用时匹配，如 setTimeout("...")
+	StringArg bool
+	// Assign 为 true 时仅在赋值时匹配，包括 += 等复合赋值
+	Assign bool
+
+	Pattern *regexp.Regexp
+}
+
+// Finding 是一处违反规则的用法
+type Finding struct {
+	Rule   string
+	Line   int // 从 1 开始
+	Column int // 从 1 开始，按字节计
+	Text   string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", f.Line, f.Column, f.Rule, f.Text)
+}
+
+// ViolationError 是 Check 发现违反规则时返回的错误
+type ViolationError struct {
+	Findings []Finding
+}
+
+func (e *ViolationError) Error() string {
+	parts := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		parts[i] = f.String()
+	}
+	return "script policy violation: " + strings.Join(parts, "; ")
+}
+
+// DefaultRules 返回默认的规则：动态执行代码 (eval、Function、字符串形式的定时器、动态 import)、
+// 写入 HTML (document.write、innerHTML 等) 和 javascript: URL
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: "eval", Path: "eval"},
+		{Name: "Function", Path: "Function", Call: true},
+		{Name: "constructor", Path: "constructor", AnyReceiver: true, StringArg: true},
+		{Name: "setTimeout", Path: "setTimeout", StringArg: true},
+		{Name: "setInterval", Path: "setInterval", StringArg: true},
+		{Name: "import", Path: "import", Call: true},
+		{Name: "document.write", Path: "document.write", Call: true},
+		{Name: "document.writeln", Path: "document.writeln", Call: true},
+		{Name: "innerHTML", Path: "innerHTML", AnyReceiver: true, Assign: true},
+		{Name: "outerHTML", Path: "outerHTML", AnyReceiver: true, Assign: true},
+		{Name: "insertAdjacentHTML", Path: "insertAdjacentHTML", AnyReceiver: true, Call: true},
+		{Name: "createContextualFragment", Path: "createContextualFragment", AnyReceiver: true, Call: true},
+		{Name: "javascript-url", Pattern: regexp.MustCompile(`(?i)\bjavascript\s*:`)},
+	}
+}
+
+// Scanner 检查脚本是否包含被禁止的用法。
+// 扫描是静态的：通过变量间接取得的函数 (如 const e = window; e.eval(...)) 无法识别，
+// 因此默认规则把对 eval 的任何引用都视为违规。Scanner 适合作为签名之外的第二道防线
+type Scanner struct {
+	Rules []Rule
+}
+
+// NewScanner 返回使用 rules 的扫描器，rules 为空时使用 DefaultRules
+func NewScanner(rules ...Rule) *Scanner {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Scanner{Rules: rules}
+}
+
+// globalObjects 是指向全局对象的名称，window.eval 与 eval 等价
+var globalObjects = map[string]bool{
+	"window": true, "self": true, "globalThis": true, "top": true, "parent": true, "frames": true,
+}
+
+// receiverUnknown 表示路径的起点不是标识符，如 [].constructor 或 f().innerHTML
+const receiverUnknown = ""
+
+// memberPath 是源码中的一个成员访问路径
+type memberPath struct {
+	segments []string
+	pos, end int
+	next     int // 路径之后的词法单元
+}
+
+// Scan 返回脚本中所有违反规则的用法，按出现的顺序排列
+func (s *Scanner) Scan(script string) []Finding {
+	toks, code := tokenize(script)
+	var findings []Finding
+	add := func(rule string, pos, end int) {
+		line, col := position(script, pos)
+		text := script[pos:end]
+		if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+			text = text[:i]
+		}
+		if len(text) > 80 {
+			text = text[:80] + "..."
+		}
+		findings = append(findings, Finding{Rule: rule, Line: line, Column: col, Text: text})
+	}
+
+	for _, p := range memberPaths(toks) {
+		for _, rule := range s.Rules {
+			if rule.Pattern == nil && rule.Path != "" && matchPath(rule, p, toks) {
+				add(rule.Name, p.pos, p.end)
+			}
+		}
+	}
+	for _, rule := range s.Rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		for _, loc := range rule.Pattern.FindAllStringIndex(code, -1) {
+			add(rule.Name, loc[0], loc[1])
+		}
+	}
+
+	// 按位置排序，正则规则的结果插入到对应位置
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings
+}
+
+// Check 在脚本违反规则时返回 *ViolationError
+func (s *Scanner) Check(script string) error {
+	if findings := s.Scan(script); len(findings) > 0 {
+		return &ViolationError{Findings: findings}
+	}
+	return nil
+}
+
+// position 将字节偏移转换为行号和列号
+func position(src string, pos int) (int, int) {
+	line := 1 + strings.Count(src[:pos], "\n")
+	col := pos + 1
+	if i := strings.LastIndexByte(src[:pos], '\n'); i >= 0 {
+		col = pos - i
+	}
+	return line, col
+}
+
+// memberPaths 从词法单元中提取成员访问路径，如 window["eval"] 得到 [window eval]
+func memberPaths(toks []token) []memberPath {
+	var paths []memberPath
+	consumed := 0
+	for i := 0; i < len(toks); i++ {
+		if i < consumed {
+			continue
+		}
+		t := toks[i]
+		var p memberPath
+		switch {
+		case t.kind == tokIdent:
+			if i > 0 && isMemberAccess(toks[i-1]) {
+				// 起点不是标识符的路径，如 [].constructor
+				p.segments = []string{receiverUnknown, t.text}
+			} else {
+				p.segments = []string{t.text}
+			}
+			p.pos, p.end, p.next = t.pos, t.end, i+1
+		case i > 0 && isExpressionEnd(toks[i-1]) && bracketMember(toks, i) != "":
+			p.segments = []string{receiverUnknown}
+			p.pos, p.end, p.next = t.pos, t.pos, i
+		default:
+			continue
+		}
+
+		for p.next < len(toks) {
+			j := p.next
+			if isMemberAccess(toks[j]) && j+1 < len(toks) && toks[j+1].kind == tokIdent {
+				p.segments = append(p.segments, toks[j+1].text)
+				p.end, p.next = toks[j+1].end, j+2
+				continue
+			}
+			if toks[j].kind == tokPunct && toks[j].text == "?." {
+				j++
+			}
+			if name := bracketMember(toks, j); name != "" {
+				p.segments = append(p.segments, name)
+				p.end, p.next = toks[j+2].end, j+3
+				continue
+			}
+			break
+		}
+		consumed = p.next
+		if isObjectKey(toks, i, p) {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// bracketMember 返回 ["name"] 形式的成员名，i 处不是该形式时返回空字符串
+func bracketMember(toks []token, i int) string {
+	if i+2 >= len(toks) || toks[i].kind != tokPunct || toks[i].text != "[" ||
+		toks[i+2].kind != tokPunct || toks[i+2].text != "]" {
+		return ""
+	}
+	switch toks[i+1].kind {
+	case tokString, tokTemplate:
+		return toks[i+1].text
+	}
+	return ""
+}
+
+// isObjectKey 判断路径是否是对象字面量的键，如 {eval: 1}
+func isObjectKey(toks []token, i int, p memberPath) bool {
+	if len(p.segments) != 1 || i == 0 || p.next >= len(toks) {
+		return false
+	}
+	prev, next := toks[i-1], toks[p.next]
+	return prev.kind == tokPunct && (prev.text == "{" || prev.text == ",") &&
+		next.kind == tokPunct && next.text == ":"
+}
+
+func isMemberAccess(t token) bool {
+	return t.kind == tokPunct && (t.text == "." || t.text == "?.")
+}
+
+func isExpressionEnd(t token) bool {
+	return t.kind == tokPunct && (t.text == ")" || t.text == "]")
+}
+
+// matchPath 判断路径是否违反规则
+func matchPath(rule Rule, p memberPath, toks []token) bool {
+	want := strings.Split(rule.Path, ".")
+	segments := p.segments
+	if segments[0] != receiverUnknown {
+		for len(segments) > 1 && globalObjects[segments[0]] {
+			segments = segments[1:]
+		}
+	}
+
+	if rule.AnyReceiver {
+		// 成员必须在某个对象上访问，单独的同名变量不算
+		for k := 1; k+len(want) <= len(segments); k++ {
+			if equal(segments[k:k+len(want)], want) && matchUse(rule, segments[k+len(want):], p, toks) {
+				return true
+			}
+		}
+		return false
+	}
+	if segments[0] == receiverUnknown || len(segments) < len(want) || !equal(segments[:len(want)], want) {
+		return false
+	}
+	return matchUse(rule, segments[len(want):], p, toks)
+}
+
+// matchUse 判断路径的用法是否符合规则的 Call、StringArg 和 Assign 条件，rest 是规则路径之后的成员
+func matchUse(rule Rule, rest []string, p memberPath, toks []token) bool {
+	if !rule.Call && !rule.StringArg && !rule.Assign {
+		return true
+	}
+	var next, arg *token
+	if p.next < len(toks) {
+		next = &toks[p.next]
+	}
+	if p.next+1 < len(toks) {
+		arg = &toks[p.next+1]
+	}
+
+	if rule.Assign {
+		return len(rest) == 0 && next != nil && next.kind == tokPunct && isAssignOp(next.text)
+	}
+	if len(rest) > 0 {
+		if len(rest) != 1 || (rest[0] != "call" && rest[0] != "apply" && rest[0] != "bind") {
+			return false
+		}
+		// .call、.apply 的第一个参数是 this，无法判断字符串参数，按调用处理
+		return next != nil && isCall(*next)
+	}
+	if next == nil || !isCall(*next) {
+		return false
+	}
+	if rule.StringArg {
+		if next.kind == tokTemplate {
+			return true
+		}
+		return arg != nil && (arg.kind == tokString || arg.kind == tokTemplate)
+	}
+	return true
+}
+
+func isCall(t token) bool {
+	return t.kind == tokTemplate || (t.kind == tokPunct && t.text == "(")
+}
+
+func isAssignOp(op string) bool {
+	switch op {
+	case "=", "+=", "||=", "&&=", "??=":
+		return true
+	}
+	return false
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package scriptpolicy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func ruleNames(findings []Finding) []string {
+	var names []string
+	for _, f := range findings {
+		names = append(names, f.Rule)
+	}
+	return names
+}
+
+func TestScanDefaultRules(t *testing.T) {
+	tests := []struct {
+		script string
+		want   []string
+	}{
+		{`eval("1")`, []string{"eval"}},
+		{`window.eval(code)`, []string{"eval"}},
+		{`var e = eval;`, []string{"eval"}},
+		{`window["eval"]("1")`, []string{"eval"}},
+		{`globalThis['eval'](x)`, []string{"eval"}},
+		{"self[`eval`](x)", []string{"eval"}},
+		{`window["\u0065val"](x)`, []string{"eval"}},
+		{`\u0065val(x)`, []string{"eval"}},
+		{`new Function("return 1")`, []string{"Function"}},
+		{`Function.call(null, "a")`, []string{"Function"}},
+		{`[].constructor.constructor("alert(1)")()`, []string{"constructor"}},
+		{"f.constructor`code`", []string{"constructor"}},
+		{`setTimeout("tick()", 10)`, []string{"setTimeout"}},
+		{"setInterval(`tick()`, 10)", []string{"setInterval"}},
+		{`import("./mod.js")`, []string{"import"}},
+		{`document.write("<b>")`, []string{"document.write"}},
+		{`el.innerHTML = html`, []string{"innerHTML"}},
+		{`el.outerHTML += html`, []string{"outerHTML"}},
+		{`el.insertAdjacentHTML("beforeend", html)`, []string{"insertAdjacentHTML"}},
+		{`range.createContextualFragment(html)`, []string{"createContextualFragment"}},
+		{`a.href = "javascript:alert(1)"`, []string{"javascript-url"}},
+		{"eval(a);\nel.innerHTML = b", []string{"eval", "innerHTML"}},
+
+		// 不违反规则的用法
+		{`setTimeout(tick, 10)`, nil},
+		{`setTimeout(() => eva1(), 10)`, nil},
+		{`x.constructor(1)`, nil},
+		{`obj.constructor === Object`, nil},
+		{`var innerHTML = 1; el.innerHTML`, nil},
+		{`if (el.innerHTML == "") {}`, nil},
+		{`({eval: 1, innerHTML: 2})`, nil},
+		{`document.writeable`, nil},
+		{`let evaluate = 1`, nil},
+		{`Function.prototype`, nil},
+		{`import x from "./mod.js"`, nil},
+
+		// 字符串、注释和正则中的文本不算
+		{`var s = "eval(1)"`, nil},
+		{`var s = 'el.innerHTML = 1'`, nil},
+		{"var s = `setTimeout(\"x\")`", nil},
+		{`// eval(1)`, nil},
+		{"/* document.write(\"x\") */", nil},
+		{`var re = /eval\(/g`, nil},
+		{`x = a / eval_count / 2`, nil},
+		{`if (/innerHTML = /.test(s)) {}`, nil},
+		{`// javascript:void(0)`, nil},
+	}
+	s := NewScanner()
+	for _, tt := range tests {
+		if got := ruleNames(s.Scan(tt.script)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Scan(%q) = %v, want %v", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestScanPosition(t *testing.T) {
+	findings := NewScanner().Scan("var a = 1;\n  window[\"eval\"](a)")
+	if len(findings) != 1 {
+		t.Fatalf("Scan() = %v, want 1 finding", findings)
+	}
+	want := Finding{Rule: "eval", Line: 2, Column: 3, Text: `window["eval"]`}
+	if findings[0] != want {
+		t.Errorf("Scan() = %+v, want %+v", findings[0], want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := NewScanner()
+	if err := s.Check(`console.log("eval")`); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+	err := s.Check(`eval(x)`)
+	var v *ViolationError
+	if !errors.As(err, &v) || len(v.Findings) != 1 {
+		t.Fatalf("Check() = %v, want *ViolationError", err)
+	}
+	if want := "script policy violation: 1:1: eval (eval)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestScanCustomRules(t *testing.T) {
+	s := NewScanner(Rule{Name: "fetch", Path: "fetch", Call: true})
+	if got := ruleNames(s.Scan(`window.fetch("/api"); eval(x)`)); !reflect.DeepEqual(got, []string{"fetch"}) {
+		t.Errorf("Scan() = %v, want [fetch]", got)
+	}
+}
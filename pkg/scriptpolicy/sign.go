@@ -0,0 +1,102 @@
+package scriptpolicy
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SignaturePrefix 是签名行的前缀，签名行是脚本的最后一行：
+//
+//	<script>
+//	//# signature=ed25519:<base64 签名>
+//
+// 签名针对签名行之前 (不含换行) 的全部字节
+const SignaturePrefix = "//# signature=ed25519:"
+
+var (
+	// ErrUnsigned 表示脚本没有签名行
+	ErrUnsigned = errors.New("script is not signed")
+	// ErrBadSignature 表示签名无效或不是由受信任的密钥签名
+	ErrBadSignature = errors.New("script signature is not valid for any trusted key")
+)
+
+// Sign 用 key 签名脚本，返回附加了签名行的脚本
+func Sign(key ed25519.PrivateKey, script string) string {
+	sig := ed25519.Sign(key, []byte(script))
+	return script + "\n" + SignaturePrefix + base64.StdEncoding.EncodeToString(sig)
+}
+
+// SplitSignature 将签名的脚本拆分为脚本和签名，没有签名行时返回 ErrUnsigned
+func SplitSignature(signed string) (string, []byte, error) {
+	trimmed := strings.TrimRight(signed, " \t\r\n")
+	var script, encoded string
+	if i := strings.LastIndex(trimmed, "\n"+SignaturePrefix); i >= 0 {
+		script, encoded = trimmed[:i], trimmed[i+1+len(SignaturePrefix):]
+	} else if strings.HasPrefix(trimmed, SignaturePrefix) {
+		encoded = trimmed[len(SignaturePrefix):]
+	} else {
+		return "", nil, ErrUnsigned
+	}
+	if strings.ContainsAny(encoded, "\r\n") {
+		// 签名行之后还有代码
+		return "", nil, ErrUnsigned
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return "", nil, fmt.Errorf("%w: malformed signature", ErrBadSignature)
+	}
+	return script, sig, nil
+}
+
+// Verifier 验证脚本是否由受信任的密钥签名
+type Verifier struct {
+	Keys []ed25519.PublicKey
+}
+
+// NewVerifier 返回信任 keys 的验证器
+func NewVerifier(keys ...ed25519.PublicKey) *Verifier {
+	return &Verifier{Keys: keys}
+}
+
+// Verify 验证签名的脚本，返回去掉签名行的脚本和签名的密钥
+func (v *Verifier) Verify(signed string) (string, ed25519.PublicKey, error) {
+	script, sig, err := SplitSignature(signed)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, key := range v.Keys {
+		if len(key) == ed25519.PublicKeySize && ed25519.Verify(key, []byte(script), sig) {
+			return script, key, nil
+		}
+	}
+	return "", nil, ErrBadSignature
+}
+
+// ParsePublicKey 解析 base64 编码的 ed25519 公钥，便于将公钥嵌入程序
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: want %d bytes, got %d", ed25519.PublicKeySize, len(b))
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// KeyID 返回公钥的短标识 (SHA-256 的前 8 字节)，用于日志和审计记录
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Digest 返回脚本的 SHA-256 摘要 (base64)，与 CSP 中的 'sha256-...' 使用相同的编码
+func Digest(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
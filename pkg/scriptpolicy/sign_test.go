@@ -0,0 +1,120 @@
+package scriptpolicy
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(t *testing.T, seed byte) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	priv := ed25519.NewKeyFromSeed([]byte(strings.Repeat(string(rune('a'+seed)), ed25519.SeedSize)))
+	return priv.Public().(ed25519.PublicKey), priv
+}
+
+func TestSignVerify(t *testing.T) {
+	pub, priv := testKey(t, 0)
+	other, _ := testKey(t, 1)
+	script := "console.log(1);\nconsole.log(2);"
+	signed := Sign(priv, script)
+
+	if !strings.HasPrefix(signed, script+"\n"+SignaturePrefix) {
+		t.Fatalf("Sign() = %q", signed)
+	}
+	got, key, err := NewVerifier(other, pub).Verify(signed)
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if got != script || !key.Equal(pub) {
+		t.Errorf("Verify() = %q, %x, want %q, %x", got, key, script, pub)
+	}
+	// 结尾的空白不影响验证
+	if _, _, err := NewVerifier(pub).Verify(signed + "\r\n\n"); err != nil {
+		t.Errorf("Verify(trailing newline) error: %v", err)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	pub, priv := testKey(t, 0)
+	other, _ := testKey(t, 1)
+	script := "console.log(1);"
+	signed := Sign(priv, script)
+	sigLine := signed[len(script)+1:]
+
+	tests := []struct {
+		name   string
+		signed string
+		keys   []ed25519.PublicKey
+		want   error
+	}{
+		{"unsigned", script, []ed25519.PublicKey{pub}, ErrUnsigned},
+		{"tampered", strings.Replace(signed, "1", "2", 1), []ed25519.PublicKey{pub}, ErrBadSignature},
+		{"prepended", "alert(1);\n" + signed, []ed25519.PublicKey{pub}, ErrBadSignature},
+		{"trailing code", signed + "\nalert(1);", []ed25519.PublicKey{pub}, ErrUnsigned},
+		{"wrong key", signed, []ed25519.PublicKey{other}, ErrBadSignature},
+		{"no keys", signed, nil, ErrBadSignature},
+		{"short key", signed, []ed25519.PublicKey{pub[:16]}, ErrBadSignature},
+		{"malformed", script + "\n" + SignaturePrefix + "not base64!", []ed25519.PublicKey{pub}, ErrBadSignature},
+		{"truncated", script + "\n" + sigLine[:len(sigLine)-8], []ed25519.PublicKey{pub}, ErrBadSignature},
+	}
+	for _, tt := range tests {
+		got, key, err := NewVerifier(tt.keys...).Verify(tt.signed)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: Verify() error = %v, want %v", tt.name, err, tt.want)
+		}
+		if got != "" || key != nil {
+			t.Errorf("%s: Verify() = %q, %x, want empty", tt.name, got, key)
+		}
+	}
+}
+
+func TestSplitSignature(t *testing.T) {
+	_, priv := testKey(t, 0)
+	sig := ed25519.Sign(priv, []byte(""))
+	line := SignaturePrefix + base64.StdEncoding.EncodeToString(sig)
+
+	tests := []struct {
+		signed, script string
+		err            error
+	}{
+		{"a\n" + line, "a", nil},
+		{"a\nb\n" + line + "  \n", "a\nb", nil},
+		// 只有签名行时脚本为空
+		{line, "", nil},
+		// 签名前缀必须在行首
+		{"a; " + line, "", ErrUnsigned},
+		{line + "\nb", "", ErrUnsigned},
+		{"", "", ErrUnsigned},
+	}
+	for _, tt := range tests {
+		script, got, err := SplitSignature(tt.signed)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("SplitSignature(%q) error = %v, want %v", tt.signed, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if script != tt.script || string(got) != string(sig) {
+			t.Errorf("SplitSignature(%q) = %q, %x", tt.signed, script, got)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _ := testKey(t, 0)
+	got, err := ParsePublicKey(" " + base64.StdEncoding.EncodeToString(pub) + "\n")
+	if err != nil || !got.Equal(pub) {
+		t.Errorf("ParsePublicKey() = %x, %v, want %x", got, err, pub)
+	}
+	for _, in := range []string{"", "not base64!", base64.StdEncoding.EncodeToString(pub[:31])} {
+		if _, err := ParsePublicKey(in); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded, want error", in)
+		}
+	}
+	if id := KeyID(pub); len(id) != 16 {
+		t.Errorf("KeyID() = %q, want 16 hex digits", id)
+	}
+}
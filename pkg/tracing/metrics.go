@@ -0,0 +1,225 @@
+package tracing
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets 是持续时间直方图的默认上界
+var DefaultBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Metrics 按 Kind 和 Name 汇总 Span 的次数、错误、字节数和持续时间，可在多个 goroutine 中使用
+type Metrics struct {
+	// Namespace 是 Prometheus 指标名的前缀，为空时使用 "webview2"
+	Namespace string
+
+	mu      sync.Mutex
+	buckets []time.Duration
+	series  map[seriesKey]*series
+}
+
+type seriesKey struct {
+	kind Kind
+	name string
+}
+
+type series struct {
+	count, errors, bytes uint64
+	total, max           time.Duration
+	buckets              []uint64
+}
+
+// Stat 是一组 Span 的汇总
+type Stat struct {
+	Kind    Kind          `json:"kind"`
+	Name    string        `json:"name"`
+	Count   uint64        `json:"count"`
+	Errors  uint64        `json:"errors"`
+	Bytes   uint64        `json:"bytes"`
+	Total   time.Duration `json:"total"`
+	Max     time.Duration `json:"max"`
+	Buckets []Bucket      `json:"buckets"`
+}
+
+// Bucket 是直方图中持续时间不超过 Le 的 Span 数量 (累计)
+type Bucket struct {
+	Le    time.Duration `json:"le"`
+	Count uint64        `json:"count"`
+}
+
+// Mean 返回平均持续时间
+func (s Stat) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// NewMetrics 返回使用 buckets 作为直方图上界的汇总，buckets 为空时使用 DefaultBuckets
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	return &Metrics{buckets: buckets, series: make(map[seriesKey]*series)}
+}
+
+func (m *Metrics) Observe(span Span) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.series == nil {
+		// 零值的 Metrics 使用默认的直方图上界
+		if m.buckets == nil {
+			m.buckets = DefaultBuckets
+		}
+		m.series = make(map[seriesKey]*series)
+	}
+	key := seriesKey{span.Kind, span.Name}
+	s := m.series[key]
+	if s == nil {
+		s = &series{buckets: make([]uint64, len(m.buckets))}
+		m.series[key] = s
+	}
+	s.count++
+	if span.Err != nil {
+		s.errors++
+	}
+	if span.Size > 0 {
+		s.bytes += uint64(span.Size)
+	}
+	s.total += span.Duration
+	if span.Duration > s.max {
+		s.max = span.Duration
+	}
+	for i, le := range m.buckets {
+		if span.Duration <= le {
+			s.buckets[i]++
+		}
+	}
+}
+
+// Snapshot 返回当前的汇总，按 Kind 和 Name 排序
+func (m *Metrics) Snapshot() []Stat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make([]Stat, 0, len(m.series))
+	for key, s := range m.series {
+		stat := Stat{
+			Kind: key.kind, Name: key.name,
+			Count: s.count, Errors: s.errors, Bytes: s.bytes,
+			Total: s.total, Max: s.max,
+			Buckets: make([]Bucket, len(m.buckets)),
+		}
+		for i, le := range m.buckets {
+			stat.Buckets[i] = Bucket{Le: le, Count: s.buckets[i]}
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Kind != stats[j].Kind {
+			return stats[i].Kind < stats[j].Kind
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// Reset 清空汇总
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.series = nil
+}
+
+// Var 返回输出 Snapshot 的 expvar.Var
+func (m *Metrics) Var() expvar.Var {
+	return expvar.Func(func() interface{} {
+		return m.Snapshot()
+	})
+}
+
+// Publish 以 name 发布到 expvar (/debug/vars)。与 expvar.Publish 相同，name 重复时 panic
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, m.Var())
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出汇总:
+//
+//	<ns>_span_duration_seconds  直方图
+//	<ns>_span_errors_total      失败次数
+//	<ns>_span_bytes_total       脚本、参数或消息的字节数
+//
+// 标签为 kind 和 name
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	ns := m.Namespace
+	if ns == "" {
+		ns = "webview2"
+	}
+	stats := m.Snapshot()
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# HELP %s_span_duration_seconds Duration of WebView operations.\n", ns)
+	fmt.Fprintf(bw, "# TYPE %s_span_duration_seconds histogram\n", ns)
+	for _, s := range stats {
+		labels := promLabels(s)
+		for _, b := range s.Buckets {
+			fmt.Fprintf(bw, "%s_span_duration_seconds_bucket{%s,le=\"%s\"} %d\n", ns, labels, promFloat(b.Le.Seconds()), b.Count)
+		}
+		fmt.Fprintf(bw, "%s_span_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", ns, labels, s.Count)
+		fmt.Fprintf(bw, "%s_span_duration_seconds_sum{%s} %s\n", ns, labels, promFloat(s.Total.Seconds()))
+		fmt.Fprintf(bw, "%s_span_duration_seconds_count{%s} %d\n", ns, labels, s.Count)
+	}
+
+	fmt.Fprintf(bw, "# HELP %s_span_errors_total Failed WebView operations.\n", ns)
+	fmt.Fprintf(bw, "# TYPE %s_span_errors_total counter\n", ns)
+	for _, s := range stats {
+		fmt.Fprintf(bw, "%s_span_errors_total{%s} %d\n", ns, promLabels(s), s.Errors)
+	}
+
+	fmt.Fprintf(bw, "# HELP %s_span_bytes_total Size of scripts, arguments and messages.\n", ns)
+	fmt.Fprintf(bw, "# TYPE %s_span_bytes_total counter\n", ns)
+	for _, s := range stats {
+		fmt.Fprintf(bw, "%s_span_bytes_total{%s} %d\n", ns, promLabels(s), s.Bytes)
+	}
+	return bw.Flush()
+}
+
+// Handler 返回以 Prometheus 文本格式输出汇总的 http.Handler
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.WritePrometheus(w)
+	})
+}
+
+func promLabels(s Stat) string {
+	return fmt.Sprintf("kind=%s,name=%s", promQuote(string(s.Kind)), promQuote(s.Name))
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promQuote(s string) string {
+	return `"` + promEscaper.Replace(s) + `"`
+}
+
+func promFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
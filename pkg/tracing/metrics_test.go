@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsBuckets(t *testing.T) {
+	m := NewMetrics(100*time.Millisecond, 10*time.Millisecond)
+	for _, s := range []Span{
+		{Kind: KindEval, Name: "eval", Duration: 5 * time.Millisecond, Size: 10},
+		{Kind: KindEval, Name: "eval", Duration: 10 * time.Millisecond, Size: 20},
+		{Kind: KindEval, Name: "eval", Duration: 50 * time.Millisecond, Err: errors.New("failed")},
+		{Kind: KindEval, Name: "eval", Duration: time.Second, Size: -1},
+		{Kind: KindRPC, Name: "add", Duration: time.Millisecond},
+	} {
+		m.Observe(s)
+	}
+
+	stats := m.Snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("Snapshot() = %d stats, want 2", len(stats))
+	}
+	want := Stat{
+		Kind: KindEval, Name: "eval",
+		Count: 4, Errors: 1, Bytes: 30,
+		Total: 1065 * time.Millisecond, Max: time.Second,
+		// 上界按升序排列，计数是累计的，边界值计入该桶
+		Buckets: []Bucket{{Le: 10 * time.Millisecond, Count: 2}, {Le: 100 * time.Millisecond, Count: 3}},
+	}
+	if !reflect.DeepEqual(stats[0], want) {
+		t.Errorf("Snapshot()[0] = %+v, want %+v", stats[0], want)
+	}
+	if got := stats[0].Mean(); got != 1065*time.Millisecond/4 {
+		t.Errorf("Mean() = %v", got)
+	}
+	if stats[1].Kind != KindRPC || stats[1].Count != 1 {
+		t.Errorf("Snapshot()[1] = %+v", stats[1])
+	}
+
+	m.Reset()
+	if got := m.Snapshot(); len(got) != 0 {
+		t.Errorf("Snapshot() after Reset = %v, want empty", got)
+	}
+	if got := (Stat{}).Mean(); got != 0 {
+		t.Errorf("Stat{}.Mean() = %v, want 0", got)
+	}
+}
+
+func TestMetricsZeroValue(t *testing.T) {
+	var m Metrics
+	m.Observe(Span{Kind: KindInit, Name: "init", Duration: 2 * time.Second})
+	stats := m.Snapshot()
+	if len(stats) != 1 || len(stats[0].Buckets) != len(DefaultBuckets) {
+		t.Fatalf("Snapshot() = %+v", stats)
+	}
+	last := stats[0].Buckets[len(DefaultBuckets)-1]
+	if last.Le != 5*time.Second || last.Count != 1 || stats[0].Buckets[5].Count != 0 {
+		t.Errorf("Buckets = %+v", stats[0].Buckets)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	m := NewMetrics(time.Millisecond, 500*time.Millisecond)
+	m.Namespace = "app"
+	m.Observe(Span{Kind: KindRPC, Name: `say "hi"` + "\n" + `a\b`, Duration: 250 * time.Millisecond, Size: 7, Err: errors.New("x")})
+	m.Observe(Span{Kind: KindEval, Name: "eval", Duration: 500 * time.Microsecond})
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() error: %v", err)
+	}
+	const rpc = `kind="rpc",name="say \"hi\"\na\\b"`
+	want := strings.Join([]string{
+		`# HELP app_span_duration_seconds Duration of WebView operations.`,
+		`# TYPE app_span_duration_seconds histogram`,
+		`app_span_duration_seconds_bucket{kind="eval",name="eval",le="0.001"} 1`,
+		`app_span_duration_seconds_bucket{kind="eval",name="eval",le="0.5"} 1`,
+		`app_span_duration_seconds_bucket{kind="eval",name="eval",le="+Inf"} 1`,
+		`app_span_duration_seconds_sum{kind="eval",name="eval"} 0.0005`,
+		`app_span_duration_seconds_count{kind="eval",name="eval"} 1`,
+		`app_span_duration_seconds_bucket{` + rpc + `,le="0.001"} 0`,
+		`app_span_duration_seconds_bucket{` + rpc + `,le="0.5"} 1`,
+		`app_span_duration_seconds_bucket{` + rpc + `,le="+Inf"} 1`,
+		`app_span_duration_seconds_sum{` + rpc + `} 0.25`,
+		`app_span_duration_seconds_count{` + rpc + `} 1`,
+		`# HELP app_span_errors_total Failed WebView operations.`,
+		`# TYPE app_span_errors_total counter`,
+		`app_span_errors_total{kind="eval",name="eval"} 0`,
+		`app_span_errors_total{` + rpc + `} 1`,
+		`# HELP app_span_bytes_total Size of scripts, arguments and messages.`,
+		`# TYPE app_span_bytes_total counter`,
+		`app_span_bytes_total{kind="eval",name="eval"} 0`,
+		`app_span_bytes_total{` + rpc + `} 7`,
+		``,
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WritePrometheus() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Namespace 为空时使用 webview2
+	buf.Reset()
+	m.Namespace = ""
+	_ = m.WritePrometheus(&buf)
+	if !strings.HasPrefix(buf.String(), "# HELP webview2_span_duration_seconds ") {
+		t.Errorf("WritePrometheus() without namespace = %q", buf.String()[:60])
+	}
+}
@@ -0,0 +1,137 @@
+// Package tracing 定义 WebView 中脚本执行、绑定调用、Web 消息和导航的跟踪记录 (Span)，
+// 并提供与平台无关的环形缓冲记录器和指标汇总 (expvar 和 Prometheus 文本格式)。
+//
+// Span 在操作结束后一次性交给 Observer，包含开始时间和持续时间，
+// 可以直接转换为 OpenTelemetry 的 span：
+//
+//	tracing.ObserverFunc(func(s tracing.Span) {
+//		_, span := tracer.Start(ctx, string(s.Kind)+" "+s.Name, trace.WithTimestamp(s.Start))
+//		if s.Err != nil {
+//			span.RecordError(s.Err)
+//		}
+//		span.End(trace.WithTimestamp(s.End()))
+//	})
+package tracing
+
+import (
+	"sync"
+	"time"
+)
+
+// Kind 是 Span 的类型
+type Kind string
+
+const (
+	KindEval       Kind = "eval"       // 执行脚本 (Eval、remote.eval、绑定返回结果等)，Name 为脚本来源
+	KindInit       Kind = "init"       // 注册 Init 脚本，Name 为脚本来源
+	KindRPC        Kind = "rpc"        // 页面调用 Bind 绑定的函数，Name 为函数名，未绑定的函数为 "unknown"
+	KindMessage    Kind = "message"    // 页面通过 postMessage 发送的消息，Name 为 "rpc" 或 "message"
+	KindNavigation Kind = "navigation" // 从导航开始到完成，Name 为空，URL 在 Attrs["url"] 中
+)
+
+// Span 是一次已完成的操作
+type Span struct {
+	ID       uint64
+	Kind     Kind
+	Name     string // 取值有限的名称，用作指标的标签
+	Start    time.Time
+	Duration time.Duration
+	Size     int   // 脚本、参数或消息的字节数
+	Err      error // 操作失败的原因
+
+	// Attrs 是其他属性，如 caller、url，取值不限，不用作指标的标签
+	Attrs map[string]string
+}
+
+// End 返回操作结束的时间
+func (s Span) End() time.Time {
+	return s.Start.Add(s.Duration)
+}
+
+// Observer 接收已完成的 Span。Observe 可能在主线程上调用，应尽快返回
+type Observer interface {
+	Observe(span Span)
+}
+
+// ObserverFunc 将函数适配为 Observer
+type ObserverFunc func(span Span)
+
+func (f ObserverFunc) Observe(span Span) {
+	f(span)
+}
+
+// Multi 返回依次交给每个 observer 的 Observer，忽略 nil
+func Multi(observers ...Observer) Observer {
+	var list []Observer
+	for _, o := range observers {
+		if o != nil {
+			list = append(list, o)
+		}
+	}
+	return multiObserver(list)
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) Observe(span Span) {
+	for _, o := range m {
+		o.Observe(span)
+	}
+}
+
+// DefaultRecorderSize 是 NewRecorder 的默认容量
+const DefaultRecorderSize = 1024
+
+// Recorder 在环形缓冲中保存最近的 Span，可在多个 goroutine 中使用。
+// 零值的 Recorder 保存最近 DefaultRecorderSize 个 Span
+type Recorder struct {
+	mu    sync.Mutex
+	spans []Span
+	next  int
+	full  bool
+}
+
+// NewRecorder 返回保存最近 size 个 Span 的记录器，size 不大于 0 时使用 DefaultRecorderSize
+func NewRecorder(size int) *Recorder {
+	if size <= 0 {
+		size = DefaultRecorderSize
+	}
+	return &Recorder{spans: make([]Span, size)}
+}
+
+func (r *Recorder) Observe(span Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.spans == nil {
+		r.spans = make([]Span, DefaultRecorderSize)
+	}
+	r.spans[r.next] = span
+	r.next++
+	if r.next == len(r.spans) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Spans 返回保存的 Span，从旧到新排列
+func (r *Recorder) Spans() []Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]Span(nil), r.spans[:r.next]...)
+	}
+	out := make([]Span, 0, len(r.spans))
+	out = append(out, r.spans[r.next:]...)
+	return append(out, r.spans[:r.next]...)
+}
+
+// Reset 清空保存的 Span
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.spans {
+		r.spans[i] = Span{}
+	}
+	r.next = 0
+	r.full = false
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func spanIDs(spans []Span) []uint64 {
+	ids := make([]uint64, len(spans))
+	for i, s := range spans {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(3)
+	if got := r.Spans(); len(got) != 0 {
+		t.Errorf("Spans() = %v, want empty", got)
+	}
+	tests := []struct {
+		id   uint64
+		want []uint64
+	}{
+		{1, []uint64{1}},
+		{2, []uint64{1, 2}},
+		{3, []uint64{1, 2, 3}},
+		// 超过容量后丢弃最旧的
+		{4, []uint64{2, 3, 4}},
+		{5, []uint64{3, 4, 5}},
+		{6, []uint64{4, 5, 6}},
+		{7, []uint64{5, 6, 7}},
+	}
+	for _, tt := range tests {
+		r.Observe(Span{ID: tt.id})
+		if got := spanIDs(r.Spans()); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("after %d: Spans() = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	// Spans 返回副本
+	spans := r.Spans()
+	spans[0].ID = 100
+	if got := spanIDs(r.Spans()); !reflect.DeepEqual(got, []uint64{5, 6, 7}) {
+		t.Errorf("Spans() = %v after modifying the copy", got)
+	}
+
+	r.Reset()
+	if got := r.Spans(); len(got) != 0 {
+		t.Errorf("Spans() after Reset = %v, want empty", got)
+	}
+	r.Observe(Span{ID: 8})
+	if got := spanIDs(r.Spans()); !reflect.DeepEqual(got, []uint64{8}) {
+		t.Errorf("Spans() = %v, want [8]", got)
+	}
+}
+
+func TestRecorderZeroValue(t *testing.T) {
+	var r Recorder
+	if got := r.Spans(); len(got) != 0 {
+		t.Errorf("Spans() = %v, want empty", got)
+	}
+	for i := 1; i <= DefaultRecorderSize+1; i++ {
+		r.Observe(Span{ID: uint64(i)})
+	}
+	spans := r.Spans()
+	if len(spans) != DefaultRecorderSize || spans[0].ID != 2 || spans[len(spans)-1].ID != DefaultRecorderSize+1 {
+		t.Errorf("Spans() = %d spans from %d to %d", len(spans), spans[0].ID, spans[len(spans)-1].ID)
+	}
+	if r := NewRecorder(0); cap(r.spans) != DefaultRecorderSize {
+		t.Errorf("NewRecorder(0) size = %d, want %d", cap(r.spans), DefaultRecorderSize)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var got []string
+	a := ObserverFunc(func(s Span) { got = append(got, "a:"+s.Name) })
+	b := ObserverFunc(func(s Span) { got = append(got, "b:"+s.Name) })
+	Multi(a, nil, b).Observe(Span{Name: "x"})
+	if want := []string{"a:x", "b:x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Multi observed %v, want %v", got, want)
+	}
+}
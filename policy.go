@@ -0,0 +1,72 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"log"
+
+	"github.com/yuaotian/go-win-webview2/pkg/scriptpolicy"
+)
+
+const (
+	// SignaturePriority 是 NewSignedScriptHook 的优先级，在其他 Before 钩子修改脚本之前验证原始脚本
+	SignaturePriority = -1000
+	// DenyPatternPriority 是 NewDenyPatternHook 的优先级，检查其他 Before 钩子修改后的最终脚本
+	DenyPatternPriority = 1000
+)
+
+// NewSignedScriptHook 返回只允许 verifier 信任的密钥签名的脚本执行的 Before 钩子，
+// 签名行 (见 scriptpolicy.Sign) 在执行前去掉。sources 为空时检查 Eval、Init 和远程控制的脚本 (包括 WebSocket 的 eval 消息)；
+// Bind 的粘合代码和库内部的脚本不检查。未签名或签名无效的脚本被拒绝，
+// 执行的脚本与签名的密钥记录在日志中，作为哪些脚本在程序中执行过的审计记录
+func NewSignedScriptHook(verifier *scriptpolicy.Verifier, sources ...ScriptSource) *BaseJSHook {
+	if len(sources) == 0 {
+		sources = []ScriptSource{ScriptEval, ScriptInit, ScriptRemote}
+	}
+	return &BaseJSHook{
+		HookType:     JSHookBefore,
+		HookPriority: SignaturePriority,
+		ScriptHandler: func(ctx *ScriptContext, script string) (string, error) {
+			if !hasSource(sources, ctx.Source) {
+				return script, nil
+			}
+			verified, key, err := verifier.Verify(script)
+			if err != nil {
+				return "", err
+			}
+			log.Printf("Signed %s script sha256-%s verified with key %s", ctx.Source, scriptpolicy.Digest(verified), scriptpolicy.KeyID(key))
+			return verified, nil
+		},
+	}
+}
+
+// NewDenyPatternHook 返回拒绝违反 scanner 规则的脚本的 Before 钩子，错误为 *scriptpolicy.ViolationError。
+// scanner 为 nil 时使用默认规则。Bind 的粘合代码和库内部的脚本不检查
+func NewDenyPatternHook(scanner *scriptpolicy.Scanner) *BaseJSHook {
+	if scanner == nil {
+		scanner = scriptpolicy.NewScanner()
+	}
+	return &BaseJSHook{
+		HookType:     JSHookBefore,
+		HookPriority: DenyPatternPriority,
+		ScriptHandler: func(ctx *ScriptContext, script string) (string, error) {
+			if ctx.Source == ScriptBind || ctx.Source == ScriptInternal {
+				return script, nil
+			}
+			if err := scanner.Check(script); err != nil {
+				return "", err
+			}
+			return script, nil
+		},
+	}
+}
+
+func hasSource(sources []ScriptSource, source ScriptSource) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+)
+
+// PrintOrientation 打印方向
+type PrintOrientation int
+
+const (
+	PrintPortrait  PrintOrientation = iota // 纵向
+	PrintLandscape                         // 横向
+)
+
+// PrintDuplex 双面打印模式
+type PrintDuplex int
+
+const (
+	PrintDuplexDefault           PrintDuplex = iota // 使用打印机默认设置
+	PrintDuplexOneSided                             // 单面
+	PrintDuplexTwoSidedLongEdge                     // 双面，长边翻页
+	PrintDuplexTwoSidedShortEdge                    // 双面，短边翻页
+)
+
+// PrintColorMode 打印颜色模式
+type PrintColorMode int
+
+const (
+	PrintColorDefault   PrintColorMode = iota // 使用打印机默认设置
+	PrintColorColor                           // 彩色
+	PrintColorGrayscale                       // 灰度
+)
+
+// PrintCollation 逐份打印模式
+type PrintCollation int
+
+const (
+	PrintCollationDefault    PrintCollation = iota // 使用打印机默认设置
+	PrintCollationCollated                         // 逐份打印
+	PrintCollationUncollated                       // 逐页打印
+)
+
+// PrintDialogKind 打印对话框类型
+type PrintDialogKind int
+
+const (
+	PrintDialogBrowser PrintDialogKind = iota // 浏览器打印预览对话框
+	PrintDialogSystem                         // 系统打印对话框
+)
+
+// PrintStatus 打印结果
+type PrintStatus int
+
+const (
+	PrintSucceeded          PrintStatus = iota // 打印成功
+	PrintPrinterUnavailable                    // 打印机不可用
+	PrintOtherError                            // 其他错误
+)
+
+func (s PrintStatus) String() string {
+	switch s {
+	case PrintSucceeded:
+		return "succeeded"
+	case PrintPrinterUnavailable:
+		return "printer unavailable"
+	default:
+		return "other error"
+	}
+}
+
+// PrintSettings 静默打印设置，零值表示使用打印机默认设置
+type PrintSettings struct {
+	PrinterName             string           // 打印机名称，为空时使用默认打印机
+	Copies                  int              // 份数
+	Duplex                  PrintDuplex      // 双面打印模式
+	ColorMode               PrintColorMode   // 颜色模式
+	Collation               PrintCollation   // 逐份打印模式
+	PageRanges              string           // 页码范围，如 "1-3,5"
+	PagesPerSide            int              // 每张纸打印的页数
+	Orientation             PrintOrientation // 打印方向
+	ScaleFactor             float64          // 缩放比例 (0.1-2.0)
+	ShouldPrintBackgrounds  bool             // 是否打印背景
+	ShouldPrintHeaderFooter bool             // 是否打印页眉页脚
+}
+
+// ErrPrintInProgress 表示已有打印任务正在进行
+var ErrPrintInProgress = edge.ErrPrintInProgress
+
+// Print 使用指定的打印设置静默打印当前页面，不显示任何界面。
+// 该方法会阻塞直到打印完成或 ctx 被取消。
+func (w *webview) Print(ctx context.Context, settings PrintSettings) (PrintStatus, error) {
+	if ctx == nil {
+		ctx = w.Context()
+	}
+
+	type printResult struct {
+		status PrintStatus
+		err    error
+	}
+	done := make(chan printResult, 1)
+
+	start := func() {
+		printSettings, err := w.browser.CreatePrintSettings()
+		if err != nil {
+			done <- printResult{PrintOtherError, err}
+			return
+		}
+		if err := applyPrintSettings(printSettings, settings); err != nil {
+			printSettings.Release()
+			done <- printResult{PrintOtherError, err}
+			return
+		}
+		err = w.browser.Print(printSettings, func(errorCode uintptr, status edge.COREWEBVIEW2_PRINT_STATUS) {
+			printSettings.Release()
+			if int32(errorCode) < 0 {
+				done <- printResult{PrintOtherError, fmt.Errorf("print failed with %08x", uint32(errorCode))}
+				return
+			}
+			done <- printResult{PrintStatus(status), nil}
+		})
+		if err != nil {
+			printSettings.Release()
+			done <- printResult{PrintOtherError, err}
+		}
+	}
+
+	var result printResult
+	if w.isMainThread() {
+		start()
+		if err := w.pumpUntil(ctx, func() bool {
+			select {
+			case result = <-done:
+				return true
+			default:
+				return false
+			}
+		}); err != nil {
+			return PrintOtherError, err
+		}
+	} else {
+		w.Dispatch(start)
+		select {
+		case result = <-done:
+		case <-ctx.Done():
+			return PrintOtherError, ctx.Err()
+		}
+	}
+	return result.status, result.err
+}
+
+// ShowPrintUI 显示浏览器或系统打印对话框
+func (w *webview) ShowPrintUI(kind PrintDialogKind) error {
+	if w.isMainThread() {
+		return w.browser.ShowPrintUI(edge.COREWEBVIEW2_PRINT_DIALOG_KIND(kind))
+	}
+	errCh := make(chan error, 1)
+	w.Dispatch(func() {
+		errCh <- w.browser.ShowPrintUI(edge.COREWEBVIEW2_PRINT_DIALOG_KIND(kind))
+	})
+	return <-errCh
+}
+
+func applyPrintSettings(ps *edge.ICoreWebView2PrintSettings, settings PrintSettings) error {
+	if settings.PrinterName != "" {
+		if err := ps.PutPrinterName(settings.PrinterName); err != nil {
+			return err
+		}
+	}
+	if settings.Copies > 0 {
+		if err := ps.PutCopies(int32(settings.Copies)); err != nil {
+			return err
+		}
+	}
+	if settings.PagesPerSide > 0 {
+		if err := ps.PutPagesPerSide(int32(settings.PagesPerSide)); err != nil {
+			return err
+		}
+	}
+	if settings.PageRanges != "" {
+		if err := ps.PutPageRanges(settings.PageRanges); err != nil {
+			return err
+		}
+	}
+	if settings.ScaleFactor > 0 {
+		if err := ps.PutScaleFactor(settings.ScaleFactor); err != nil {
+			return err
+		}
+	}
+	if err := ps.PutDuplex(edge.COREWEBVIEW2_PRINT_DUPLEX(settings.Duplex)); err != nil {
+		return err
+	}
+	if err := ps.PutColorMode(edge.COREWEBVIEW2_PRINT_COLOR_MODE(settings.ColorMode)); err != nil {
+		return err
+	}
+	if err := ps.PutCollation(edge.COREWEBVIEW2_PRINT_COLLATION(settings.Collation)); err != nil {
+		return err
+	}
+	if err := ps.PutOrientation(edge.COREWEBVIEW2_PRINT_ORIENTATION(settings.Orientation)); err != nil {
+		return err
+	}
+	if err := ps.PutShouldPrintBackgrounds(settings.ShouldPrintBackgrounds); err != nil {
+		return err
+	}
+	return ps.PutShouldPrintHeaderAndFooter(settings.ShouldPrintHeaderFooter)
+}
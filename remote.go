@@ -0,0 +1,317 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"github.com/yuaotian/go-win-webview2/pkg/remote"
+)
+
+// remoteSessionKey 是连接元数据中保存远程控制会话的键
+const remoteSessionKey = "webview2.remote"
+
+// RemoteOptions 是远程控制的选项
+type RemoteOptions struct {
+	// Token 是客户端在 remote.hello 中提供的令牌，为空时自动生成。
+	// 与 WebSocket 连接令牌不同，该令牌不会提供给页面
+	Token string
+}
+
+// remoteControl 是启用的远程控制，每次启用创建新的实例，旧的会话随之失效
+type remoteControl struct {
+	options RemoteOptions
+	target  *remoteTarget
+}
+
+// remoteConnSession 将会话与创建它的 remoteControl 关联
+type remoteConnSession struct {
+	control *remoteControl
+	session *remote.Session
+}
+
+// EnableRemoteControl 在 WebSocket 服务上启用远程控制协议 (见 pkg/remote)，
+// 外部程序可通过 remote.Dial 导航、执行脚本、调用绑定函数、截图、控制窗口和订阅事件。
+// 需要同时调用 EnableWebSocket 或 EnableWebSocketWithOptions，两者的调用顺序不限
+func (w *webview) EnableRemoteControl(opts RemoteOptions) error {
+	if opts.Token == "" {
+		token, err := newWebSocketToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate remote control token: %w", err)
+		}
+		opts.Token = token
+	}
+
+	control := &remoteControl{options: opts, target: &remoteTarget{w: w}}
+	w.wsMu.Lock()
+	w.remote = control
+	w.wsMu.Unlock()
+
+	for _, method := range remote.Methods {
+		method := method
+		w.HandleWebSocketRequest(method, func(conn *WSConn, params json.RawMessage) (interface{}, error) {
+			session := w.remoteSession(conn)
+			if session == nil {
+				return nil, remote.ErrUnauthorized
+			}
+			return session.Handle(context.Background(), method, params)
+		})
+	}
+	return nil
+}
+
+// DisableRemoteControl 禁用远程控制，已认证的连接不能再调用协议方法
+func (w *webview) DisableRemoteControl() {
+	w.wsMu.Lock()
+	w.remote = nil
+	w.wsMu.Unlock()
+	for _, method := range remote.Methods {
+		w.HandleWebSocketRequest(method, nil)
+	}
+}
+
+// RemoteControlToken 返回远程控制令牌，未启用时返回空字符串
+func (w *webview) RemoteControlToken() string {
+	w.wsMu.Lock()
+	defer w.wsMu.Unlock()
+	if w.remote == nil {
+		return ""
+	}
+	return w.remote.options.Token
+}
+
+// remoteSession 返回连接上的会话，远程控制重新启用后会创建新的会话
+func (w *webview) remoteSession(conn *WSConn) *remote.Session {
+	w.wsMu.Lock()
+	control := w.remote
+	w.wsMu.Unlock()
+	if control == nil {
+		return nil
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if s, ok := conn.meta[remoteSessionKey].(*remoteConnSession); ok && s.control == control {
+		return s.session
+	}
+	if conn.meta == nil {
+		conn.meta = make(map[string]interface{})
+	}
+	s := &remoteConnSession{control: control, session: remote.NewSession(control.target, control.options.Token)}
+	conn.meta[remoteSessionKey] = s
+	return s.session
+}
+
+// notifyRemote 向订阅了事件的远程控制连接推送事件，不阻塞调用者
+func (w *webview) notifyRemote(event string, data interface{}) {
+	w.wsMu.Lock()
+	control := w.remote
+	w.wsMu.Unlock()
+	if control == nil {
+		return
+	}
+
+	var conns []*WSConn
+	for _, conn := range w.WebSocketConns() {
+		s, ok := conn.Meta(remoteSessionKey).(*remoteConnSession)
+		if ok && s.control == control && s.session.Subscribed(event) {
+			conns = append(conns, conn)
+		}
+	}
+	if len(conns) == 0 {
+		return
+	}
+	message, err := remote.EventFrame(event, data)
+	if err != nil {
+		log.Printf("Warning: Failed to encode remote event %s: %v", event, err)
+		return
+	}
+	go func() {
+		for _, conn := range conns {
+			if err := conn.Send(string(message)); err != nil {
+				log.Printf("Warning: Failed to send remote event %s: %v", event, err)
+			}
+		}
+	}()
+}
+
+// remoteTarget 将 webview 适配为 remote.Target
+type remoteTarget struct {
+	w *webview
+}
+
+func (t *remoteTarget) Navigate(ctx context.Context, url string) error {
+	return t.w.runOnMainContext(ctx, func() {
+		t.w.Navigate(url)
+	})
+}
+
+func (t *remoteTarget) Eval(ctx context.Context, script string) (json.RawMessage, error) {
+	return t.w.evalResult(ctx, ScriptContext{Source: ScriptRemote}, script)
+}
+
+func (t *remoteTarget) Call(ctx context.Context, name string, args []json.RawMessage) (json.RawMessage, error) {
+	if !t.w.hasBinding(name) {
+		return nil, fmt.Errorf("%w: %s", remote.ErrBindingNotFound, name)
+	}
+
+	type reply struct {
+		value interface{}
+		err   error
+	}
+	ch := make(chan reply, 1)
+	t.w.Dispatch(func() {
+		d := rpcMessage{Method: name, Params: args}
+		start := time.Now()
+		value, err := t.w.callbinding(d)
+		t.w.traceRPC(d, start, err)
+		ch <- reply{value, err}
+	})
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return json.Marshal(r.value)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *remoteTarget) Screenshot(ctx context.Context, format string) ([]byte, error) {
+	chromium, ok := t.w.browser.(*edge.Chromium)
+	if !ok {
+		return nil, errors.New("screenshot is not supported by this browser")
+	}
+	imageFormat := edge.COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT(edge.COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT_PNG)
+	if format == remote.FormatJPEG {
+		imageFormat = edge.COREWEBVIEW2_CAPTURE_PREVIEW_IMAGE_FORMAT_JPEG
+	}
+
+	type reply struct {
+		data []byte
+		err  error
+	}
+	ch := make(chan reply, 1)
+	// 并发的截图由 CapturePreview 排队
+	t.w.Dispatch(func() {
+		if err := chromium.CapturePreview(imageFormat, func(data []byte, err error) {
+			ch <- reply{data, err}
+		}); err != nil {
+			ch <- reply{nil, err}
+		}
+	})
+
+	select {
+	case r := <-ch:
+		return r.data, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *remoteTarget) WindowState(ctx context.Context) (remote.WindowState, error) {
+	var state remote.WindowState
+	var ok bool
+	err := t.w.runOnMainContext(ctx, func() {
+		var s WindowState
+		if s, ok = t.w.captureWindowState(); !ok {
+			return
+		}
+		state = remote.WindowState{
+			Bounds:  remote.Bounds{X: s.X, Y: s.Y, Width: s.Width, Height: s.Height},
+			State:   remote.StateNormal,
+			Monitor: s.Monitor,
+			DPI:     int(s.DPI),
+			URL:     t.w.browser.Source(),
+		}
+		switch {
+		case t.w.fullscreen:
+			state.State = remote.StateFullscreen
+		case s.Minimized:
+			state.State = remote.StateMinimized
+		case s.Maximized:
+			state.State = remote.StateMaximized
+		}
+	})
+	if err != nil {
+		return remote.WindowState{}, err
+	}
+	if !ok {
+		return remote.WindowState{}, errors.New("failed to read window placement")
+	}
+	return state, nil
+}
+
+func (t *remoteTarget) SetWindowState(ctx context.Context, params remote.SetWindowStateParams) error {
+	w := t.w
+	return w.runOnMainContext(ctx, func() {
+		if params.Title != nil {
+			w.SetTitle(*params.Title)
+		}
+		if params.Bounds != nil {
+			w.setNormalBounds(*params.Bounds)
+		}
+		if params.State == "" {
+			return
+		}
+		if params.State != remote.StateFullscreen && w.fullscreen {
+			w.SetFullscreen(false)
+		}
+		switch params.State {
+		case remote.StateFullscreen:
+			w.SetFullscreen(true)
+		case remote.StateMaximized:
+			_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_MAXIMIZE)
+		case remote.StateMinimized:
+			_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_MINIMIZE)
+		case remote.StateNormal:
+			_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_RESTORE)
+		}
+	})
+}
+
+func (t *remoteTarget) Events() []string {
+	return []string{
+		remote.EventNavigationCompleted,
+		remote.EventFullscreenChanged,
+		remote.EventZoomChanged,
+		remote.EventDPIChanged,
+	}
+}
+
+// setNormalBounds 设置窗口还原状态下的位置和大小 (屏幕坐标)，不改变最大化、最小化或全屏状态
+func (w *webview) setNormalBounds(bounds remote.Bounds) {
+	offsetX, offsetY := rectWorkspaceOffset(w32.Rect{
+		Left: bounds.X, Top: bounds.Y, Right: bounds.X + bounds.Width, Bottom: bounds.Y + bounds.Height,
+	})
+	rect := w32.Rect{
+		Left:   bounds.X - offsetX,
+		Top:    bounds.Y - offsetY,
+		Right:  bounds.X - offsetX + bounds.Width,
+		Bottom: bounds.Y - offsetY + bounds.Height,
+	}
+	if w.fullscreen {
+		// 退出全屏时恢复到新的位置
+		w.fsPlacement.RcNormalPosition = rect
+		return
+	}
+
+	placement := w32.WindowPlacement{}
+	placement.Length = uint32(unsafe.Sizeof(placement))
+	if r, _, _ := w32.User32GetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement))); r == 0 {
+		return
+	}
+	placement.RcNormalPosition = rect
+	_, _, _ = w32.User32SetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement)))
+}
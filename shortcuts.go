@@ -0,0 +1,214 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/yuaotian/go-win-webview2/pkg/hotkey"
+)
+
+// ShortcutOptions 是 EnableShortcuts 的选项
+type ShortcutOptions struct {
+	// Keymap 是快捷键绑定，通常为默认绑定 Merge 用户的绑定，格式见 hotkey.Binding
+	Keymap hotkey.Keymap
+	// Timeout 是组合键序列中两次按键之间的最长间隔，为 0 时使用 hotkey.DefaultChordTimeout
+	Timeout time.Duration
+	// OnPending 在组合键序列等待下一个键时以已按下的前缀调用，序列完成、取消或超时后以 nil 调用，
+	// 可用于在状态栏显示 "已按下 Ctrl+K，等待第二个键"
+	OnPending func(pending hotkey.Sequence)
+}
+
+// shortcutState 是启用的快捷键，只在主线程访问
+type shortcutState struct {
+	matcher   *hotkey.Matcher
+	timeout   time.Duration
+	onPending func(pending hotkey.Sequence)
+	shown     bool        // 是否已以非 nil 调用 onPending
+	timer     *time.Timer // 组合键序列的超时
+}
+
+// builtinShortcuts 是 Keymap 中可以直接使用的命令，HandleShortcut 注册的同名命令优先
+var builtinShortcuts = map[string]func(w *webview){
+	"webview.reload":    (*webview).Reload,
+	"webview.stop":      (*webview).Stop,
+	"webview.back":      (*webview).Back,
+	"webview.forward":   (*webview).Forward,
+	"webview.zoomIn":    (*webview).ZoomIn,
+	"webview.zoomOut":   (*webview).ZoomOut,
+	"webview.resetZoom": (*webview).ResetZoom,
+	"webview.devTools":  (*webview).OpenDevTools,
+}
+
+// textInputScript 在焦点进入或离开文本输入框和可编辑元素时通知程序，用于 when 条件 textInputFocus。
+// 只跟踪顶层页面，焦点在 iframe 中时视为不在输入框中
+const textInputScript = `(function() {
+	if (window !== window.top || window.__webview2TextInputTracked) return;
+	window.__webview2TextInputTracked = true;
+	var nonText = ['button', 'checkbox', 'color', 'file', 'hidden', 'image', 'radio', 'range', 'reset', 'submit'];
+	function isTextInput(el) {
+		while (el && el.shadowRoot && el.shadowRoot.activeElement) el = el.shadowRoot.activeElement;
+		if (!el) return false;
+		if (el.isContentEditable || el.tagName === 'TEXTAREA') return true;
+		return el.tagName === 'INPUT' && nonText.indexOf((el.type || 'text').toLowerCase()) < 0;
+	}
+	var last = null;
+	function update() {
+		var focused = isTextInput(document.activeElement);
+		if (focused !== last && window.__webview2TextInput) {
+			last = focused;
+			window.__webview2TextInput(focused);
+		}
+	}
+	document.addEventListener('focusin', update, true);
+	document.addEventListener('focusout', function() { setTimeout(update, 0); }, true);
+	update();
+})();`
+
+// EnableShortcuts 在 WebView 获得焦点时按 opts.Keymap 匹配快捷键和 "Ctrl+K Ctrl+S" 这样的组合键序列，
+// 匹配后调用 HandleShortcut 注册的命令或 webview.reload 等内置命令。
+// 匹配的按键和组合键序列中的按键不再传给页面，Keymap 优先于菜单快捷键和 HotKeyWindow 热键。
+// 再次调用时替换之前的 Keymap
+func (w *webview) EnableShortcuts(opts ShortcutOptions) error {
+	matcher, err := hotkey.NewMatcher(opts.Keymap)
+	if err != nil {
+		return err
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = hotkey.DefaultChordTimeout
+	}
+	matcher.Timeout = opts.Timeout
+
+	w.m.Lock()
+	bound := w.textInputBound
+	w.textInputBound = true
+	w.m.Unlock()
+	if !bound {
+		if err := w.Bind("__webview2TextInput", func(focused bool) {
+			w.m.Lock()
+			w.textInputFocus = focused
+			w.m.Unlock()
+		}); err != nil {
+			return err
+		}
+		w.initInternal(textInputScript)
+		// 当前页面在 Bind 之前已加载，补上绑定和跟踪脚本
+		w.evalInternal(bindingScript("__webview2TextInput") + textInputScript)
+	}
+
+	w.runOnMain(func() {
+		w.endShortcutSequence()
+		w.shortcuts = &shortcutState{matcher: matcher, timeout: opts.Timeout, onPending: opts.OnPending}
+	})
+	return nil
+}
+
+// DisableShortcuts 停止匹配快捷键，HandleShortcut 注册的命令保留
+func (w *webview) DisableShortcuts() {
+	w.runOnMain(func() {
+		w.endShortcutSequence()
+		w.shortcuts = nil
+	})
+}
+
+// HandleShortcut 注册 Keymap 中命令的处理函数，handler 为 nil 时移除。handler 在主线程调用
+func (w *webview) HandleShortcut(command string, handler HotKeyHandler) error {
+	if command == "" {
+		return errors.New("shortcut command is required")
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if handler == nil {
+		delete(w.shortcutHandlers, command)
+		return nil
+	}
+	if w.shortcutHandlers == nil {
+		w.shortcutHandlers = make(map[string]HotKeyHandler)
+	}
+	w.shortcutHandlers[command] = handler
+	return nil
+}
+
+// shortcutKey 在主线程处理 WebView 获得焦点时的按键，返回 true 表示按键属于快捷键
+func (w *webview) shortcutKey(virtualKey uint) bool {
+	state := w.shortcuts
+	if state == nil {
+		return false
+	}
+	w.m.Lock()
+	ctx := hotkey.Context{TextInputFocus: w.textInputFocus}
+	w.m.Unlock()
+	ctx.Origin = pageOrigin(w.browser.Source())
+
+	result, binding := state.matcher.Press(HotKey{Modifiers: currentModifiers(), KeyCode: int(virtualKey)}, ctx)
+	switch result {
+	case hotkey.Pending:
+		w.waitShortcutSequence(state)
+		return true
+	case hotkey.Cancelled:
+		w.endShortcutSequence()
+		return true
+	case hotkey.Matched:
+		w.endShortcutSequence()
+		w.runShortcut(binding.Command)
+		return true
+	default:
+		return false
+	}
+}
+
+// waitShortcutSequence 通知等待下一个键，超时后结束序列，只在主线程调用
+func (w *webview) waitShortcutSequence(state *shortcutState) {
+	if state.timer != nil {
+		state.timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(state.timeout, func() {
+		w.Dispatch(func() {
+			if w.shortcuts == state && state.timer == timer {
+				w.endShortcutSequence()
+			}
+		})
+	})
+	state.timer = timer
+
+	if state.onPending != nil {
+		state.shown = true
+		state.onPending(state.matcher.Pending())
+	}
+}
+
+// endShortcutSequence 丢弃等待中的序列，之前通知过等待时以 nil 调用 OnPending，只在主线程调用
+func (w *webview) endShortcutSequence() {
+	state := w.shortcuts
+	if state == nil {
+		return
+	}
+	state.matcher.Reset()
+	if state.timer != nil {
+		state.timer.Stop()
+		state.timer = nil
+	}
+	if state.shown {
+		state.shown = false
+		state.onPending(nil)
+	}
+}
+
+func (w *webview) runShortcut(command string) {
+	w.m.Lock()
+	handler := w.shortcutHandlers[command]
+	w.m.Unlock()
+	if handler != nil {
+		handler()
+		return
+	}
+	if builtin, ok := builtinShortcuts[command]; ok {
+		builtin(w)
+		return
+	}
+	log.Printf("Warning: No handler for shortcut command %q", command)
+}
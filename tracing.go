@@ -0,0 +1,143 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"github.com/yuaotian/go-win-webview2/pkg/tracing"
+)
+
+// traceState 是跟踪的状态
+type traceState struct {
+	seq uint64 // 原子操作
+
+	// observer 由 webview.m 保护
+	observer tracing.Observer
+
+	// 进行中的导航，只在主线程访问
+	navigations map[uint64]navigationStart
+}
+
+type navigationStart struct {
+	start time.Time
+	url   string
+}
+
+// SetObserver 设置接收跟踪记录的 Observer，为 nil 时停止跟踪。
+// 多个 Observer 用 tracing.Multi 组合，tracing.Recorder 和 tracing.Metrics 提供了记录和汇总。
+// 设置后 Eval 会等待页面执行完成，记录的持续时间包括脚本的执行时间
+func (w *webview) SetObserver(observer tracing.Observer) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.trace.observer = observer
+}
+
+func (w *webview) observer() tracing.Observer {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.trace.observer
+}
+
+// observe 为 span 分配 ID 后交给 Observer，未设置 Observer 时忽略
+func (w *webview) observe(span tracing.Span) {
+	observer := w.observer()
+	if observer == nil {
+		return
+	}
+	span.ID = atomic.AddUint64(&w.trace.seq, 1)
+	observer.Observe(span)
+}
+
+// traceScript 记录脚本的执行或注册
+func (w *webview) traceScript(kind tracing.Kind, ctx *ScriptContext, script string, start time.Time) {
+	attrs := map[string]string{}
+	if ctx.URL != "" {
+		attrs["url"] = ctx.URL
+	}
+	if ctx.Caller != "" {
+		attrs["caller"] = ctx.Caller
+	}
+	if ctx.Binding != "" {
+		attrs["binding"] = ctx.Binding
+	}
+	w.observe(tracing.Span{
+		Kind:     kind,
+		Name:     ctx.Source.String(),
+		Start:    start,
+		Duration: time.Since(start),
+		Size:     len(script),
+		Err:      ctx.Err,
+		Attrs:    attrs,
+	})
+}
+
+// traceRPC 记录页面对绑定函数的调用，未绑定的函数记录为 unknownBinding
+func (w *webview) traceRPC(d rpcMessage, start time.Time, err error) {
+	if w.observer() == nil {
+		return
+	}
+	size := 0
+	for _, p := range d.Params {
+		size += len(p)
+	}
+	w.observe(tracing.Span{
+		Kind:     tracing.KindRPC,
+		Name:     w.bindingName(d.Method),
+		Start:    start,
+		Duration: time.Since(start),
+		Size:     size,
+		Err:      err,
+	})
+}
+
+// traceMessage 记录页面通过 postMessage 发送的消息
+func (w *webview) traceMessage(name, msg string, start time.Time) {
+	w.observe(tracing.Span{
+		Kind:     tracing.KindMessage,
+		Name:     name,
+		Start:    start,
+		Duration: time.Since(start),
+		Size:     len(msg),
+	})
+}
+
+// navigationStarted 在主线程记录导航的开始
+func (w *webview) navigationStarted(navigationID uint64, url string) {
+	if w.observer() == nil {
+		return
+	}
+	if w.trace.navigations == nil {
+		w.trace.navigations = make(map[uint64]navigationStart)
+	}
+	w.trace.navigations[navigationID] = navigationStart{start: time.Now(), url: url}
+}
+
+// navigationCompleted 在主线程记录导航的完成
+func (w *webview) navigationCompleted(args *edge.ICoreWebView2NavigationCompletedEventArgs) {
+	navigationID, err := args.GetNavigationId()
+	if err != nil {
+		return
+	}
+	started, ok := w.trace.navigations[navigationID]
+	if !ok {
+		return
+	}
+	delete(w.trace.navigations, navigationID)
+
+	span := tracing.Span{
+		Kind:     tracing.KindNavigation,
+		Start:    started.start,
+		Duration: time.Since(started.start),
+		Attrs:    map[string]string{"url": started.url},
+	}
+	if success, err := args.GetIsSuccess(); err == nil && !success {
+		status, _ := args.GetWebErrorStatus()
+		span.Err = fmt.Errorf("navigation failed with web error status %d", status)
+	}
+	w.observe(span)
+}
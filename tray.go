@@ -0,0 +1,275 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+// wmTrayIcon 是托盘图标发送给窗口的回调消息
+const wmTrayIcon = w32.WMApp + 1
+
+// trayMenuFirstID 是托盘弹出菜单命令 ID 的起始值，与菜单栏的命令 ID 区分
+const trayMenuFirstID = 30000
+
+// taskbarCreated 在资源管理器重启后广播，收到后需要重新添加托盘图标
+var taskbarCreated = w32.RegisterWindowMessage("TaskbarCreated")
+
+// NotificationIcon 托盘通知的图标
+type NotificationIcon int
+
+const (
+	NotificationNone    NotificationIcon = w32.NIIF_NONE    // 无图标
+	NotificationInfo    NotificationIcon = w32.NIIF_INFO    // 信息
+	NotificationWarning NotificationIcon = w32.NIIF_WARNING // 警告
+	NotificationError   NotificationIcon = w32.NIIF_ERROR   // 错误
+	NotificationApp     NotificationIcon = w32.NIIF_USER    // 使用托盘图标
+)
+
+// TrayOptions 托盘图标选项，图标来源的优先级与窗口图标相同: IconData > IconPath > IconId
+type TrayOptions struct {
+	IconId         uint   // 图标资源 ID
+	IconPath       string // 图标文件路径
+	IconData       []byte // 图标二进制数据 (ICO 或 PNG)
+	Tooltip        string // 鼠标悬停提示
+	Menu           *Menu  // 右键弹出菜单
+	OnClick        func() // 左键单击
+	OnDoubleClick  func() // 左键双击 (双击前会先触发一次单击)
+	OnNotification func() // 点击托盘通知
+}
+
+// Tray 是窗口的系统托盘图标
+type Tray struct {
+	w          *webview
+	id         uint32
+	icon       uintptr
+	sharedIcon bool // 系统默认图标，不能销毁
+
+	m       sync.Mutex
+	opts    TrayOptions
+	removed bool
+}
+
+// AddTray 在系统托盘中添加图标，窗口销毁时自动移除
+func (w *webview) AddTray(opts TrayOptions) (*Tray, error) {
+	t := &Tray{w: w, opts: opts}
+	var err error
+	w.runOnMain(func() {
+		w.nextTrayID++
+		t.id = w.nextTrayID
+		t.icon, t.sharedIcon = w.loadTrayIcon(iconSource{id: opts.IconId, path: opts.IconPath, data: opts.IconData})
+
+		data := t.data()
+		data.UFlags = w32.NIF_MESSAGE | w32.NIF_ICON | w32.NIF_TIP | w32.NIF_SHOWTIP
+		data.UCallbackMessage = wmTrayIcon
+		data.HIcon = t.icon
+		w32.CopyUTF16(data.SzTip[:], opts.Tooltip)
+		if !w32.ShellNotifyIcon(w32.NIM_ADD, &data) {
+			t.destroyIcon()
+			err = errors.New("failed to add tray icon")
+			return
+		}
+		if w.trays == nil {
+			w.trays = map[uint32]*Tray{}
+		}
+		w.trays[t.id] = t
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Tray) data() w32.NotifyIconData {
+	return w32.NotifyIconData{HWnd: t.w.hwnd, UID: t.id}
+}
+
+func (t *Tray) destroyIcon() {
+	if t.icon != 0 && !t.sharedIcon {
+		_, _, _ = w32.User32DestroyIcon.Call(t.icon)
+	}
+	t.icon = 0
+}
+
+// loadTrayIcon 加载托盘大小的图标，未指定图标时使用窗口图标的来源
+func (w *webview) loadTrayIcon(src iconSource) (uintptr, bool) {
+	if src.empty() {
+		src = w.icon
+	}
+	size, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CXSMICON)
+	var hinstance windows.Handle
+	_ = windows.GetModuleHandleEx(0, nil, &hinstance)
+	return src.load(hinstance, int(size))
+}
+
+// SetIcon 更换托盘图标，参数含义与 TrayOptions 相同
+func (t *Tray) SetIcon(iconId uint, iconPath string, iconData []byte) error {
+	return t.modify(func(data *w32.NotifyIconData) {
+		t.destroyIcon()
+		t.icon, t.sharedIcon = t.w.loadTrayIcon(iconSource{id: iconId, path: iconPath, data: iconData})
+		data.UFlags = w32.NIF_ICON
+		data.HIcon = t.icon
+	})
+}
+
+// SetTooltip 设置鼠标悬停提示
+func (t *Tray) SetTooltip(tooltip string) error {
+	t.m.Lock()
+	t.opts.Tooltip = tooltip
+	t.m.Unlock()
+	return t.modify(func(data *w32.NotifyIconData) {
+		data.UFlags = w32.NIF_TIP | w32.NIF_SHOWTIP
+		w32.CopyUTF16(data.SzTip[:], tooltip)
+	})
+}
+
+// SetMenu 设置右键弹出菜单，nil 表示不显示菜单
+func (t *Tray) SetMenu(menu *Menu) {
+	t.m.Lock()
+	t.opts.Menu = menu
+	t.m.Unlock()
+}
+
+// OnClick 设置左键单击回调
+func (t *Tray) OnClick(f func()) {
+	t.m.Lock()
+	t.opts.OnClick = f
+	t.m.Unlock()
+}
+
+// OnDoubleClick 设置左键双击回调
+func (t *Tray) OnDoubleClick(f func()) {
+	t.m.Lock()
+	t.opts.OnDoubleClick = f
+	t.m.Unlock()
+}
+
+// ShowNotification 显示托盘气泡通知 (Windows 10 及以上显示为系统通知)
+func (t *Tray) ShowNotification(title, text string, icon NotificationIcon) error {
+	return t.modify(func(data *w32.NotifyIconData) {
+		data.UFlags = w32.NIF_INFO
+		data.DwInfoFlags = uint32(icon)
+		if icon == NotificationApp {
+			data.DwInfoFlags |= w32.NIIF_LARGE_ICON
+			data.HBalloonIcon = t.icon
+		}
+		w32.CopyUTF16(data.SzInfoTitle[:], title)
+		w32.CopyUTF16(data.SzInfo[:], text)
+	})
+}
+
+// Remove 从系统托盘中移除图标
+func (t *Tray) Remove() error {
+	var err error
+	t.w.runOnMain(func() {
+		if t.removed {
+			return
+		}
+		t.removed = true
+		delete(t.w.trays, t.id)
+		data := t.data()
+		if !w32.ShellNotifyIcon(w32.NIM_DELETE, &data) {
+			err = errors.New("failed to remove tray icon")
+		}
+		t.destroyIcon()
+	})
+	return err
+}
+
+// modify 在主线程上修改托盘图标
+func (t *Tray) modify(f func(data *w32.NotifyIconData)) error {
+	var err error
+	t.w.runOnMain(func() {
+		if t.removed {
+			err = errors.New("tray icon has been removed")
+			return
+		}
+		data := t.data()
+		f(&data)
+		if !w32.ShellNotifyIcon(w32.NIM_MODIFY, &data) {
+			err = errors.New("failed to modify tray icon")
+		}
+	})
+	return err
+}
+
+// readd 在资源管理器重启后重新添加托盘图标
+func (t *Tray) readd() {
+	t.m.Lock()
+	tooltip := t.opts.Tooltip
+	t.m.Unlock()
+	data := t.data()
+	data.UFlags = w32.NIF_MESSAGE | w32.NIF_ICON | w32.NIF_TIP | w32.NIF_SHOWTIP
+	data.UCallbackMessage = wmTrayIcon
+	data.HIcon = t.icon
+	w32.CopyUTF16(data.SzTip[:], tooltip)
+	w32.ShellNotifyIcon(w32.NIM_ADD, &data)
+}
+
+// handleTrayMessage 处理托盘图标的回调消息，wParam 为图标 ID，lParam 为鼠标消息
+func (w *webview) handleTrayMessage(wp, lp uintptr) {
+	t, ok := w.trays[uint32(wp)]
+	if !ok {
+		return
+	}
+	t.m.Lock()
+	opts := t.opts
+	t.m.Unlock()
+
+	switch lp {
+	case w32.WMLButtonUp:
+		if opts.OnClick != nil {
+			opts.OnClick()
+		}
+	case w32.WMLButtonDblClk:
+		if opts.OnDoubleClick != nil {
+			opts.OnDoubleClick()
+		}
+	case w32.WMRButtonUp, w32.WMContextMenu:
+		if opts.Menu != nil {
+			w.showPopupMenu(opts.Menu)
+		}
+	case w32.NIN_BALLOONUSERCLICK:
+		if opts.OnNotification != nil {
+			opts.OnNotification()
+		}
+	}
+}
+
+// showPopupMenu 在鼠标位置显示弹出菜单并处理选中的菜单项
+func (w *webview) showPopupMenu(menu *Menu) {
+	popup, err := buildNativeMenu(menu, true, trayMenuFirstID)
+	if err != nil {
+		return
+	}
+	defer popup.destroy()
+
+	pt, _ := w32.GetCursorPos()
+	// 弹出菜单前窗口必须位于前台，否则点击菜单外部时菜单不会关闭
+	_, _, _ = w32.User32SetForegroundWindow.Call(w.hwnd)
+	cmd, _, _ := w32.User32TrackPopupMenu.Call(
+		popup.handle,
+		w32.TPM_RIGHTBUTTON|w32.TPM_RETURNCMD|w32.TPM_NONOTIFY,
+		uintptr(pt.X),
+		uintptr(pt.Y),
+		0,
+		w.hwnd,
+		0,
+	)
+	_, _, _ = w32.User32PostMessageW.Call(w.hwnd, w32.WMNull, 0, 0)
+	if cmd != 0 {
+		popup.activate(uint16(cmd))
+	}
+}
+
+// removeTrays 移除窗口的所有托盘图标
+func (w *webview) removeTrays() {
+	for _, t := range w.trays {
+		_ = t.Remove()
+	}
+}
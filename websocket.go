@@ -0,0 +1,503 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketOptions WebSocket 服务选项
+type WebSocketOptions struct {
+	// Host 监听地址，默认 127.0.0.1。
+	// 设置为 0.0.0.0 等地址会把服务暴露到局域网，只应在确有需要时使用
+	Host string
+	// Port 监听端口
+	Port int
+
+	// Token 客户端连接时必须提供的令牌，为空时为本次会话自动生成随机令牌。
+	// 页面中的客户端会自动携带，其他客户端可通过 URL 参数 token 或
+	// "Authorization: Bearer <token>" 请求头提供，见 WebSocketToken
+	Token string
+
+	// AllowedOrigins 允许连接的页面来源，如 "https://app.example.com"、"http://localhost:5173"，
+	// "*" 表示任意来源。只有列出的来源和 AllowAppContent 允许的页面能获得客户端脚本和令牌，
+	// 本机地址 (localhost、127.0.0.1) 的任意端口也需要逐个列出
+	AllowedOrigins []string
+
+	// AllowAppContent 允许程序自身通过 SetHtml/NavigateToString 加载的页面 (以及 about:blank) 连接。
+	// 这些页面的来源为 "null"，开启后服务端也接受 Origin 为 "null" 的握手请求，
+	// 因此 file:// 页面 (浏览器同样发送 "null") 需要同时开启此项并在 AllowedOrigins 中列出 "file://"
+	AllowAppContent bool
+
+	// AllowRemoteEval 允许页面执行服务端发送的 {"type":"eval","script":"..."} 消息。
+	// 默认关闭，开启后任何持有令牌的客户端都能在页面中执行脚本。
+	// 脚本交回 Go 侧以 ScriptRemote 为来源经过 JS 钩子执行，NewSignedScriptHook 等策略同样适用
+	AllowRemoteEval bool
+}
+
+// allowsOrigin 报告 origin 是否允许连接或接收客户端脚本
+func (o *WebSocketOptions) allowsOrigin(origin string) bool {
+	if origin == "null" {
+		return o.AllowAppContent
+	}
+	for _, allowed := range o.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageOrigin 返回文档 URL 对应的来源，about:blank 和 data: 等不透明来源返回 "null"
+func pageOrigin(source string) string {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "null"
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return u.Scheme + "://" + u.Host
+	case "file":
+		return "file://"
+	}
+	return "null"
+}
+
+// newWebSocketToken 生成 32 字节的随机令牌
+func newWebSocketToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkWebSocketToken 检查请求是否携带正确的令牌
+func checkWebSocketToken(r *http.Request, token string) bool {
+	got := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); got == "" && strings.HasPrefix(auth, "Bearer ") {
+		got = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// webSocketClientScript 在页面中建立到 WebSocket 服务的连接，并提供 window.webview2.ws。
+// 连接地址和令牌通过绑定函数获取，不会出现在脚本中，
+// Go 侧只对允许的来源返回配置，断开后自动重连并重新加入房间
+const webSocketClientScript = `(function() {
+	if (window !== window.top || window.__webview2WebSocketConnect) return;
+	var rooms = {}, handlers = {}, pending = {}, nextId = 1;
+	var send = function(frame) {
+		var ws = window._webSocket;
+		if (!ws || ws.readyState !== 1) throw new Error('WebSocket is not connected');
+		ws.send(JSON.stringify(frame));
+	};
+	var api = {
+		id: null,
+		request: function(method, data) {
+			return new Promise(function(resolve, reject) {
+				var id = 'p' + nextId++;
+				pending[id] = {resolve: resolve, reject: reject};
+				try {
+					send({type: 'request', id: id, method: method, data: data});
+				} catch (e) {
+					delete pending[id];
+					reject(e);
+				}
+			});
+		},
+		join: function(room, listener) {
+			rooms[room] = rooms[room] || [];
+			if (listener) rooms[room].push(listener);
+			try { send({type: 'join', room: room}); } catch (e) {}
+		},
+		leave: function(room) {
+			delete rooms[room];
+			try { send({type: 'leave', room: room}); } catch (e) {}
+		},
+		publish: function(room, data) {
+			send({type: 'publish', room: room, data: data});
+		},
+		handle: function(method, handler) {
+			if (handler) handlers[method] = handler; else delete handlers[method];
+		}
+	};
+	window.webview2 = Object.assign(window.webview2 || {}, {ws: api});
+
+	var onFrame = function(config, data) {
+		switch (data.type) {
+		case 'welcome':
+			api.id = data.id;
+			Object.keys(rooms).forEach(function(room) { send({type: 'join', room: room}); });
+			break;
+		case 'response':
+			var p = pending[data.id];
+			if (!p) return;
+			delete pending[data.id];
+			if (data.error) p.reject(new Error(data.error)); else p.resolve(data.data);
+			break;
+		case 'request':
+			var handler = handlers[data.method];
+			Promise.resolve().then(function() {
+				if (!handler) throw new Error('no handler for ' + data.method);
+				return handler(data.data);
+			}).then(function(result) {
+				send({type: 'response', id: data.id, data: result});
+			}, function(e) {
+				send({type: 'response', id: data.id, error: String(e && e.message || e)});
+			});
+			break;
+		case 'publish':
+			(rooms[data.room] || []).forEach(function(listener) { listener(data.data, data.from); });
+			break;
+		case 'eval':
+			if (config.eval) window.__webview2WebSocketEval(data.script);
+			break;
+		}
+	};
+
+	var connect = function() {
+		if (window._webSocket && window._webSocket.readyState <= 1) return;
+		window.__webview2WebSocketConfig().then(function(config) {
+			if (!config || (window._webSocket && window._webSocket.readyState <= 1)) return;
+			var ws = new WebSocket(config.url);
+			window._webSocket = ws;
+			ws.addEventListener('message', function(event) {
+				var data;
+				try {
+					data = JSON.parse(event.data);
+				} catch (e) {
+					return; // 不是 JSON 的消息由页面自行处理
+				}
+				try {
+					onFrame(config, data);
+				} catch (e) {
+					console.error('WebSocket message error:', e);
+				}
+			});
+			ws.onclose = function() {
+				Object.keys(pending).forEach(function(id) {
+					pending[id].reject(new Error('WebSocket closed'));
+					delete pending[id];
+				});
+				api.id = null;
+				if (window._webSocket !== ws) return;
+				window._webSocket = null;
+				setTimeout(connect, 1000);
+			};
+		});
+	};
+	window.__webview2WebSocketConnect = connect;
+	if (document.readyState === 'loading') {
+		document.addEventListener('DOMContentLoaded', connect);
+	} else {
+		connect();
+	}
+})();`
+
+// webSocketConfig 是 __webview2WebSocketConfig 返回给页面的连接配置
+type webSocketConfig struct {
+	URL  string `json:"url"`
+	Eval bool   `json:"eval"`
+}
+
+// wsBridge 是一次启用的 WebSocket 服务，使用独立的 ServeMux，
+// 不影响宿主程序的 http.DefaultServeMux，同一进程中可以有多个窗口各自启用
+type wsBridge struct {
+	options  WebSocketOptions
+	server   *http.Server
+	listener net.Listener
+	upgrader websocket.Upgrader
+
+	m          sync.Mutex
+	conns      map[string]*WSConn
+	nextConnID uint64
+}
+
+// EnableWebSocket 在 127.0.0.1 的指定端口启用 WebSocket 服务，port 为 0 时自动选择端口。
+// 不允许任何页面来源，只接受持有令牌的外部客户端，页面中的客户端需要 EnableWebSocketWithOptions 设置来源
+func (w *webview) EnableWebSocket(port int) error {
+	return w.EnableWebSocketWithOptions(WebSocketOptions{Port: port})
+}
+
+// EnableWebSocketWithOptions 按选项启用 WebSocket 服务，并在允许的页面中注入客户端 (window._webSocket)。
+// 端口被占用等启动错误直接返回；服务已在运行时会先关闭再按新选项启动，
+// 实际监听地址见 WebSocketAddr。
+func (w *webview) EnableWebSocketWithOptions(opts WebSocketOptions) error {
+	if opts.Host == "" {
+		opts.Host = "127.0.0.1"
+	}
+	if opts.Token == "" {
+		token, err := newWebSocketToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate websocket token: %w", err)
+		}
+		opts.Token = token
+	}
+
+	w.stopWebSocket()
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to start websocket server: %w", err)
+	}
+	bridge := &wsBridge{
+		options:  opts,
+		listener: listener,
+		conns:    make(map[string]*WSConn),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				// 没有 Origin 的请求来自非浏览器客户端，仍需令牌
+				origin := r.Header.Get("Origin")
+				return origin == "" || opts.allowsOrigin(origin)
+			},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(writer http.ResponseWriter, r *http.Request) {
+		w.serveWebSocket(bridge, writer, r)
+	})
+	bridge.server = &http.Server{Handler: mux}
+
+	w.wsMu.Lock()
+	w.ws = bridge
+	w.wsMu.Unlock()
+
+	go func() {
+		if err := bridge.server.Serve(listener); err != http.ErrServerClosed {
+			log.Printf("WebSocket server error: %v", err)
+		}
+	}()
+
+	// 注入 WebSocket 客户端代码，导航后的页面也会重新连接
+	if !w.hasBinding("__webview2WebSocketConfig") {
+		if err := w.Bind("__webview2WebSocketConfig", w.webSocketConfig); err != nil {
+			return err
+		}
+		if err := w.Bind("__webview2WebSocketEval", w.webSocketEval); err != nil {
+			return err
+		}
+		w.initInternal(webSocketClientScript)
+	}
+	// 当前页面加载时还没有绑定函数，需要补充定义
+	w.evalInternal(bindingScript("__webview2WebSocketConfig") + ";" + bindingScript("__webview2WebSocketEval") + ";" +
+		webSocketClientScript + ";window.__webview2WebSocketConnect();")
+
+	return nil
+}
+
+// serveWebSocket 校验令牌并处理一个连接
+func (w *webview) serveWebSocket(bridge *wsBridge, writer http.ResponseWriter, r *http.Request) {
+	if !checkWebSocketToken(r, bridge.options.Token) {
+		http.Error(writer, "invalid websocket token", http.StatusUnauthorized)
+		return
+	}
+	conn, err := bridge.upgrader.Upgrade(writer, r, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	// 保存连接
+	c := newWSConn(bridge, conn, r.Header.Get("Origin"))
+	bridge.m.Lock()
+	bridge.conns[c.id] = c
+	bridge.m.Unlock()
+
+	// 创建并添加 WebSocket Hook
+	wsHook := NewWebSocketHook(conn)
+	w.AddJSHook(wsHook)
+
+	// 处理消息
+	go func() {
+		defer func() {
+			conn.Close()
+			c.shutdown()
+			bridge.m.Lock()
+			delete(bridge.conns, c.id)
+			bridge.m.Unlock()
+			w.RemoveJSHook(wsHook)
+
+			w.wsMu.Lock()
+			onDisconnect := w.wsDisconnectHandler
+			w.wsMu.Unlock()
+			if onDisconnect != nil {
+				onDisconnect(c)
+			}
+		}()
+
+		if err := c.SendJSON(wsFrame{Type: wsTypeWelcome, ID: c.id}); err != nil {
+			log.Printf("WebSocket write error: %v", err)
+			return
+		}
+		w.wsMu.Lock()
+		onConnect := w.wsConnectHandler
+		w.wsMu.Unlock()
+		if onConnect != nil {
+			onConnect(c)
+		}
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) && !errors.Is(err, net.ErrClosed) {
+					log.Printf("WebSocket read error: %v", err)
+				}
+				return
+			}
+
+			if w.handleFrame(c, message) {
+				continue
+			}
+			w.wsMu.Lock()
+			onMessage := w.wsConnMessageHandler
+			w.wsMu.Unlock()
+			if onMessage != nil {
+				onMessage(c, string(message))
+			}
+			w.wsMu.Lock()
+			handler := w.wsHandler
+			w.wsMu.Unlock()
+			if handler != nil {
+				handler(string(message))
+			}
+		}
+	}()
+}
+
+func (w *webview) webSocketBridge() *wsBridge {
+	w.wsMu.Lock()
+	defer w.wsMu.Unlock()
+	return w.ws
+}
+
+// webSocketConfig 只对允许的来源返回连接地址和令牌
+func (w *webview) webSocketConfig() *webSocketConfig {
+	bridge := w.webSocketBridge()
+	if bridge == nil || !bridge.options.allowsOrigin(pageOrigin(w.browser.Source())) {
+		return nil
+	}
+	return &webSocketConfig{
+		URL:  "ws://" + bridge.clientAddr() + "/ws?token=" + url.QueryEscape(bridge.options.Token),
+		Eval: bridge.options.AllowRemoteEval,
+	}
+}
+
+// webSocketEval 执行页面收到的 eval 消息，脚本经过 JS 钩子，来源为 ScriptRemote
+func (w *webview) webSocketEval(script string) error {
+	bridge := w.webSocketBridge()
+	if bridge == nil || !bridge.options.AllowRemoteEval || !bridge.options.allowsOrigin(pageOrigin(w.browser.Source())) {
+		return errors.New("remote eval is not allowed")
+	}
+	w.evalScript(ScriptContext{Source: ScriptRemote}, script)
+	return nil
+}
+
+// clientAddr 返回客户端应连接的地址，监听所有地址时使用 127.0.0.1
+func (b *wsBridge) clientAddr() string {
+	addr := b.listener.Addr().(*net.TCPAddr)
+	host := b.options.Host
+	if addr.IP != nil && !addr.IP.IsUnspecified() {
+		host = addr.IP.String()
+	} else if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(addr.Port))
+}
+
+// WebSocketAddr 返回 WebSocket 服务实际监听的地址，如 "127.0.0.1:51234"，未启用时返回空字符串。
+// 以端口 0 启用时可通过它获取系统分配的端口
+func (w *webview) WebSocketAddr() string {
+	if bridge := w.webSocketBridge(); bridge != nil {
+		return bridge.clientAddr()
+	}
+	return ""
+}
+
+// WebSocketToken 返回当前会话的 WebSocket 令牌，供外部客户端连接时使用，未启用时返回空字符串
+func (w *webview) WebSocketToken() string {
+	if bridge := w.webSocketBridge(); bridge != nil {
+		return bridge.options.Token
+	}
+	return ""
+}
+
+func (w *webview) hasBinding(name string) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	_, ok := w.bindings[name]
+	return ok
+}
+
+// stopWebSocket 关闭所有连接和服务器，不修改页面
+func (w *webview) stopWebSocket() {
+	w.wsMu.Lock()
+	bridge := w.ws
+	w.ws = nil
+	w.wsMu.Unlock()
+	if bridge == nil {
+		return
+	}
+
+	// 被升级的连接不受 Shutdown 管理，需要单独关闭
+	for _, c := range bridge.connList() {
+		c.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bridge.server.Shutdown(ctx); err != nil {
+		log.Printf("Warning: Failed to shut down websocket server: %v", err)
+	}
+}
+
+// DisableWebSocket 禁用 WebSocket 服务，之后可以再次启用
+func (w *webview) DisableWebSocket() {
+	w.stopWebSocket()
+
+	// 清理客户端 WebSocket
+	w.evalInternal(`
+		if (window._webSocket) {
+			var ws = window._webSocket;
+			window._webSocket = null;
+			ws.close();
+		}
+	`)
+}
+
+// OnWebSocketMessage 设置 WebSocket 消息处理器。
+// join、leave、publish、request、response 类型的消息由服务处理，不会交给处理器
+func (w *webview) OnWebSocketMessage(handler WebSocketHandler) {
+	w.wsMu.Lock()
+	w.wsHandler = handler
+	w.wsMu.Unlock()
+}
+
+// SendWebSocketMessage 向所有连接发送 WebSocket 消息
+func (w *webview) SendWebSocketMessage(message string) {
+	bridge := w.webSocketBridge()
+	if bridge == nil {
+		return
+	}
+	for _, c := range bridge.connList() {
+		if err := c.Send(message); err != nil {
+			log.Printf("WebSocket write error: %v", err)
+		}
+	}
+}
@@ -9,16 +9,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"reflect"
 	"strconv"
 	"sync"
 	"time"
 	"unsafe"
 
-	"github.com/gorilla/websocket"
 	"github.com/yuaotian/go-win-webview2/internal/w32"
 	"github.com/yuaotian/go-win-webview2/pkg/edge"
+	"github.com/yuaotian/go-win-webview2/pkg/remote"
 
 	"golang.org/x/sys/windows"
 )
@@ -39,18 +38,26 @@ func setWindowContext(wnd uintptr, data interface{}) {
 }
 
 type browser interface {
-	Embed(hwnd uintptr) bool
+	Embed(hwnd uintptr) error
 	Resize()
-	Navigate(url string)
-	NavigateToString(htmlContent string)
-	Init(script string)
-	Eval(script string)
+	Navigate(url string) error
+	NavigateToString(htmlContent string) error
+	Init(script string) error
+	Eval(script string) error
 	NotifyParentWindowPositionChanged() error
 	Focus()
 	PrintToPDF(path string) error
+	CreatePrintSettings() (*edge.ICoreWebView2PrintSettings, error)
+	Print(settings *edge.ICoreWebView2PrintSettings, callback func(errorCode uintptr, status edge.COREWEBVIEW2_PRINT_STATUS)) error
+	ShowPrintUI(kind edge.COREWEBVIEW2_PRINT_DIALOG_KIND) error
 	DisableContextMenu() error
 	EnableContextMenu() error
 	GetSettings() (*edge.ICoreWebViewSettings, error)
+	Source() string
+	ExecuteScript(script string, callback func(result string, err error)) error
+	ZoomFactor() (float64, error)
+	SetBackgroundColor(color edge.COREWEBVIEW2_COLOR) error
+	SetZoomFactor(zoomFactor float64) error
 }
 
 type webview struct {
@@ -58,25 +65,80 @@ type webview struct {
 	mainthread uintptr
 	browser    browser
 	autofocus  bool
-	maxsz      w32.Point
-	minsz      w32.Point
+	maxsz      w32.Point // 最大尺寸 (DIP)
+	minsz      w32.Point // 最小尺寸 (DIP)
+	dpi        uint32    // 当前 DPI
 	m          sync.Mutex
 	bindings   map[string]interface{}
 	dispatchq  []func()
 	ctx        context.Context
-	hotkeys    map[int]HotKeyHandler
+	hotkeys    hotKeyRegistry
 	jsHooks    []JSHook // JavaScript hooks
+	zoom       *zoomState
+	stateFile  string // 窗口状态文件
+	stateKey   string // 窗口状态在文件中的键
+
+	// 快捷键，只在主线程访问
+	shortcuts *shortcutState
+	// 快捷键命令和页面焦点是否在输入框中，由 m 保护
+	shortcutHandlers map[string]HotKeyHandler
+	textInputFocus   bool
+	textInputBound   bool
 
 	// 状态听回调
 	onLoadingStateChanged func(bool)
 	onURLChanged          func(string)
 	onTitleChanged        func(string)
 	onFullscreenChanged   func(bool)
+	onDPIChanged          func(int)
+
+	// 恢复窗口状态时已按目标显示器 DPI 计算好尺寸，忽略 WM_DPICHANGED 建议的矩形
+	ignoreDPIRect bool
+
+	// 全屏状态，以及进入全屏前的位置和样式
+	fullscreen  bool
+	fsPlacement w32.WindowPlacement
+	fsStyle     uintptr
+	fsExStyle   uintptr
+
+	// 无边框窗口的标题栏和边框，非无边框窗口为 nil
+	frame *framelessState
+
+	// 菜单栏和右键菜单
+	menuBar            *nativeMenu
+	contextMenuHandler ContextMenuHandler
+	contextMenuItems   map[int32]menuEntry
+
+	// 窗口图标来源，托盘图标未指定图标时使用
+	icon iconSource
+
+	// 托盘图标
+	trays      map[uint32]*Tray
+	nextTrayID uint32
+
+	// 关闭时隐藏窗口而不是销毁
+	hideOnClose bool
+
+	// WebSocket 服务，未启用时为 nil。ws 和各处理函数由 wsMu 保护
+	ws                   *wsBridge
+	wsMu                 sync.Mutex
+	wsHandler            WebSocketHandler
+	wsConnMessageHandler func(conn *WSConn, message string)
+	wsConnectHandler     func(conn *WSConn)
+	wsDisconnectHandler  func(conn *WSConn)
+	wsRequestHandlers    map[string]WSRequestHandler
+
+	// 远程控制，未启用时为 nil，由 wsMu 保护
+	remote *remoteControl
+
+	// 等待页面返回结果的脚本
+	evals evalState
+
+	// CSP 拦截，未启用时为 nil
+	csp *cspState
 
-	wsServer      *http.Server
-	wsUpgrader    websocket.Upgrader
-	wsHandler     WebSocketHandler
-	wsConnections sync.Map
+	// 跟踪记录
+	trace traceState
 
 	// 用于处理导航的通道
 	navigationChan chan string
@@ -86,26 +148,32 @@ type webview struct {
 }
 
 type WindowOptions struct {
-	Title              string  // 窗口标题
-	Width              uint    // 窗口宽度
-	Height             uint    // 窗口高度
-	IconId             uint    // 图标ID
-	Center             bool    // 是否居中
-	Frameless          bool    // 是否无边框
-	Fullscreen         bool    // 是否全屏
-	AlwaysOnTop        bool    // 是否置顶
-	Resizable          bool    // 是否可调整大小
-	Minimizable        bool    // 是否可最小化
-	Maximizable        bool    // 是否可最大化
-	Minimized          bool    // 初始是否最小化
-	Maximized          bool    // 初始是否最大化
-	DisableContextMenu bool    // 是否禁用右键���单
-	EnableDragAndDrop  bool    // 是否启用拖放
-	HideWindowOnClose  bool    // 关闭时是否隐藏窗口而不是退出
-	DefaultBackground  string  // 默认背景色 (CSS 格式，如 "#FFFFFF")
-	Opacity            float64 // 初始透明度 (0.0-1.0)
-	IconPath           string  // 图标文件路径
-	IconData           []byte  // 图标二进制数据
+	Title              string   // 窗口标题
+	Width              uint     // 窗口宽度
+	Height             uint     // 窗口高度
+	IconId             uint     // 图标ID
+	Center             bool     // 是否居中
+	Frameless          bool     // 是否无边框
+	Fullscreen         bool     // 是否全屏
+	AlwaysOnTop        bool     // 是否置顶
+	Resizable          bool     // 是否可调整大小
+	Minimizable        bool     // 是否可最小化
+	Maximizable        bool     // 是否可最大化
+	Minimized          bool     // 初始是否最小化
+	Maximized          bool     // 初始是否最大化
+	DisableContextMenu bool     // 是否禁用右键���单
+	EnableDragAndDrop  bool     // 是否启用拖放
+	HideWindowOnClose  bool     // 关闭时是否隐藏窗口而不是退出
+	DefaultBackground  string   // 默认背景色 (CSS 格式，如 "#FFFFFF"、"rgba(0,0,0,0)"、"transparent")
+	Transparent        bool     // 是否使用透明背景 (WebView 透明并透出桌面)
+	Backdrop           Backdrop // 窗口背景材质 (云母/亚克力)，设置后自动启用透明背景
+	Opacity            float64  // 初始透明度 (0.0-1.0)
+	IconPath           string   // 图标文件路径
+	IconData           []byte   // 图标二进制数据
+	StateFile          string   // 窗口状态文件 (JSON)，设置后关闭时保存位置/大小/最大化状态并在下次启动时恢复
+	StateKey           string   // 窗口状态在文件中的键，用于多个窗口共用一个文件，默认为 "main"
+	ResizeBorder       int      // 无边框窗口可拖动调整大小的边框宽度 (DIP)，默认 5，-1 表示禁用
+	Monitor            string   // 初始显示的显示器设备名 (见 Monitors)，为空时使用主显示器；已恢复保存的状态时忽略
 }
 
 // 添加默认配置
@@ -143,23 +211,41 @@ type WebViewOptions struct {
 	//WindowOptions 自定义创建的窗口以嵌入
 	//WebView2 小部件。
 	WindowOptions WindowOptions
+
+	//Zoom 配置缩放范围、按来源记住缩放比例以及缩放快捷键。
+	Zoom ZoomOptions
+
+	//BindDialogs 为 true 时在页面中提供 window.webview2.dialogs
+	//(openFile/openFiles/saveFile/pickFolder)，各方法返回 Promise。
+	BindDialogs bool
 }
 
-// New 在新窗口中创建个新的 webview。
+// New 在新窗口中创建个新的 webview，失败时返回 nil，需要错误原因时使用 NewWithOptions。
 func New(debug bool) WebView {
-	return NewWithOptions(WebViewOptions{Debug: debug})
-
+	w, err := NewWithOptions(WebViewOptions{Debug: debug})
+	if err != nil {
+		log.Printf("Error creating webview: %v", err)
+		return nil
+	}
+	return w
 }
 
 // NewWindow 使用现有窗创一个新的 webview。
 //
 // 已弃用：使用 NewWithOptions。
 func NewWindow(debug bool, window unsafe.Pointer) WebView {
-	return NewWithOptions(WebViewOptions{Debug: debug, Window: window})
+	w, err := NewWithOptions(WebViewOptions{Debug: debug, Window: window})
+	if err != nil {
+		log.Printf("Error creating webview: %v", err)
+		return nil
+	}
+	return w
 }
 
 // NewWithOptions 使用提供的选项创建一个的 webview。
-func NewWithOptions(options WebViewOptions) WebView {
+// WebView2 创建失败时返回的错误可用 errors.Is 与 edge.ErrFileNotFound (未安装运行时)、
+// edge.ErrAccessDenied (数据目录不可写) 和 edge.ErrChangedMode (COM 线程模式错误) 比较。
+func NewWithOptions(options WebViewOptions) (WebView, error) {
 	// 合并默认选项
 	defaultOpts := DefaultWindowOptions()
 	if options.WindowOptions.Title == "" {
@@ -190,49 +276,87 @@ func NewWithOptions(options WebViewOptions) WebView {
 	}
 
 	w := &webview{
-		ctx:     context.Background(),
-		hotkeys: make(map[int]HotKeyHandler),
+		ctx: context.Background(),
 	}
 	w.bindings = map[string]interface{}{}
 	w.autofocus = options.AutoFocus
+	w.zoom = newZoomState(options.Zoom)
 
 	chromium := edge.NewChromium()
 	chromium.MessageCallback = w.msgcb
 	chromium.DataPath = options.DataPath
 	chromium.SetPermission(edge.CoreWebView2PermissionKindClipboardRead, edge.CoreWebView2PermissionStateAllow)
+	chromium.ZoomFactorChangedCallback = w.zoomFactorChanged
+	chromium.NavigationStartedCallback = w.navigationStarted
+	chromium.NavigationCompletedCallback = func(_ *edge.ICoreWebView2, args *edge.ICoreWebView2NavigationCompletedEventArgs) {
+		w.navigationCompleted(args)
+		w.restoreZoom()
+		w.notifyRemote(remote.EventNavigationCompleted, w.browser.Source())
+	}
+	chromium.AcceleratorKeyCallback = w.acceleratorKey
+	chromium.ContextMenuRequestedCallback = func(_ *edge.ICoreWebView2, args *edge.ICoreWebView2ContextMenuRequestedEventArgs) {
+		w.contextMenuRequested(args)
+	}
+	chromium.CustomItemSelectedCallback = w.customContextMenuItemSelected
 
 	w.browser = chromium
 	w.mainthread, _, _ = w32.Kernel32GetCurrentThreadID.Call()
-	if !w.CreateWithOptions(options.WindowOptions) {
-		return nil
+	if err := w.CreateWithOptions(options.WindowOptions); err != nil {
+		w.destroyWindow()
+		return nil, err
 	}
 
 	settings, err := chromium.GetSettings()
 	if err != nil {
-		log.Printf("Warning: Failed to get settings: %v", err)
-		return nil
+		w.destroyWindow()
+		return nil, err
 	}
 
-	
 	//禁用上下文菜单
-	err = settings.PutAreDefaultContextMenusEnabled(options.Debug)
-	if err != nil {
-		log.Fatal(err)
+	if err := settings.PutAreDefaultContextMenusEnabled(options.Debug); err != nil {
+		log.Printf("Warning: Failed to set default context menus: %v", err)
 	}
 	//禁用开者工具
-	err = settings.PutAreDevToolsEnabled(options.Debug)
-	if err != nil {
-		log.Fatal(err)
+	if err := settings.PutAreDevToolsEnabled(options.Debug); err != nil {
+		log.Printf("Warning: Failed to set dev tools: %v", err)
+	}
+
+	// 页面通过 window.webview2.getDPI() 获取当前 DPI
+	_ = w.Bind("__webview2GetDPI", func() int {
+		return w.DPI()
+	})
+
+	// 页面返回 evalResult 的执行结果
+	w.bindEvalResult()
+
+	// 无边框窗口由页面提供标题栏
+	if w.frame != nil {
+		w.initFrameless()
+	}
+
+	if options.BindDialogs {
+		w.bindDialogs()
 	}
 
 	// 设置默认消息处理
+	// 带 method 字段的是 Bind 的 RPC 调用，其余交给 Chromium 处理
 	w.SetMessageCallback(func(msg string) {
+		start := time.Now()
+		var probe struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal([]byte(msg), &probe) == nil && probe.Method != "" {
+			w.msgcb(msg)
+			w.traceMessage("rpc", msg, start)
+			return
+		}
 		if chromium, ok := w.browser.(*edge.Chromium); ok {
 			chromium.HandleWebMessage(msg)
 		}
+		w.traceMessage("message", msg, start)
 	})
 
-	return w
+	return w, nil
 }
 
 type rpcMessage struct {
@@ -254,22 +378,32 @@ func (w *webview) msgcb(msg string) {
 	rejectScript := fmt.Sprintf("window._rpc[%s].reject", id)
 	resolveScript := fmt.Sprintf("window._rpc[%s].resolve", id)
 	cleanupScript := fmt.Sprintf("window._rpc[%s] = undefined", id)
+	ctx := ScriptContext{Source: ScriptBind, Binding: w.bindingName(d.Method)}
 
-	if res, err := w.callbinding(d); err != nil {
-		w.Dispatch(func() {
-			w.Eval(fmt.Sprintf("%s(%s); %s", rejectScript, jsString(err.Error()), cleanupScript))
-		})
+	start := time.Now()
+	res, err := w.callbinding(d)
+	w.traceRPC(d, start, err)
+	if err != nil {
+		w.evalScript(ctx, fmt.Sprintf("%s(%s); %s", rejectScript, jsString(err.Error()), cleanupScript))
 	} else if b, err := json.Marshal(res); err != nil {
-		w.Dispatch(func() {
-			w.Eval(fmt.Sprintf("%s(%s); %s", rejectScript, jsString(err.Error()), cleanupScript))
-		})
+		w.evalScript(ctx, fmt.Sprintf("%s(%s); %s", rejectScript, jsString(err.Error()), cleanupScript))
 	} else {
-		w.Dispatch(func() {
-			w.Eval(fmt.Sprintf("%s(%s); %s", resolveScript, string(b), cleanupScript))
-		})
+		w.evalScript(ctx, fmt.Sprintf("%s(%s); %s", resolveScript, string(b), cleanupScript))
 	}
 }
 
+// unknownBinding 是页面调用未绑定的函数时在跟踪和 ScriptContext 中使用的名称
+const unknownBinding = "unknown"
+
+// bindingName 返回用于跟踪的函数名。方法名由页面提供，未绑定的名称统一为 unknownBinding，
+// 避免页面产生任意多的 span 名称
+func (w *webview) bindingName(method string) string {
+	if !w.hasBinding(method) {
+		return unknownBinding
+	}
+	return method
+}
+
 func (w *webview) callbinding(d rpcMessage) (interface{}, error) {
 	w.m.Lock()
 	f, ok := w.bindings[d.Method]
@@ -330,12 +464,30 @@ func (w *webview) callbinding(d rpcMessage) (interface{}, error) {
 	}
 }
 
+// acceleratorKey 处理 WebView 获得焦点时的按键，返回 true 表示已处理且不再传给页面
+func (w *webview) acceleratorKey(virtualKey uint) bool {
+	if w.shortcutKey(virtualKey) {
+		return true
+	}
+	if w.menuAcceleratorKey(virtualKey) {
+		return true
+	}
+	if w.windowHotKey(virtualKey) {
+		return true
+	}
+	return w.zoomHotkey(virtualKey)
+}
+
 func wndproc(hwnd, msg, wp, lp uintptr) uintptr {
 	if w, ok := getWindowContext(hwnd).(*webview); ok {
 		switch msg {
 		case w32.WMMove, w32.WMMoving:
 			_ = w.browser.NotifyParentWindowPositionChanged()
 		case w32.WMNCLButtonDown:
+			if wp == w32.HTMaxButton && w.frame != nil {
+				// 由 WM_NCLBUTTONUP 切换最大化，避免系统绘制默认的标题栏按钮
+				return 0
+			}
 			if wp == w32.HTCaption {
 				// 直接调用 DefWindowProc 处理拖动
 				r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
@@ -346,6 +498,10 @@ func wndproc(hwnd, msg, wp, lp uintptr) uintptr {
 			return r
 		case w32.WMSize:
 			w.browser.Resize()
+			w.updateCaptionRegion()
+			if wp == w32.SIZE_MAXIMIZED || wp == w32.SIZE_RESTORED {
+				w.notifyMaximizeChanged(wp == w32.SIZE_MAXIMIZED)
+			}
 		case w32.WMActivate:
 			if wp == w32.WAInactive {
 				break
@@ -354,74 +510,83 @@ func wndproc(hwnd, msg, wp, lp uintptr) uintptr {
 				w.browser.Focus()
 			}
 		case w32.WMClose:
+			if err := w.SaveWindowState(); err != nil {
+				log.Printf("Warning: Failed to save window state: %v", err)
+			}
+			if w.hideOnClose {
+				// 隐藏到后台，之后可通过 Show 或托盘图标重新显示
+				_, _, _ = w32.User32ShowWindow.Call(hwnd, w32.SW_HIDE)
+				return 0
+			}
 			_, _, _ = w32.User32DestroyWindow.Call(hwnd)
 		case w32.WMDestroy:
 			w.Terminate()
 		case w32.WMGetMinMaxInfo:
 			lpmmi := (*w32.MinMaxInfo)(unsafe.Pointer(lp))
+			dpi := uint32(w.DPI())
 			if w.maxsz.X > 0 && w.maxsz.Y > 0 {
-				lpmmi.PtMaxSize = w.maxsz
-				lpmmi.PtMaxTrackSize = w.maxsz
+				maxsz := w32.Point{X: scaleForDPI(int(w.maxsz.X), dpi), Y: scaleForDPI(int(w.maxsz.Y), dpi)}
+				lpmmi.PtMaxSize = maxsz
+				lpmmi.PtMaxTrackSize = maxsz
 			}
 			if w.minsz.X > 0 && w.minsz.Y > 0 {
-				lpmmi.PtMinTrackSize = w.minsz
+				lpmmi.PtMinTrackSize = w32.Point{X: scaleForDPI(int(w.minsz.X), dpi), Y: scaleForDPI(int(w.minsz.Y), dpi)}
 			}
+		case w32.WMDpiChanged:
+			// lParam 是系统提供的建议窗口矩形
+			w.handleDPIChanged(uint32(wp&0xffff), (*w32.Rect)(unsafe.Pointer(lp)))
 		case w32.WMNCHitTest:
-			// 获取鼠标位置
-			x := int32(lp & 0xffff)
-			y := int32((lp >> 16) & 0xffff)
-
-			// 获取窗口位置
-			var rect w32.Rect
-			_, _, _ = w32.User32GetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&rect)))
-
-			// 定义边框拖拽区宽度
-			const borderWidth = 5
-
-			// 检查是否在拖拽域内
-			if y >= rect.Top && y <= rect.Top+borderWidth {
-				if x >= rect.Left && x <= rect.Left+borderWidth {
-					return w32.HTTopLeft
-				}
-				if x >= rect.Right-borderWidth && x <= rect.Right {
-					return w32.HTTopRight
-				}
-				return w32.HTTop
+			if w.frame == nil {
+				r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
+				return r
 			}
-			if y >= rect.Bottom-borderWidth && y <= rect.Bottom {
-				if x >= rect.Left && x <= rect.Left+borderWidth {
-					return w32.HTBottomLeft
-				}
-				if x >= rect.Right-borderWidth && x <= rect.Right {
-					return w32.HTBottomRight
-				}
-				return w32.HTBottom
+			return w.framelessHitTest(lp)
+		case w32.WMPaint:
+			if w.frame != nil {
+				w.framelessPaint()
+				return 0
 			}
-			if x >= rect.Left && x <= rect.Left+borderWidth {
-				return w32.HTLeft
+		case w32.WMNCCalcSize:
+			if w.frame == nil || wp == 0 {
+				r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
+				return r
 			}
-			if x >= rect.Right-borderWidth && x <= rect.Right {
-				return w32.HTRight
+			// 客户区覆盖整个窗口，去除系统边框和标题栏
+			w.framelessCalcSize(lp)
+			return 0
+		case w32.WMNCLButtonUp:
+			if wp == w32.HTMaxButton && w.frame != nil {
+				w.toggleMaximize()
+				return 0
 			}
-
-			// 允许窗口
-			return w32.HTCaption
-
+			r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
+			return r
 		case w32.WMLButtonDown:
 			if wp == w32.HTCaption {
 				_, _, _ = w32.User32SendMessageW.Call(hwnd, w32.WMNCLButtonDown, wp, lp)
 				return 0
 			}
-		case w32.WMHotKey:
-			w.m.Lock()
-			if handler, ok := w.hotkeys[int(wp)]; ok {
-				w.m.Unlock()
-				handler()
-			} else {
-				w.m.Unlock()
+		case w32.WMCommand:
+			// lParam 为 0 表示来自菜单或快捷键而非控件
+			if lp == 0 && w.handleMenuCommand(uint16(wp&0xffff)) {
+				return 0
 			}
+			r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
+			return r
+		case w32.WMHotKey:
+			w.globalHotKey(int(wp))
+			return 0
+		case wmTrayIcon:
+			w.handleTrayMessage(wp, lp)
 			return 0
 		default:
+			if taskbarCreated != 0 && msg == uintptr(taskbarCreated) {
+				// 资源管理器重启后托盘图标会丢失，需要重新添加
+				for _, t := range w.trays {
+					t.readd()
+				}
+				return 0
+			}
 			r, _, _ := w32.User32DefWindowProcW.Call(hwnd, msg, wp, lp)
 			return r
 		}
@@ -434,14 +599,26 @@ func wndproc(hwnd, msg, wp, lp uintptr) uintptr {
 func (w *webview) Create(debug bool, window unsafe.Pointer) bool {
 	// This function signature stopped making sense a long time ago.
 	// It is but legacy cruft at this point.
-	return w.CreateWithOptions(WindowOptions{})
+	return w.CreateWithOptions(WindowOptions{}) == nil
+}
+
+// destroyWindow 在创建失败时销毁已创建的窗口，不触发 Terminate
+func (w *webview) destroyWindow() {
+	if w.hwnd == 0 {
+		return
+	}
+	windowContext.Delete(w.hwnd)
+	_, _, _ = w32.User32DestroyWindow.Call(w.hwnd)
+	w.hwnd = 0
 }
 
-func (w *webview) CreateWithOptions(opts WindowOptions) bool {
+func (w *webview) CreateWithOptions(opts WindowOptions) error {
+	// 必须在创建窗口之前声明 DPI 感知
+	enableDpiAwareness()
+
 	var hinstance windows.Handle
 	if err := windows.GetModuleHandleEx(0, nil, &hinstance); err != nil {
-		log.Printf("Error getting module handle: %v", err)
-		return false
+		return fmt.Errorf("failed to get module handle: %w", err)
 	}
 
 	icon := w.loadWindowIcon(hinstance, opts.IconId, opts)
@@ -471,6 +648,11 @@ func (w *webview) CreateWithOptions(opts WindowOptions) bool {
 		windowHeight = 480
 	}
 
+	// Width/Height 为设备无关像素，按主显示器的 DPI 换算为物理像素
+	dpi := primaryMonitorDPI()
+	windowWidth = uint(scaleForDPI(int(windowWidth), dpi))
+	windowHeight = uint(scaleForDPI(int(windowHeight), dpi))
+
 	var posX, posY uint
 	if opts.Center {
 		// get screen size
@@ -488,7 +670,8 @@ func (w *webview) CreateWithOptions(opts WindowOptions) bool {
 	// 修改窗口样式设置
 	var style uint32 = w32.WSOverlappedWindow
 	if opts.Frameless {
-		style = w32.WSPopup | w32.WSVisible
+		style = framelessStyle(opts)
+		w.frame = newFramelessState(opts)
 	} else {
 		// 根据选项调整窗样式
 		if !opts.Maximizable {
@@ -502,10 +685,20 @@ func (w *webview) CreateWithOptions(opts WindowOptions) bool {
 		}
 	}
 
+	// 背景材质需要透明的 WebView 才能透出
+	if opts.Backdrop != BackdropNone {
+		opts.Transparent = true
+	}
+
 	// 添加分层窗口扩展样式
+	// 透明窗口由 DWM 合成，分层窗口会阻止透出，因此不使用 WS_EX_LAYERED
 	var exStyle uint32 = w32.WS_EX_LAYERED
+	if opts.Transparent {
+		exStyle = 0
+	}
 
-	w.hwnd, _, _ = w32.User32CreateWindowExW.Call(
+	var createErr error
+	w.hwnd, _, createErr = w32.User32CreateWindowExW.Call(
 		uintptr(exStyle),
 		uintptr(unsafe.Pointer(className)),
 		uintptr(unsafe.Pointer(windowName)),
@@ -519,23 +712,85 @@ func (w *webview) CreateWithOptions(opts WindowOptions) bool {
 		uintptr(hinstance),
 		0,
 	)
+	if w.hwnd == 0 {
+		return fmt.Errorf("failed to create window: %w", createErr)
+	}
 
 	// 设置初始透明度(默认完全不透明)
-	_, _, _ = w32.User32SetLayeredWindowAttributes.Call(
-		w.hwnd,
-		0,
-		255, // 完全不透明
-		w32.LWA_ALPHA,
-	)
+	if !opts.Transparent {
+		_, _, _ = w32.User32SetLayeredWindowAttributes.Call(
+			w.hwnd,
+			0,
+			255, // 完全不透明
+			w32.LWA_ALPHA,
+		)
+	}
 
+	w.dpi = w32.GetDpiForWindow(w.hwnd)
 	setWindowContext(w.hwnd, w)
 
+	// 创建窗口时 wndproc 还无法处理 WM_NCCALCSIZE，重新计算以去除无边框窗口的边框
+	if w.frame != nil {
+		_, _, _ = w32.User32SetWindowPos.Call(
+			w.hwnd,
+			0,
+			0, 0, 0, 0,
+			w32.SWP_NOMOVE|w32.SWP_NOSIZE|w32.SWP_NOZORDER|w32.SWP_NOACTIVATE|w32.SWP_FRAMECHANGED,
+		)
+	}
+
+	// 恢复上次保存的窗口状态
+	stateRestored := false
+	if opts.StateFile != "" {
+		w.stateFile = opts.StateFile
+		w.stateKey = opts.StateKey
+		if w.stateKey == "" {
+			w.stateKey = defaultStateKey
+		}
+		if state, ok := loadWindowState(w.stateFile, w.stateKey); ok {
+			w.ignoreDPIRect = true
+			w.applyWindowState(state)
+			w.ignoreDPIRect = false
+			w.dpi = w32.GetDpiForWindow(w.hwnd)
+			stateRestored = true
+		}
+	}
+	if !stateRestored && opts.Monitor != "" {
+		if target, ok := findMonitor(opts.Monitor); !ok {
+			log.Printf("Warning: Monitor %s not found", opts.Monitor)
+		} else if err := w.moveToMonitor(target); err != nil {
+			log.Printf("Warning: Failed to move window to monitor %s: %v", opts.Monitor, err)
+		}
+		w.dpi = w32.GetDpiForWindow(w.hwnd)
+	}
+
+	// 设置默认背景色，在 WebView 创建前设置以避免首帧白屏
+	background := opts.DefaultBackground
+	if opts.Transparent {
+		background = "transparent"
+		if err := w.extendFrameIntoClientArea(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		if opts.Backdrop != BackdropNone {
+			if err := w.SetBackdrop(opts.Backdrop); err != nil {
+				log.Printf("Warning: Failed to set backdrop: %v", err)
+			}
+		}
+	}
+	if background != "" {
+		if color, err := edge.ParseColor(background); err != nil {
+			log.Printf("Warning: Invalid background color %q: %v", background, err)
+		} else if err := w.browser.SetBackgroundColor(color); err != nil {
+			log.Printf("Warning: Failed to set background color: %v", err)
+		}
+	}
+
 	_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_SHOW)
 	_, _, _ = w32.User32UpdateWindow.Call(w.hwnd)
 	_, _, _ = w32.User32SetFocus.Call(w.hwnd)
 
-	if !w.browser.Embed(w.hwnd) {
-		return false
+	if err := w.browser.Embed(w.hwnd); err != nil {
+		return err
 	}
 	w.browser.Resize()
 
@@ -553,48 +808,25 @@ func (w *webview) CreateWithOptions(opts WindowOptions) bool {
 		w.DisableContextMenu()
 	}
 
-	// 设置默认背色
-	if opts.DefaultBackground != "" {
-		w.Eval(fmt.Sprintf(`
-			document.documentElement.style.background = '%s';
-			document.body.style.background = '%s';
-		`, opts.DefaultBackground, opts.DefaultBackground))
-	}
-
 	// 处理窗口关闭行为
-	if opts.HideWindowOnClose {
-		w.Bind("__handleWindowClose", func() {
-			w.Dispatch(func() {
-				_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_HIDE)
-			})
-		})
-		w.Init(`
-			window.onbeforeunload = function(e) {
-				window.__handleWindowClose();
-				e.preventDefault();
-				return false;
-			};
-		`)
-	}
+	w.hideOnClose = opts.HideWindowOnClose
 
-	// 设置初始窗口状态
+	// 设置初始窗口状态，已恢复保存的状态时以保存的状态为准
+	if stateRestored {
+		return nil
+	}
 	if opts.Maximizable && opts.Maximized {
 		w.Maximize()
 	} else if opts.Minimizable && opts.Minimized {
 		w.Minimize()
 	}
 
-	return true
+	return nil
 }
 
 func (w *webview) Destroy() {
 	// 注所有热键
-	w.m.Lock()
-	for id := range w.hotkeys {
-		_, _, _ = w32.User32UnregisterHotKey.Call(w.hwnd, uintptr(id))
-	}
-	w.hotkeys = nil
-	w.m.Unlock()
+	w.unregisterAllHotKeys()
 
 	// 清理资源
 	w.m.Lock()
@@ -602,6 +834,24 @@ func (w *webview) Destroy() {
 	w.dispatchq = nil
 	w.m.Unlock()
 
+	// 移除托盘图标，否则图标会残留到鼠标经过时才消失
+	w.removeTrays()
+
+	// 关闭 WebSocket 服务，释放端口
+	w.stopWebSocket()
+
+	// 移除并释放菜单栏及其图标位图
+	if w.menuBar != nil {
+		_, _, _ = w32.User32SetMenu.Call(w.hwnd, 0)
+		w.menuBar.destroy()
+		w.menuBar = nil
+	}
+
+	// 保存窗口状态，移除 windowContext 后 wndproc 将无法再处理
+	if err := w.SaveWindowState(); err != nil {
+		log.Printf("Warning: Failed to save window state: %v", err)
+	}
+
 	// 从 windowContext 中移除
 	windowContext.Delete(w.hwnd)
 
@@ -648,11 +898,15 @@ func (w *webview) Window() unsafe.Pointer {
 }
 
 func (w *webview) Navigate(url string) {
-	w.browser.Navigate(url)
+	if err := w.browser.Navigate(url); err != nil {
+		log.Printf("Warning: Failed to navigate: %v", err)
+	}
 }
 
 func (w *webview) SetHtml(html string) {
-	w.browser.NavigateToString(html)
+	if err := w.browser.NavigateToString(html); err != nil {
+		log.Printf("Warning: Failed to set HTML: %v", err)
+	}
 }
 
 func (w *webview) SetTitle(title string) {
@@ -684,16 +938,16 @@ func (w *webview) SetSize(width int, height int, hints Hint) {
 		w.minsz.X = int32(width)
 		w.minsz.Y = int32(height)
 	} else {
+		// width/height 为设备无关像素的客户区尺寸
+		dpi := uint32(w.DPI())
 		r := w32.Rect{}
 		r.Left = 0
 		r.Top = 0
-		r.Right = int32(width)
-		r.Bottom = int32(height)
-		_, _, _ = w32.User32AdjustWindowRect.Call(
-			uintptr(unsafe.Pointer(&r)),
-			uintptr(w32.WSOverlappedWindow),
-			0,
-		)
+		r.Right = scaleForDPI(width, dpi)
+		r.Bottom = scaleForDPI(height, dpi)
+		exIndex := w32.GWL_EXSTYLE
+		exStyle, _, _ := w32.User32GetWindowLongPtrW.Call(w.hwnd, uintptr(exIndex))
+		w32.AdjustWindowRectForDpi(&r, uint32(style), uint32(exStyle), dpi)
 		_, _, _ = w32.User32SetWindowPos.Call(
 			w.hwnd, 0, uintptr(r.Left), uintptr(r.Top),
 			uintptr(r.Right-r.Left), uintptr(r.Bottom-r.Top),
@@ -704,39 +958,28 @@ func (w *webview) SetSize(width int, height int, hints Hint) {
 
 // 初始化(加载之前注入js，永久注入)
 func (w *webview) Init(js string) {
-	// 添加 webview2 导航功能
-	baseScript := `
-		window.webview2 = {
+	w.initScript(ScriptContext{Source: ScriptInit, Caller: callerLocation(1)}, js)
+}
+
+// initBaseScript 添加 webview2 导航功能，随每个 Init 脚本注册
+const initBaseScript = `
+		// 保留其他脚本 (如 dialogs) 添加到 window.webview2 的成员
+		window.webview2 = Object.assign(window.webview2 || {}, {
 			navigate: function(url) {
 				window.chrome.webview.postMessage(JSON.stringify({
 					type: 'navigate',
 					url: url
 				}));
+			},
+			getDPI: function() {
+				return window.__webview2GetDPI();
 			}
-		};
+		});
 	`
 
-	// 合并脚本
-	fullScript := baseScript
-	if js != "" {
-		fullScript += ";" + js
-	}
-
-	// 初始化浏览器
-	w.browser.Init(fullScript)
-}
-
 // 执行JS(加载之后注入js，临时注入)
 func (w *webview) Eval(js string) {
-	// 应用前置钩子
-	js = w.processScript(js, JSHookBefore)
-
-	w.Dispatch(func() {
-		w.browser.Eval(js)
-	})
-
-	// 应用后置钩子
-	js = w.processScript(js, JSHookAfter)
+	w.evalScript(ScriptContext{Source: ScriptEval, Caller: callerLocation(1)}, js)
 }
 
 func (w *webview) Dispatch(f func()) {
@@ -746,6 +989,92 @@ func (w *webview) Dispatch(f func()) {
 	_, _, _ = w32.User32PostThreadMessageW.Call(w.mainthread, w32.WMApp, 0, 0)
 }
 
+// isMainThread 判断当前是否运行在窗口消息循环所在的线程
+func (w *webview) isMainThread() bool {
+	tid, _, _ := w32.Kernel32GetCurrentThreadID.Call()
+	return tid == w.mainthread
+}
+
+// runOnMain 在主线程上同步执行 f，若当前已在主线程则直接执行
+func (w *webview) runOnMain(f func()) {
+	if w.isMainThread() {
+		f()
+		return
+	}
+	done := make(chan struct{})
+	w.Dispatch(func() {
+		defer close(done)
+		f()
+	})
+	<-done
+}
+
+// runOnMainContext 与 runOnMain 相同，但在 ctx 结束时不再等待并返回 ctx.Err()，
+// 用于消息循环可能已经停止的调用方。此时 f 仍在队列中，消息循环恢复后可能执行
+func (w *webview) runOnMainContext(ctx context.Context, f func()) error {
+	if w.isMainThread() {
+		f()
+		return nil
+	}
+	done := make(chan struct{})
+	w.Dispatch(func() {
+		defer close(done)
+		f()
+	})
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pumpUntil 在主线程上处理消息，直到 cond 返回 true 或 ctx 被取消。
+// 用于在主线程上等待异步 COM 回调而不阻塞消息循环。
+func (w *webview) pumpUntil(ctx context.Context, cond func() bool) error {
+	// 没有消息时 GetMessageW 一直阻塞，ctx 结束时投递一条消息将其唤醒
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_, _, _ = w32.User32PostThreadMessageW.Call(w.mainthread, w32.WMApp, 0, 0)
+		case <-stop:
+		}
+	}()
+
+	var msg w32.Msg
+	for !cond() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		r, _, _ := w32.User32GetMessageW.Call(
+			uintptr(unsafe.Pointer(&msg)),
+			0,
+			0,
+			0,
+		)
+		if r == 0 {
+			// 收到 WM_QUIT，重新投递以便 Run 正常退出
+			w.Terminate()
+			return errors.New("message loop terminated")
+		}
+		if msg.Message == w32.WMApp {
+			w.m.Lock()
+			q := append([]func(){}, w.dispatchq...)
+			w.dispatchq = []func(){}
+			w.m.Unlock()
+			for _, v := range q {
+				v()
+			}
+			continue
+		}
+		_, _, _ = w32.User32TranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
+		_, _, _ = w32.User32DispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
+	}
+	return nil
+}
+
 func (w *webview) Bind(name string, f interface{}) error {
 	v := reflect.ValueOf(f)
 	if v.Kind() != reflect.Func {
@@ -758,7 +1087,14 @@ func (w *webview) Bind(name string, f interface{}) error {
 	w.bindings[name] = f
 	w.m.Unlock()
 
-	w.Init("(function() { var name = " + jsString(name) + ";" + `
+	w.initScript(ScriptContext{Source: ScriptBind, Caller: callerLocation(1), Binding: name}, bindingScript(name))
+
+	return nil
+}
+
+// bindingScript 返回在页面中定义绑定函数的脚本
+func bindingScript(name string) string {
+	return "(function() { var name = " + jsString(name) + ";" + `
 		var RPC = window._rpc = (window._rpc || {nextSeq: 1});
 		window[name] = function() {
 		  var seq = RPC.nextSeq++;
@@ -775,68 +1111,79 @@ func (w *webview) Bind(name string, f interface{}) error {
 		  }));
 		  return promise;
 		}
-	})()`)
+	})()`
+}
 
-	return nil
+// iconSource 描述图标的来源，优先级为 data > path > id
+type iconSource struct {
+	id   uint
+	path string
+	data []byte
 }
 
-func (w *webview) loadWindowIcon(hinstance windows.Handle, iconId uint, opts WindowOptions) uintptr {
+func (s iconSource) empty() bool {
+	return len(s.data) == 0 && s.path == "" && s.id == 0
+}
+
+// load 按指定尺寸加载图标，size 为 0 时使用系统默认的大图标尺寸。
+// 所有来源都加载失败时返回系统默认图标，此时 shared 为 true，调用方不能销毁它
+func (s iconSource) load(hinstance windows.Handle, size int) (icon uintptr, shared bool) {
+	if size <= 0 {
+		cx, _, _ := w32.User32GetSystemMetrics.Call(w32.SystemMetricsCxIcon)
+		size = int(cx)
+	}
+
 	// 1. 优先使用 IconData
-	if len(opts.IconData) > 0 {
-		icon, _, _ := w32.User32CreateIconFromResourceEx.Call(
-			uintptr(unsafe.Pointer(&opts.IconData[0])),
-			uintptr(len(opts.IconData)),
-			1,          // IMAGE_ICON
-			0x00030000, // 版本
-			0, 0,
-			w32.LR_DEFAULTSIZE,
-		)
-		if icon != 0 {
-			return icon
-		}
+	if icon := w32.CreateIconFromBytes(s.data, size); icon != 0 {
+		return icon, false
 	}
 
 	// 2. 其次使用 IconPath
-	if opts.IconPath != "" {
-		iconPath, _ := windows.UTF16PtrFromString(opts.IconPath)
+	if s.path != "" {
+		iconPath, _ := windows.UTF16PtrFromString(s.path)
 		icon, _, _ := w32.User32LoadImageW.Call(
 			0,
 			uintptr(unsafe.Pointer(iconPath)),
 			1, // IMAGE_ICON
-			0, 0,
-			w32.LR_LOADFROMFILE|w32.LR_DEFAULTSIZE,
+			uintptr(size),
+			uintptr(size),
+			w32.LR_LOADFROMFILE,
 		)
 		if icon != 0 {
-			return icon
+			return icon, false
 		}
 	}
 
 	// 3. 再次使用 IconId
-	if iconId != 0 {
+	if s.id != 0 {
 		icon, _, _ := w32.User32LoadImageW.Call(
 			uintptr(hinstance),
-			uintptr(iconId),
-			1,
-			0,
+			uintptr(s.id),
+			1, // IMAGE_ICON
+			uintptr(size),
+			uintptr(size),
 			0,
-			w32.LR_DEFAULTSIZE|w32.LR_SHARED,
 		)
 		if icon != 0 {
-			return icon
+			return icon, false
 		}
 	}
 
-	// 4. 最后使用默认标
-	icow, _, _ := w32.User32GetSystemMetrics.Call(w32.SystemMetricsCxIcon)
-	icoh, _, _ := w32.User32GetSystemMetrics.Call(w32.SystemMetricsCyIcon)
-	icon, _, _ := w32.User32LoadImageW.Call(
+	// 4. 最后使用默认图标
+	icon, _, _ = w32.User32LoadImageW.Call(
 		0,
 		32512, // IDI_APPLICATION
 		1,     // IMAGE_ICON
-		icow,
-		icoh,
+		uintptr(size),
+		uintptr(size),
 		w32.LR_SHARED,
 	)
+	return icon, true
+}
+
+func (w *webview) loadWindowIcon(hinstance windows.Handle, iconId uint, opts WindowOptions) uintptr {
+	w.icon = iconSource{id: iconId, path: opts.IconPath, data: opts.IconData}
+	icon, _ := w.icon.load(hinstance, 0)
 	return icon
 }
 
@@ -855,132 +1202,59 @@ func (w *webview) WithContext(ctx context.Context) WebView {
 	return w
 }
 
-func (w *webview) RegisterHotKey(modifiers int, keyCode int, handler HotKeyHandler) error {
-	w.m.Lock()
-	defer w.m.Unlock()
-
-	// 化键映
-	if w.hotkeys == nil {
-		w.hotkeys = make(map[int]HotKeyHandler)
-	}
-
-	// 生成唯一的热键ID
-	hotkeyID := len(w.hotkeys) + 1
-
-	// 注册热键
-	ret, _, err := w32.User32RegisterHotKey.Call(
-		w.hwnd,
-		uintptr(hotkeyID),
-		uintptr(modifiers),
-		uintptr(keyCode),
-	)
-
-	if ret == 0 {
-		return fmt.Errorf("failed to register hotkey: %v", err)
-	}
-
-	// 保存处理函数
-	w.hotkeys[hotkeyID] = handler
-	return nil
-}
-
-func (w *webview) UnregisterHotKey(modifiers int, keyCode int) {
-	w.m.Lock()
-	defer w.m.Unlock()
-
-	// 查找对应的热键ID
-	var hotkeyID int
-	for id := range w.hotkeys {
-		// 里简化处理，实际应该保modifiers和keyCode来确保全配
-		hotkeyID = id
-		break
-	}
-
-	if hotkeyID > 0 {
-		_, _, _ = w32.User32UnregisterHotKey.Call(w.hwnd, uintptr(hotkeyID))
-		delete(w.hotkeys, hotkeyID)
-	}
-}
-
-// RegisterHotKeyString 通过字符串注册热键
-// 例如: "Ctrl+Alt+Q"
-func (w *webview) RegisterHotKeyString(hotkey string, handler HotKeyHandler) error {
-	hk, err := ParseHotKey(hotkey)
-	if err != nil {
-		return fmt.Errorf("failed to parse hotkey: %v", err)
-	}
-	return w.RegisterHotKey(hk.Modifiers, hk.KeyCode, handler)
-}
-
-// SetFullscreen 设置窗口全屏状态
+// SetFullscreen 设置窗口全屏状态。全屏时窗口铺满其当前所在的显示器，
+// 退出全屏时恢复进入全屏前的位置、大小和样式
 func (w *webview) SetFullscreen(enable bool) {
-	if enable {
-		var rect w32.Rect
-		_, _, _ = w32.User32GetWindowRect.Call(w.hwnd, uintptr(unsafe.Pointer(&rect)))
-
-		screenWidth, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CXSCREEN)
-		screenHeight, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CYSCREEN)
-
-		style, _, _ := w32.User32GetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF))
-		styleUint32 := uint32(style)
-		styleUint32 &^= w32.WSOverlappedWindow
-		style = uintptr(styleUint32)
-		_, _, _ = w32.User32SetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF), style)
-
-		_, _, _ = w32.User32SetWindowPos.Call(
-			w.hwnd,
-			uintptr(w32.HWND_TOP),
-			0,
-			0,
-			screenWidth,
-			screenHeight,
-			w32.SWP_FRAMECHANGED,
-		)
-	} else {
-		style, _, _ := w32.User32GetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF))
-		styleUint32 := uint32(style)
-
-		// 检查是否为无边框窗口
-		isFrameless := (styleUint32 & w32.WSPopup) != 0
-
-		if !isFrameless {
-			styleUint32 |= w32.WSOverlappedWindow
+	changed := false
+	w.runOnMain(func() {
+		if enable == w.fullscreen {
+			return
+		}
+		changed = true
+		if enable {
+			w.fsPlacement = w32.WindowPlacement{}
+			w.fsPlacement.Length = uint32(unsafe.Sizeof(w.fsPlacement))
+			_, _, _ = w32.User32GetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&w.fsPlacement)))
+			w.fsStyle, _, _ = w32.User32GetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF))
+			exIndex := w32.GWL_EXSTYLE
+			w.fsExStyle, _, _ = w32.User32GetWindowLongPtrW.Call(w.hwnd, uintptr(exIndex))
+			w.fullscreen = true
+
+			style := uint32(w.fsStyle) &^ (w32.WSOverlappedWindow | w32.WSPopup)
+			_, _, _ = w32.User32SetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF), uintptr(style|w32.WSPopup))
+			w.fitToMonitor(w.windowMonitor())
 		} else {
-			styleUint32 = w32.WSPopup | w32.WSVisible
+			w.fullscreen = false
+			_, _, _ = w32.User32SetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF), w.fsStyle)
+			exIndex := w32.GWL_EXSTYLE
+			_, _, _ = w32.User32SetWindowLongPtrW.Call(w.hwnd, uintptr(exIndex), w.fsExStyle)
+
+			// 先以还原状态应用保存的位置，再让系统按新样式重新计算边框
+			placement := w.fsPlacement
+			if placement.ShowCmd == w32.SW_SHOWMINIMIZED {
+				placement.ShowCmd = w32.SW_SHOWNORMAL
+			}
+			w.ignoreDPIRect = true
+			_, _, _ = w32.User32SetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement)))
+			_, _, _ = w32.User32SetWindowPos.Call(
+				w.hwnd,
+				0,
+				0, 0, 0, 0,
+				w32.SWP_NOMOVE|w32.SWP_NOSIZE|w32.SWP_NOZORDER|w32.SWP_NOACTIVATE|w32.SWP_FRAMECHANGED,
+			)
+			w.ignoreDPIRect = false
+			w.browser.Resize()
 		}
-
-		style = uintptr(styleUint32)
-		_, _, _ = w32.User32SetWindowLongPtrW.Call(w.hwnd, uintptr(w32.GWLStyle&0xFFFFFFFF), style)
-
-		// 获取屏幕尺寸
-		screenWidth, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CXSCREEN)
-		screenHeight, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CYSCREEN)
-
-		// 设置默认窗口大小
-		width := uintptr(1024)
-		height := uintptr(768)
-
-		// 计算居中置
-		x := (screenWidth - width) / 2
-		y := (screenHeight - height) / 2
-
-		// 恢复窗口
-		_, _, _ = w32.User32SetWindowPos.Call(
-			w.hwnd,
-			uintptr(w32.HWND_TOP),
-			x,
-			y,
-			width,
-			height,
-			w32.SWP_FRAMECHANGED,
-		)
+	})
+	if !changed {
+		return
 	}
-	w.browser.Resize()
 
-	// 触发屏状态改变回调
+	// 触发全屏状态改变回调
 	if w.onFullscreenChanged != nil {
 		w.onFullscreenChanged(enable)
 	}
+	w.notifyRemote(remote.EventFullscreenChanged, enable)
 }
 
 // SetAlwaysOnTop 设置窗口置顶状
@@ -1018,19 +1292,40 @@ func (w *webview) Restore() {
 	})
 }
 
-// 居中窗口
+// 显示窗口并将其置于前台，窗口最小化时会被还原
+func (w *webview) Show() {
+	w.Dispatch(func() {
+		if w32.IsIconic(w.hwnd) {
+			_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_RESTORE)
+		} else {
+			_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_SHOW)
+		}
+		_, _, _ = w32.User32SetForegroundWindow.Call(w.hwnd)
+	})
+}
+
+// 隐藏窗口，可通过 Show 或托盘图标重新显示
+func (w *webview) Hide() {
+	w.Dispatch(func() {
+		_, _, _ = w32.User32ShowWindow.Call(w.hwnd, w32.SW_HIDE)
+	})
+}
+
+// 居中窗口 (在窗口当前所在显示器的工作区内)
 func (w *webview) Center() {
 	w.Dispatch(func() {
 		var rect w32.Rect
 		_, _, _ = w32.User32GetWindowRect.Call(w.hwnd, uintptr(unsafe.Pointer(&rect)))
-		width := int32(rect.Right - rect.Left)
-		height := int32(rect.Bottom - rect.Top)
-
-		screenWidth, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CXSCREEN)
-		screenHeight, _, _ := w32.User32GetSystemMetrics.Call(w32.SM_CYSCREEN)
+		width := rect.Right - rect.Left
+		height := rect.Bottom - rect.Top
 
-		x := int32(screenWidth-uintptr(width)) / 2
-		y := int32(screenHeight-uintptr(height)) / 2
+		info, ok := w32.GetMonitorInfo(w.windowMonitor())
+		if !ok {
+			return
+		}
+		work := info.RcWork
+		x := work.Left + (work.Right-work.Left-width)/2
+		y := work.Top + (work.Bottom-work.Top-height)/2
 
 		_, _, _ = w32.User32SetWindowPos.Call(
 			w.hwnd,
@@ -1072,19 +1367,19 @@ func (w *webview) SetOpacity(opacity float64) {
 
 // 浏览器相关能
 func (w *webview) Reload() {
-	w.Eval("window.location.reload();")
+	w.evalInternal("window.location.reload();")
 }
 
 func (w *webview) Back() {
-	w.Eval("window.history.back();")
+	w.evalInternal("window.history.back();")
 }
 
 func (w *webview) Forward() {
-	w.Eval("window.history.forward();")
+	w.evalInternal("window.history.forward();")
 }
 
 func (w *webview) Stop() {
-	w.Eval("window.stop();")
+	w.evalInternal("window.stop();")
 }
 
 // 开发者工具
@@ -1097,7 +1392,7 @@ func (w *webview) OpenDevTools() {
 
 func (w *webview) CloseDevTools() {
 	// 通过 JavaScript 关闭开发者工具
-	w.Eval(`if(window.devtools && window.devtools.isOpen()) window.devtools.close();`)
+	w.evalInternal(`if(window.devtools && window.devtools.isOpen()) window.devtools.close();`)
 }
 
 func (w *webview) OnLoadingStateChanged(callback func(bool)) {
@@ -1119,7 +1414,7 @@ func (w *webview) OnFullscreenChanged(callback func(bool)) {
 // ClearCache 清除浏览器缓存
 func (w *webview) ClearCache() {
 	// 通过 JavaScript 清除缓存
-	w.Eval(`
+	w.evalInternal(`
 		if (window.caches) {
 			caches.keys().then(function(keyList) {
 				return Promise.all(keyList.map(function(key) {
@@ -1135,7 +1430,7 @@ func (w *webview) ClearCache() {
 // ClearCookies 清除浏览器 cookies
 func (w *webview) ClearCookies() {
 	// 通过 JavaScript 清除 cookies
-	w.Eval(`
+	w.evalInternal(`
 		document.cookie.split(";").forEach(function(c) { 
 			document.cookie = c.replace(/^ +/, "")
 				.replace(/=.*/, "=;expires=" + new Date().toUTCString() + ";path=/"); 
@@ -1154,11 +1449,6 @@ func (w *webview) DispatchAsync(f func()) {
 	}()
 }
 
-// Print 直接使用默认打印机打印前页面
-func (w *webview) Print() {
-	w.Eval(`window.print()`)
-}
-
 // PrintToPDF 将当前页面打印为 PDF 文件
 func (w *webview) PrintToPDF(path string) {
 	// 使用 WebView2 的 PrintToPdf 方法
@@ -1169,9 +1459,11 @@ func (w *webview) PrintToPDF(path string) {
 	}
 }
 
-// ShowPrintDialog 显示打印对话框
+// ShowPrintDialog 显示浏览器打印对话框
 func (w *webview) ShowPrintDialog() {
-	w.Eval(`window.print()`)
+	if err := w.ShowPrintUI(PrintDialogBrowser); err != nil {
+		log.Printf("Failed to show print dialog: %v", err)
+	}
 }
 
 // DisableContextMenu 禁用右键菜单
@@ -1185,7 +1477,7 @@ func (w *webview) DisableContextMenu() error {
 // EnableContextMenu 启用右键菜单
 func (w *webview) EnableContextMenu() error {
 	// 移除 JavaScript 的右键菜单禁用
-	w.Eval(`
+	w.evalInternal(`
 		document.removeEventListener('contextmenu', function(e) {
 			e.preventDefault();
 			return false;
@@ -1218,8 +1510,7 @@ func (w *webview) RunAsync() {
 
 			// 处理热键消息
 			if msg.Message == w32.WMHotKey {
-				if handler, ok := w.hotkeys[int(msg.WParam)]; ok && handler != nil {
-					handler()
+				if w.globalHotKey(int(msg.WParam)) {
 					continue
 				}
 			}
@@ -1266,184 +1557,6 @@ func (w *webview) SetMinimized(enable bool) {
 	})
 }
 
-// AddJSHook 添加 JavaScript 钩子
-func (w *webview) AddJSHook(hook JSHook) {
-	w.m.Lock()
-	defer w.m.Unlock()
-
-	// 按优先级插入
-	inserted := false
-	for i, h := range w.jsHooks {
-		if hook.Priority() < h.Priority() {
-			// 在此位置插入
-			w.jsHooks = append(w.jsHooks[:i], append([]JSHook{hook}, w.jsHooks[i:]...)...)
-			inserted = true
-			break
-		}
-	}
-	if !inserted {
-		w.jsHooks = append(w.jsHooks, hook)
-	}
-}
-
-// RemoveJSHook 移除 JavaScript 钩子
-func (w *webview) RemoveJSHook(hook JSHook) {
-	w.m.Lock()
-	defer w.m.Unlock()
-
-	for i, h := range w.jsHooks {
-		if h == hook {
-			w.jsHooks = append(w.jsHooks[:i], w.jsHooks[i+1:]...)
-			break
-		}
-	}
-}
-
-// ClearJSHooks 清除所有 JavaScript 钩子
-func (w *webview) ClearJSHooks() {
-	w.m.Lock()
-	defer w.m.Unlock()
-	w.jsHooks = nil
-}
-
-// processScript 处理脚本，用所有钩子
-func (w *webview) processScript(script string, hookType JSHookType) string {
-	w.m.Lock()
-	defer w.m.Unlock()
-
-	result := script
-	for _, hook := range w.jsHooks {
-		if hook.Type() == hookType {
-			result = hook.Handle(result)
-		}
-	}
-	return result
-}
-
-// EnableWebSocket 启用 WebSocket 服务
-func (w *webview) EnableWebSocket(port int) error {
-	w.wsUpgrader = websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			return true // 允许所有来源
-		},
-	}
-
-	// 创建 WebSocket 处理器
-	http.HandleFunc("/ws", func(writer http.ResponseWriter, r *http.Request) {
-		conn, err := w.wsUpgrader.Upgrade(writer, r, nil)
-		if err != nil {
-			log.Printf("WebSocket upgrade failed: %v", err)
-			return
-		}
-
-		// 保存连接
-		connID := fmt.Sprintf("%p", conn)
-		w.wsConnections.Store(connID, conn)
-
-		// 创建并添加 WebSocket Hook
-		wsHook := NewWebSocketHook(conn)
-		w.AddJSHook(wsHook)
-
-		// 处理消息
-		go func() {
-			defer func() {
-				conn.Close()
-				w.wsConnections.Delete(connID)
-				w.RemoveJSHook(wsHook)
-			}()
-
-			for {
-				_, message, err := conn.ReadMessage()
-				if err != nil {
-					if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-						log.Printf("WebSocket read error: %v", err)
-					}
-					return
-				}
-
-				if w.wsHandler != nil {
-					w.wsHandler(string(message))
-				}
-			}
-		}()
-	})
-
-	// 启动 WebSocket 服务器
-	w.wsServer = &http.Server{
-		Addr: fmt.Sprintf(":%d", port),
-	}
-
-	go func() {
-		if err := w.wsServer.ListenAndServe(); err != http.ErrServerClosed {
-			log.Printf("WebSocket server error: %v", err)
-		}
-	}()
-
-	// 注入 WebSocket 客户端代码
-	w.Eval(`
-		if (!window._webSocket) {
-			window._webSocket = new WebSocket('ws://localhost:` + fmt.Sprint(port) + `/ws');
-			window._webSocket.onmessage = function(event) {
-				try {
-					const data = JSON.parse(event.data);
-					if (data.type === 'eval') {
-						eval(data.script);
-					}
-				} catch(e) {
-					console.error('WebSocket message error:', e);
-				}
-			};
-		}
-	`)
-
-	return nil
-}
-
-// DisableWebSocket 禁用 WebSocket 服务
-func (w *webview) DisableWebSocket() {
-	if w.wsServer != nil {
-		// 关闭所有连接
-		w.wsConnections.Range(func(key, value interface{}) bool {
-			if conn, ok := value.(*websocket.Conn); ok {
-				conn.Close()
-			}
-			return true
-		})
-
-		// 关闭服务器
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		w.wsServer.Shutdown(ctx)
-		w.wsServer = nil
-	}
-
-	// 清理客户端 WebSocket
-	w.Eval(`
-		if (window._webSocket) {
-			window._webSocket.close();
-			window._webSocket = null;
-		}
-	`)
-}
-
-// OnWebSocketMessage 设置 WebSocket 消息处理器
-func (w *webview) OnWebSocketMessage(handler WebSocketHandler) {
-	w.wsHandler = handler
-}
-
-// SendWebSocketMessage 发送 WebSocket 消息
-func (w *webview) SendWebSocketMessage(message string) {
-	w.wsConnections.Range(func(key, value interface{}) bool {
-		if conn, ok := value.(*websocket.Conn); ok {
-			err := conn.WriteMessage(websocket.TextMessage, []byte(message))
-			if err != nil {
-				log.Printf("WebSocket write error: %v", err)
-			}
-		}
-		return true
-	})
-}
-
 func (w *webview) OnNavigationStarting(handler func()) {
 	if w.browser != nil {
 		if chromium, ok := w.browser.(*edge.Chromium); ok {
@@ -0,0 +1,281 @@
+//go:build windows
+// +build windows
+
+package webviewloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// BootstrapperURL 是微软提供的 Evergreen Bootstrapper 下载地址
+const BootstrapperURL = "https://go.microsoft.com/fwlink/p/?LinkId=2124703"
+
+var (
+	// ErrRuntimeNotInstalled 表示重试后仍未检测到满足要求的 WebView2 运行时
+	ErrRuntimeNotInstalled = errors.New("webview2 runtime is not installed")
+	// ErrNoInstaller 表示运行时缺失但没有提供任何安装程序
+	ErrNoInstaller = errors.New("no webview2 runtime installer available")
+)
+
+// InstallStage 安装流程所处的阶段
+type InstallStage int
+
+const (
+	StageChecking    InstallStage = iota // 检查已安装的版本
+	StageDownloading                     // 下载 Bootstrapper
+	StageInstalling                      // 运行安装程序
+	StageRetrying                        // 安装失败，等待重试
+	StageDone                            // 已满足要求
+)
+
+func (s InstallStage) String() string {
+	switch s {
+	case StageChecking:
+		return "checking"
+	case StageDownloading:
+		return "downloading"
+	case StageInstalling:
+		return "installing"
+	case StageRetrying:
+		return "retrying"
+	case StageDone:
+		return "done"
+	}
+	return fmt.Sprintf("InstallStage(%d)", int(s))
+}
+
+// InstallProgress 描述安装进度
+type InstallProgress struct {
+	Stage   InstallStage
+	Attempt int    // 当前是第几次安装尝试，从 1 开始；检查阶段为 0
+	Percent int    // 下载进度 (0-100)，未知时为 -1
+	Version string // 已安装的版本，未安装时为空
+	Err     error  // 上一次尝试失败的原因 (StageRetrying)
+}
+
+// InstallOptions 配置 EnsureRuntime。
+// 安装程序的优先级: InstallerPath > BootstrapperPath > Bootstrapper > DownloadURL
+type InstallOptions struct {
+	// MinVersion 要求的最低版本，如 "110.0.1587.41"，也可以是版本约束，
+	// 如 ">=110, <130"，为空时只要求已安装
+	MinVersion string
+
+	// InstallerPath 离线安装包 (MicrosoftEdgeWebView2RuntimeInstaller*.exe) 的路径
+	InstallerPath string
+	// BootstrapperPath Evergreen Bootstrapper (MicrosoftEdgeWebview2Setup.exe) 的路径
+	BootstrapperPath string
+	// Bootstrapper 嵌入程序中的 Evergreen Bootstrapper，运行前写入临时目录
+	Bootstrapper []byte
+	// DownloadURL 以上都未提供时从该地址下载 Bootstrapper，如 BootstrapperURL
+	DownloadURL string
+
+	// Silent 为 true 时静默安装，否则显示安装程序界面
+	Silent bool
+	// Retries 安装失败后的重试次数
+	Retries int
+	// RetryDelay 两次尝试之间的等待时间，默认 3 秒
+	RetryDelay time.Duration
+
+	// Progress 报告安装进度，在调用 EnsureRuntime 的 goroutine 中调用
+	Progress func(p InstallProgress)
+}
+
+// EnsureRuntime 检查 WebView2 运行时是否已安装且不低于 MinVersion，
+// 不满足时运行安装程序并重新检查，返回安装后的版本。
+func EnsureRuntime(ctx context.Context, opts InstallOptions) (string, error) {
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 3 * time.Second
+	}
+	var constraint *Constraint
+	if opts.MinVersion != "" {
+		c, err := ParseConstraint(opts.MinVersion)
+		if err != nil {
+			return "", err
+		}
+		constraint = &c
+	}
+	report := func(p InstallProgress) {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		report(InstallProgress{Stage: StageChecking, Attempt: attempt, Percent: -1})
+		version, ok, err := checkRuntime(constraint)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			report(InstallProgress{Stage: StageDone, Attempt: attempt, Percent: 100, Version: version})
+			return version, nil
+		}
+		if attempt > opts.Retries {
+			if lastErr != nil {
+				return version, fmt.Errorf("%w: %v", ErrRuntimeNotInstalled, lastErr)
+			}
+			return version, ErrRuntimeNotInstalled
+		}
+		if attempt > 0 {
+			report(InstallProgress{Stage: StageRetrying, Attempt: attempt, Percent: -1, Version: version, Err: lastErr})
+			select {
+			case <-ctx.Done():
+				return version, ctx.Err()
+			case <-time.After(opts.RetryDelay):
+			}
+		}
+
+		lastErr = installRuntime(ctx, opts, attempt+1, report)
+		if ctx.Err() != nil {
+			return version, ctx.Err()
+		}
+		if errors.Is(lastErr, ErrNoInstaller) {
+			return version, lastErr
+		}
+	}
+}
+
+// checkRuntime 返回已安装的版本以及是否满足版本约束
+func checkRuntime(constraint *Constraint) (string, bool, error) {
+	version, err := GetInstalledVersion()
+	if err != nil {
+		return "", false, err
+	}
+	if version == "" {
+		return "", false, nil
+	}
+	if constraint == nil {
+		return version, true, nil
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return version, false, err
+	}
+	return version, constraint.Check(v), nil
+}
+
+// installRuntime 准备安装程序并运行一次
+func installRuntime(ctx context.Context, opts InstallOptions, attempt int, report func(InstallProgress)) error {
+	path, cleanup, err := prepareInstaller(ctx, opts, attempt, report)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	report(InstallProgress{Stage: StageInstalling, Attempt: attempt, Percent: -1})
+	args := []string{"/install"}
+	if opts.Silent {
+		args = []string{"/silent", "/install"}
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("installer exited with code 0x%08X", uint32(exitErr.ExitCode()))
+		}
+		return fmt.Errorf("failed to run installer: %w", err)
+	}
+	return nil
+}
+
+// prepareInstaller 返回安装程序的路径，以及用于删除临时文件的清理函数
+func prepareInstaller(ctx context.Context, opts InstallOptions, attempt int, report func(InstallProgress)) (string, func(), error) {
+	noop := func() {}
+	switch {
+	case opts.InstallerPath != "":
+		return opts.InstallerPath, noop, nil
+	case opts.BootstrapperPath != "":
+		return opts.BootstrapperPath, noop, nil
+	case len(opts.Bootstrapper) > 0:
+		return writeTempInstaller(func(f *os.File) error {
+			_, err := f.Write(opts.Bootstrapper)
+			return err
+		})
+	case opts.DownloadURL != "":
+		report(InstallProgress{Stage: StageDownloading, Attempt: attempt, Percent: -1})
+		return writeTempInstaller(func(f *os.File) error {
+			return download(ctx, opts.DownloadURL, f, func(percent int) {
+				report(InstallProgress{Stage: StageDownloading, Attempt: attempt, Percent: percent})
+			})
+		})
+	}
+	return "", noop, ErrNoInstaller
+}
+
+// writeTempInstaller 将安装程序写入临时目录，文件名须以 .exe 结尾才能运行
+func writeTempInstaller(write func(f *os.File) error) (string, func(), error) {
+	dir, err := ioutil.TempDir("", "webview2-setup")
+	if err != nil {
+		return "", func() {}, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	path := filepath.Join(dir, "MicrosoftEdgeWebview2Setup.exe")
+	f, err := os.Create(path)
+	if err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+	return path, cleanup, nil
+}
+
+// download 下载 url 到 w，并在已知长度时报告进度
+func download(ctx context.Context, url string, w io.Writer, progress func(percent int)) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to download installer: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download installer: %s", resp.Status)
+	}
+
+	total := resp.ContentLength
+	var written int64
+	last := -1
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			written += int64(n)
+			percent := -1
+			if total > 0 {
+				percent = int(written * 100 / total)
+			}
+			if percent != last {
+				last = percent
+				progress(percent)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to download installer: %w", err)
+		}
+	}
+}
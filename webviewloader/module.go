@@ -31,6 +31,9 @@ var (
 //	-1 = v1 < v2
 //	 0 = v1 == v2
 //	 1 = v1 > v2
+//
+// 该函数需要加载 WebView2Loader.dll，一般应使用可在任意平台运行的
+// Version.Compare，此函数仅用于与官方实现交叉验证。
 func CompareBrowserVersions(v1 string, v2 string) (int, error) {
 
 	_v1, err := windows.UTF16PtrFromString(v1)
@@ -0,0 +1,241 @@
+package webviewloader
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Channel 是 WebView2 运行时的发布渠道
+type Channel int
+
+const (
+	ChannelStable Channel = iota // 稳定版，版本号没有后缀
+	ChannelBeta                  // Beta
+	ChannelDev                   // Dev
+	ChannelCanary                // Canary
+)
+
+func (c Channel) String() string {
+	switch c {
+	case ChannelStable:
+		return "stable"
+	case ChannelBeta:
+		return "beta"
+	case ChannelDev:
+		return "dev"
+	case ChannelCanary:
+		return "canary"
+	}
+	return fmt.Sprintf("Channel(%d)", int(c))
+}
+
+// ErrInvalidVersion 表示无法解析的版本号
+var ErrInvalidVersion = errors.New("invalid browser version")
+
+// Version 是 WebView2 运行时的版本号，如 "120.0.2210.91 beta"。
+// 与 CompareBrowserVersions 一样，比较时只看数字部分，不考虑渠道。
+type Version struct {
+	Major   int
+	Minor   int
+	Build   int
+	Patch   int
+	Channel Channel
+
+	// parts 是解析时给出的数字段数 (1-4)，约束中的部分版本号只比较这些段
+	parts int
+}
+
+// ParseVersion 解析 "major[.minor[.build[.patch]]][ channel]" 格式的版本号，
+// 缺少的段视为 0，渠道不区分大小写
+func ParseVersion(s string) (Version, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	v := Version{}
+	if len(fields) == 2 {
+		channel, ok := parseChannel(fields[1])
+		if !ok {
+			return Version{}, fmt.Errorf("%w: unknown channel %q", ErrInvalidVersion, fields[1])
+		}
+		v.Channel = channel
+	}
+
+	parts := strings.Split(fields[0], ".")
+	if len(parts) > 4 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	nums := [4]int{}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Build, v.Patch = nums[0], nums[1], nums[2], nums[3]
+	v.parts = len(parts)
+	return v, nil
+}
+
+// parseChannel 解析渠道名称，不区分大小写
+func parseChannel(name string) (Channel, bool) {
+	switch strings.ToLower(name) {
+	case "stable":
+		return ChannelStable, true
+	case "beta":
+		return ChannelBeta, true
+	case "dev":
+		return ChannelDev, true
+	case "canary":
+		return ChannelCanary, true
+	}
+	return 0, false
+}
+
+// MustParseVersion 与 ParseVersion 相同，解析失败时 panic，用于常量
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Version) numbers() [4]int {
+	return [4]int{v.Major, v.Minor, v.Build, v.Patch}
+}
+
+// Compare 比较两个版本，返回 -1、0 或 1
+func (v Version) Compare(other Version) int {
+	return compareNumbers(v.numbers(), other.numbers(), 4)
+}
+
+// Less 报告 v 是否低于 other
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+func compareNumbers(a, b [4]int, n int) int {
+	for i := 0; i < n; i++ {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// String 返回 "major.minor.build.patch"，非稳定版带渠道后缀
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Build, v.Patch)
+	if v.Channel != ChannelStable {
+		s += " " + v.Channel.String()
+	}
+	return s
+}
+
+// Satisfies 报告 v 是否满足约束，如 ">=110"
+func (v Version) Satisfies(constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// constraintTerm 是单个比较条件，如 ">=110.0"
+type constraintTerm struct {
+	op      string
+	version Version
+}
+
+func (t constraintTerm) check(v Version) bool {
+	// 只比较约束中写出的段，因此 "=110" 匹配所有 110.x.x.x
+	n := t.version.parts
+	if n == 0 {
+		n = 4
+	}
+	c := compareNumbers(v.numbers(), t.version.numbers(), n)
+	switch t.op {
+	case "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// Constraint 是一组必须同时满足的版本条件，如 ">=110, <125"
+type Constraint struct {
+	terms []constraintTerm
+	raw   string
+}
+
+// ParseConstraint 解析以逗号或空格分隔的条件，支持 = == != > >= < <=，
+// 省略运算符时等同于 >=。版本号后可以带渠道，如 ">=120.0 beta"，与 Compare 一样渠道不影响结果
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(s)}
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		op := ""
+		for _, candidate := range []string{">=", "<=", "==", "!=", ">", "<", "="} {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				break
+			}
+		}
+		rest := strings.TrimSpace(field[len(op):])
+		// 允许运算符和版本号之间有空格，如 ">= 110"
+		if rest == "" && op != "" && i+1 < len(fields) {
+			i++
+			rest = fields[i]
+		}
+		if op == "" {
+			op = ">="
+		}
+		if i+1 < len(fields) {
+			if _, ok := parseChannel(fields[i+1]); ok {
+				i++
+				rest += " " + fields[i]
+			}
+		}
+		v, err := ParseVersion(rest)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		c.terms = append(c.terms, constraintTerm{op: op, version: v})
+	}
+	if len(c.terms) == 0 {
+		return Constraint{}, fmt.Errorf("invalid version constraint %q", s)
+	}
+	return c, nil
+}
+
+// Check 报告版本是否满足所有条件
+func (c Constraint) Check(v Version) bool {
+	for _, t := range c.terms {
+		if !t.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Constraint) String() string {
+	return c.raw
+}
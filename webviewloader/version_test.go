@@ -0,0 +1,144 @@
+package webviewloader
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"120", Version{Major: 120, parts: 1}},
+		{"120.0.2210.91", Version{Major: 120, Build: 2210, Patch: 91, parts: 4}},
+		{"121.0.2277.4 beta", Version{Major: 121, Build: 2277, Patch: 4, Channel: ChannelBeta, parts: 4}},
+		{"122.0.2300 Dev", Version{Major: 122, Build: 2300, Channel: ChannelDev, parts: 3}},
+		{" 123.0.2320.0 canary ", Version{Major: 123, Build: 2320, Channel: ChannelCanary, parts: 4}},
+		{"120.0.2210.91 stable", Version{Major: 120, Build: 2210, Patch: 91, parts: 4}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	for _, in := range []string{"", "   ", "120.x", "^120", "1.2.3.4.5", "-1", "120 nightly", "120 beta extra", "120..1"} {
+		if _, err := ParseVersion(in); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("ParseVersion(%q) error = %v, want ErrInvalidVersion", in, err)
+		}
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"120", "120.0.0.0"},
+		{"120.0.2210.91", "120.0.2210.91"},
+		{"121.0.2277.4 BETA", "121.0.2277.4 beta"},
+		{"123.0.2320.0 canary", "123.0.2320.0 canary"},
+	}
+	for _, tt := range tests {
+		if got := MustParseVersion(tt.in).String(); got != tt.want {
+			t.Errorf("MustParseVersion(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"120.0.2210.91", "120.0.2210.91", 0},
+		{"120.0.2210.91", "120.0.2210.100", -1},
+		{"121.0.0.0", "120.9.9999.9999", 1},
+		{"120", "120.0.0.0", 0},
+		// 比较时不考虑渠道
+		{"120.0.2210.91 beta", "120.0.2210.91", 0},
+	}
+	for _, tt := range tests {
+		a, b := MustParseVersion(tt.a), MustParseVersion(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := a.Less(b); got != (tt.want < 0) {
+			t.Errorf("Less(%q, %q) = %t", tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// 省略运算符时等同于 >=
+		{"120", "120.0.0.0", true},
+		{"120", "130.0.5", true},
+		{"120", "119.9.9999.9", false},
+
+		// 部分版本号只比较写出的段
+		{"<130", "130.0.5", false},
+		{"<130", "129.9.9999.9", true},
+		{"<=130", "130.0.5", true},
+		{"=120", "120.0.2210.91", true},
+		{"==120.0", "120.1.0.0", false},
+		{"!=120", "120.5.0.0", false},
+		{"!=120", "121.0.0.0", true},
+		{">120", "120.0.2210.91", false},
+		{">120.0.2210", "120.0.2210.91", false},
+		{">120.0.2210.90", "120.0.2210.91", true},
+
+		// 多个条件必须同时满足，可以用逗号或空格分隔，运算符后可以有空格
+		{">=110, <125", "120.0.2210.91", true},
+		{">=110, <125", "125.0.0.0", false},
+		{">=110 <125", "109.0.1518.78", false},
+		{">= 110, < 125, != 120", "120.0.2210.91", false},
+		{">= 110, < 125, != 120", "121.0.2277.4", true},
+
+		// 约束不考虑渠道
+		{">=120", "121.0.2277.4 beta", true},
+		{"<122", "121.0.2277.4 canary", true},
+		{">=120.0 beta", "120.0.2210.91", true},
+		{">=120.0 Beta, <125 canary", "121.0.2277.4 dev", true},
+		{">= 120 dev <121", "121.0.0.0", false},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) error: %v", tt.constraint, err)
+			continue
+		}
+		if got := c.Check(MustParseVersion(tt.version)); got != tt.want {
+			t.Errorf("%q.Check(%q) = %t, want %t", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, in := range []string{"", " , ", "^120", "~120", "120.x", ">=", ">=110, <", ">=abc", "=>120", "beta", ">=120 nightly", ">=120 beta beta"} {
+		if _, err := ParseConstraint(in); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	v := MustParseVersion("120.0.2210.91")
+	if ok, err := v.Satisfies(">=110, <125"); err != nil || !ok {
+		t.Errorf("Satisfies(>=110, <125) = %t, %v", ok, err)
+	}
+	if _, err := v.Satisfies("^120"); err == nil {
+		t.Error("Satisfies(^120) succeeded, want error")
+	}
+}
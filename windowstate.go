@@ -0,0 +1,208 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"golang.org/x/sys/windows"
+)
+
+// defaultStateKey 是未指定 StateKey 时使用的键
+const defaultStateKey = "main"
+
+// WindowState 描述窗口的位置和状态，用于在多次启动之间保存和恢复
+type WindowState struct {
+	X         int32  `json:"x"`         // 还原状态下窗口左边的屏幕坐标
+	Y         int32  `json:"y"`         // 还原状态下窗口上边的屏幕坐标
+	Width     int32  `json:"width"`     // 还原状态下的窗口宽度
+	Height    int32  `json:"height"`    // 还原状态下的窗口高度
+	Maximized bool   `json:"maximized"` // 是否最大化
+	Minimized bool   `json:"minimized"` // 是否最小化
+	Monitor   string `json:"monitor"`   // 所在显示器的设备名，如 \\.\DISPLAY1
+	DPI       uint32 `json:"dpi"`       // 保存时所在显示器的 DPI
+}
+
+// loadWindowState 从状态文件中读取指定键的窗口状态
+func loadWindowState(file, key string) (WindowState, bool) {
+	states := map[string]WindowState{}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: Failed to read window state %s: %v", file, err)
+		}
+		return WindowState{}, false
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		log.Printf("Warning: Failed to parse window state %s: %v", file, err)
+		return WindowState{}, false
+	}
+	state, ok := states[key]
+	if !ok || state.Width <= 0 || state.Height <= 0 {
+		return WindowState{}, false
+	}
+	return state, true
+}
+
+// saveWindowState 将窗口状态写入状态文件，保留文件中其它键的状态
+func saveWindowState(file, key string, state WindowState) error {
+	states := map[string]WindowState{}
+	if data, err := ioutil.ReadFile(file); err == nil {
+		_ = json.Unmarshal(data, &states)
+	}
+	states[key] = state
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// monitorWorkspaceOffset 返回 monitor 上工作区坐标 (WINDOWPLACEMENT 使用) 相对屏幕坐标的偏移。
+// 工作区坐标以窗口所在显示器的工作区为原点，偏移是工作区与显示器左上角的距离，
+// 任务栏在左侧或顶部时不为 0
+func monitorWorkspaceOffset(monitor uintptr) (int32, int32) {
+	info, ok := w32.GetMonitorInfo(monitor)
+	if !ok {
+		return 0, 0
+	}
+	return info.RcWork.Left - info.RcMonitor.Left, info.RcWork.Top - info.RcMonitor.Top
+}
+
+// rectWorkspaceOffset 返回屏幕矩形所在显示器的工作区坐标偏移
+func rectWorkspaceOffset(rect w32.Rect) (int32, int32) {
+	monitor, _, _ := w32.User32MonitorFromRect.Call(uintptr(unsafe.Pointer(&rect)), w32.MONITOR_DEFAULTTONEAREST)
+	return monitorWorkspaceOffset(monitor)
+}
+
+// captureWindowState 读取窗口当前的位置和状态
+func (w *webview) captureWindowState() (WindowState, bool) {
+	placement := w32.WindowPlacement{}
+	placement.Length = uint32(unsafe.Sizeof(placement))
+	if w.fullscreen {
+		// 全屏时保存进入全屏前的位置
+		placement = w.fsPlacement
+	} else if r, _, _ := w32.User32GetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement))); r == 0 {
+		return WindowState{}, false
+	}
+
+	monitor, _, _ := w32.User32MonitorFromWindow.Call(w.hwnd, w32.MONITOR_DEFAULTTONEAREST)
+	offsetX, offsetY := monitorWorkspaceOffset(monitor)
+	rect := placement.RcNormalPosition
+	state := WindowState{
+		X:         rect.Left + offsetX,
+		Y:         rect.Top + offsetY,
+		Width:     rect.Right - rect.Left,
+		Height:    rect.Bottom - rect.Top,
+		Maximized: placement.ShowCmd == w32.SW_SHOWMAXIMIZED,
+		Minimized: placement.ShowCmd == w32.SW_SHOWMINIMIZED,
+		DPI:       w32.GetDpiForWindow(w.hwnd),
+	}
+	if state.Minimized {
+		// 最小化前如果是最大化状态，WPF_RESTORETOMAXIMIZED 会被设置
+		state.Maximized = placement.Flags&0x0002 != 0
+	}
+
+	if info, ok := w32.GetMonitorInfo(monitor); ok {
+		state.Monitor = windows.UTF16ToString(info.SzDevice[:])
+	}
+	return state, true
+}
+
+// sanitizeWindowState 确保窗口状态在当前显示器布局下可见：
+// 原显示器不存在时移动到主显示器并居中，DPI 变化时按比例缩放，并将窗口限制在工作区内
+func sanitizeWindowState(state WindowState) WindowState {
+	rect := w32.Rect{Left: state.X, Top: state.Y, Right: state.X + state.Width, Bottom: state.Y + state.Height}
+	monitor, _, _ := w32.User32MonitorFromRect.Call(uintptr(unsafe.Pointer(&rect)), w32.MONITOR_DEFAULTTONULL)
+	relocate := monitor == 0
+	if relocate {
+		monitor, _, _ = w32.User32MonitorFromRect.Call(uintptr(unsafe.Pointer(&rect)), w32.MONITOR_DEFAULTTOPRIMARY)
+	}
+	info, ok := w32.GetMonitorInfo(monitor)
+	if !ok {
+		return state
+	}
+	if name := windows.UTF16ToString(info.SzDevice[:]); state.Monitor != "" && name != state.Monitor {
+		// 显示器布局发生变化，窗口落在了另一个显示器上
+		relocate = true
+	}
+	work := info.RcWork
+
+	if dpi := w32.GetDpiForMonitor(monitor); state.DPI != 0 && dpi != state.DPI {
+		state.Width = int32(int64(state.Width) * int64(dpi) / int64(state.DPI))
+		state.Height = int32(int64(state.Height) * int64(dpi) / int64(state.DPI))
+	}
+	state.DPI = w32.GetDpiForMonitor(monitor)
+	state.Monitor = windows.UTF16ToString(info.SzDevice[:])
+
+	if workWidth := work.Right - work.Left; state.Width > workWidth {
+		state.Width = workWidth
+	}
+	if workHeight := work.Bottom - work.Top; state.Height > workHeight {
+		state.Height = workHeight
+	}
+
+	if relocate {
+		state.X = work.Left + (work.Right-work.Left-state.Width)/2
+		state.Y = work.Top + (work.Bottom-work.Top-state.Height)/2
+	}
+	if state.X+state.Width > work.Right {
+		state.X = work.Right - state.Width
+	}
+	if state.Y+state.Height > work.Bottom {
+		state.Y = work.Bottom - state.Height
+	}
+	if state.X < work.Left {
+		state.X = work.Left
+	}
+	if state.Y < work.Top {
+		state.Y = work.Top
+	}
+	return state
+}
+
+// applyWindowState 将保存的状态应用到窗口，窗口以还原或最大化状态显示
+func (w *webview) applyWindowState(state WindowState) {
+	state = sanitizeWindowState(state)
+
+	offsetX, offsetY := rectWorkspaceOffset(w32.Rect{Left: state.X, Top: state.Y, Right: state.X + state.Width, Bottom: state.Y + state.Height})
+	placement := w32.WindowPlacement{
+		ShowCmd: w32.SW_SHOWNORMAL,
+		RcNormalPosition: w32.Rect{
+			Left:   state.X - offsetX,
+			Top:    state.Y - offsetY,
+			Right:  state.X - offsetX + state.Width,
+			Bottom: state.Y - offsetY + state.Height,
+		},
+	}
+	placement.Length = uint32(unsafe.Sizeof(placement))
+	// 不恢复最小化状态，以免启动后窗口不可见
+	if state.Maximized {
+		placement.ShowCmd = w32.SW_SHOWMAXIMIZED
+	}
+	_, _, _ = w32.User32SetWindowPlacement.Call(w.hwnd, uintptr(unsafe.Pointer(&placement)))
+}
+
+// SaveWindowState 立即保存窗口状态到 WindowOptions.StateFile，未配置时不做任何操作。
+// 窗口关闭时会自动调用。
+func (w *webview) SaveWindowState() error {
+	if w.stateFile == "" || w.hwnd == 0 {
+		return nil
+	}
+	state, ok := w.captureWindowState()
+	if !ok {
+		return nil
+	}
+	return saveWindowState(w.stateFile, w.stateKey, state)
+}
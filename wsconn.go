@@ -0,0 +1,406 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// 由 WebSocket 服务处理的消息类型，其他类型的消息交给消息处理器
+const (
+	wsTypeWelcome  = "welcome"  // 服务端 -> 客户端: 连接建立，附带连接 ID
+	wsTypeJoin     = "join"     // 客户端 -> 服务端: 加入房间
+	wsTypeLeave    = "leave"    // 客户端 -> 服务端: 离开房间
+	wsTypePublish  = "publish"  // 双向: 向房间内的其他连接广播
+	wsTypeRequest  = "request"  // 双向: 请求，需要以相同 id 回复 response
+	wsTypeResponse = "response" // 双向: 请求的结果
+)
+
+// wsFrame 是 WebSocket 消息的 JSON 格式，与 {"type":...,"data":...} 的约定兼容
+type wsFrame struct {
+	Type   string          `json:"type"`
+	ID     string          `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Room   string          `json:"room,omitempty"`
+	From   string          `json:"from,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+var (
+	// ErrWSConnClosed 表示连接已关闭
+	ErrWSConnClosed = errors.New("websocket connection closed")
+	// ErrWSNoHandler 表示对方没有注册请求的方法
+	ErrWSNoHandler = errors.New("no websocket request handler")
+)
+
+// WSRequestError 是对方处理请求时返回的错误
+type WSRequestError struct {
+	Method  string
+	Message string
+}
+
+func (e *WSRequestError) Error() string {
+	return fmt.Sprintf("websocket request %s failed: %s", e.Method, e.Message)
+}
+
+// WSRequestHandler 处理客户端的请求，返回值序列化为 JSON 作为响应
+type WSRequestHandler func(conn *WSConn, params json.RawMessage) (interface{}, error)
+
+// WSConn 是一个 WebSocket 客户端连接，可在任意 goroutine 中使用
+type WSConn struct {
+	id     string
+	remote string
+	origin string
+	conn   *websocket.Conn
+	bridge *wsBridge
+
+	writeMu sync.Mutex // gorilla/websocket 不允许并发写
+
+	mu      sync.Mutex
+	meta    map[string]interface{}
+	rooms   map[string]bool
+	pending map[string]chan wsFrame
+	nextReq uint64
+	closed  bool
+}
+
+// ID 返回连接 ID，在同一个 WebSocket 服务内唯一
+func (c *WSConn) ID() string {
+	return c.id
+}
+
+// RemoteAddr 返回客户端地址
+func (c *WSConn) RemoteAddr() string {
+	return c.remote
+}
+
+// Origin 返回客户端的 Origin 请求头，非浏览器客户端为空
+func (c *WSConn) Origin() string {
+	return c.origin
+}
+
+// Meta 返回连接上保存的元数据
+func (c *WSConn) Meta(key string) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.meta[key]
+}
+
+// SetMeta 在连接上保存元数据，如用户名或窗口类型
+func (c *WSConn) SetMeta(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.meta == nil {
+		c.meta = make(map[string]interface{})
+	}
+	c.meta[key] = value
+}
+
+// Send 发送文本消息
+func (c *WSConn) Send(message string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		return fmt.Errorf("websocket write to %s: %w", c.id, err)
+	}
+	return nil
+}
+
+// SendJSON 将 v 序列化为 JSON 后发送
+func (c *WSConn) SendJSON(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Send(string(b))
+}
+
+// Close 关闭连接，断开回调会在连接的 goroutine 中调用
+func (c *WSConn) Close() error {
+	return c.conn.Close()
+}
+
+// Join 加入房间
+func (c *WSConn) Join(room string) {
+	c.mu.Lock()
+	if c.rooms == nil {
+		c.rooms = make(map[string]bool)
+	}
+	c.rooms[room] = true
+	c.mu.Unlock()
+}
+
+// Leave 离开房间
+func (c *WSConn) Leave(room string) {
+	c.mu.Lock()
+	delete(c.rooms, room)
+	c.mu.Unlock()
+}
+
+// InRoom 报告连接是否在房间中
+func (c *WSConn) InRoom(room string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rooms[room]
+}
+
+// Rooms 返回连接所在的房间
+func (c *WSConn) Rooms() []string {
+	c.mu.Lock()
+	rooms := make([]string, 0, len(c.rooms))
+	for room := range c.rooms {
+		rooms = append(rooms, room)
+	}
+	c.mu.Unlock()
+	sort.Strings(rooms)
+	return rooms
+}
+
+// Request 向客户端发送请求并等待相同 id 的 response，
+// 客户端应回复 {"type":"response","id":...,"data":...} 或带 error 字段的响应
+func (c *WSConn) Request(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrWSConnClosed
+	}
+	c.nextReq++
+	id := "s" + strconv.FormatUint(c.nextReq, 10)
+	ch := make(chan wsFrame, 1)
+	if c.pending == nil {
+		c.pending = make(map[string]chan wsFrame)
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.SendJSON(wsFrame{Type: wsTypeRequest, ID: id, Method: method, Data: data}); err != nil {
+		return nil, err
+	}
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, ErrWSConnClosed
+		}
+		if resp.Error != "" {
+			return nil, &WSRequestError{Method: method, Message: resp.Error}
+		}
+		return resp.Data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// resolve 将响应交给等待中的 Request
+func (c *WSConn) resolve(frame wsFrame) {
+	c.mu.Lock()
+	ch := c.pending[frame.ID]
+	delete(c.pending, frame.ID)
+	c.mu.Unlock()
+	if ch != nil {
+		ch <- frame
+	}
+}
+
+// shutdown 标记连接已关闭并结束所有等待中的请求
+func (c *WSConn) shutdown() {
+	c.mu.Lock()
+	c.closed = true
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+}
+
+// reply 回复客户端的请求
+func (c *WSConn) reply(id string, result interface{}, err error) {
+	frame := wsFrame{Type: wsTypeResponse, ID: id}
+	if err == nil {
+		frame.Data, err = json.Marshal(result)
+	}
+	if err != nil {
+		frame.Data = nil
+		frame.Error = err.Error()
+	}
+	if err := c.SendJSON(frame); err != nil {
+		log.Printf("WebSocket reply failed: %v", err)
+	}
+}
+
+// newWSConn 包装已升级的连接
+func newWSConn(bridge *wsBridge, conn *websocket.Conn, origin string) *WSConn {
+	remote := conn.RemoteAddr().String()
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		remote = addr.String()
+	}
+	bridge.m.Lock()
+	bridge.nextConnID++
+	id := "c" + strconv.FormatUint(bridge.nextConnID, 10)
+	bridge.m.Unlock()
+	return &WSConn{id: id, remote: remote, origin: origin, conn: conn, bridge: bridge}
+}
+
+// handleFrame 处理服务端负责的消息类型，返回 false 表示交给消息处理器
+func (w *webview) handleFrame(c *WSConn, message []byte) bool {
+	var frame wsFrame
+	if json.Unmarshal(message, &frame) != nil {
+		return false
+	}
+	switch frame.Type {
+	case wsTypeJoin:
+		if frame.Room != "" {
+			c.Join(frame.Room)
+		}
+	case wsTypeLeave:
+		c.Leave(frame.Room)
+	case wsTypePublish:
+		c.bridge.publish(frame.Room, c, wsFrame{Type: wsTypePublish, Room: frame.Room, From: c.id, Data: frame.Data})
+	case wsTypeResponse:
+		c.resolve(frame)
+	case wsTypeRequest:
+		w.wsMu.Lock()
+		handler := w.wsRequestHandlers[frame.Method]
+		w.wsMu.Unlock()
+		// 在单独的 goroutine 中处理，处理器内可以继续向同一连接发送请求
+		go func() {
+			if handler == nil {
+				c.reply(frame.ID, nil, fmt.Errorf("%w: %s", ErrWSNoHandler, frame.Method))
+				return
+			}
+			result, err := handler(c, frame.Data)
+			c.reply(frame.ID, result, err)
+		}()
+	default:
+		return false
+	}
+	return true
+}
+
+// publish 向房间内除 except 以外的连接发送 frame，返回发送成功的连接数
+func (b *wsBridge) publish(room string, except *WSConn, frame wsFrame) int {
+	b.m.Lock()
+	conns := make([]*WSConn, 0, len(b.conns))
+	for _, c := range b.conns {
+		if c != except && c.InRoom(room) {
+			conns = append(conns, c)
+		}
+	}
+	b.m.Unlock()
+
+	sent := 0
+	for _, c := range conns {
+		if err := c.SendJSON(frame); err != nil {
+			log.Printf("WebSocket publish failed: %v", err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// OnWebSocketConnect 设置连接建立时的回调，在连接的 goroutine 中调用
+func (w *webview) OnWebSocketConnect(handler func(conn *WSConn)) {
+	w.wsMu.Lock()
+	w.wsConnectHandler = handler
+	w.wsMu.Unlock()
+}
+
+// OnWebSocketDisconnect 设置连接断开时的回调，在连接的 goroutine 中调用
+func (w *webview) OnWebSocketDisconnect(handler func(conn *WSConn)) {
+	w.wsMu.Lock()
+	w.wsDisconnectHandler = handler
+	w.wsMu.Unlock()
+}
+
+// OnWebSocketConnMessage 设置带连接信息的消息处理器，与 OnWebSocketMessage 可同时使用
+func (w *webview) OnWebSocketConnMessage(handler func(conn *WSConn, message string)) {
+	w.wsMu.Lock()
+	w.wsConnMessageHandler = handler
+	w.wsMu.Unlock()
+}
+
+// HandleWebSocketRequest 注册客户端请求的处理器，handler 为 nil 时取消注册
+func (w *webview) HandleWebSocketRequest(method string, handler WSRequestHandler) {
+	w.wsMu.Lock()
+	defer w.wsMu.Unlock()
+	if handler == nil {
+		delete(w.wsRequestHandlers, method)
+		return
+	}
+	if w.wsRequestHandlers == nil {
+		w.wsRequestHandlers = make(map[string]WSRequestHandler)
+	}
+	w.wsRequestHandlers[method] = handler
+}
+
+// WebSocketConns 返回当前所有连接，按连接顺序排列
+func (w *webview) WebSocketConns() []*WSConn {
+	bridge := w.webSocketBridge()
+	if bridge == nil {
+		return nil
+	}
+	return bridge.connList()
+}
+
+// WebSocketConn 按 ID 查找连接，不存在时返回 nil
+func (w *webview) WebSocketConn(id string) *WSConn {
+	bridge := w.webSocketBridge()
+	if bridge == nil {
+		return nil
+	}
+	bridge.m.Lock()
+	defer bridge.m.Unlock()
+	return bridge.conns[id]
+}
+
+// PublishWebSocket 向房间内的所有连接发送 {"type":"publish","room":...,"data":...}，
+// data 序列化为 JSON，返回发送成功的连接数
+func (w *webview) PublishWebSocket(room string, data interface{}) (int, error) {
+	bridge := w.webSocketBridge()
+	if bridge == nil {
+		return 0, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	return bridge.publish(room, nil, wsFrame{Type: wsTypePublish, Room: room, Data: b}), nil
+}
+
+func (b *wsBridge) connList() []*WSConn {
+	b.m.Lock()
+	conns := make([]*WSConn, 0, len(b.conns))
+	for _, c := range b.conns {
+		conns = append(conns, c)
+	}
+	b.m.Unlock()
+	sort.Slice(conns, func(i, j int) bool {
+		a, _ := strconv.ParseUint(conns[i].id[1:], 10, 64)
+		b, _ := strconv.ParseUint(conns[j].id[1:], 10, 64)
+		return a < b
+	})
+	return conns
+}
@@ -0,0 +1,246 @@
+//go:build windows
+// +build windows
+
+package webview2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/yuaotian/go-win-webview2/internal/w32"
+	"github.com/yuaotian/go-win-webview2/pkg/remote"
+)
+
+const (
+	defaultMinZoom = 0.25
+	defaultMaxZoom = 5.0
+)
+
+// zoomSteps 是 ZoomIn/ZoomOut 使用的缩放级别，与 Edge 浏览器保持一致
+var zoomSteps = []float64{0.25, 0.33, 0.5, 0.67, 0.75, 0.8, 0.9, 1, 1.1, 1.25, 1.5, 1.75, 2, 2.5, 3, 4, 5}
+
+// ZoomOptions 缩放相关配置
+type ZoomOptions struct {
+	Min               float64 // 最小缩放比例，默认 0.25
+	Max               float64 // 最大缩放比例，默认 5.0
+	RememberPerOrigin bool    // 按来源 (scheme://host) 记住缩放比例
+	StoreFile         string  // 按来源记录的缩放比例的持久化文件 (JSON)，为空时仅保存在内存中
+	Hotkeys           bool    // 在 WebView 获得焦点时启用 Ctrl+= / Ctrl+- / Ctrl+0
+}
+
+// zoomState 保存缩放状态
+type zoomState struct {
+	sync.Mutex
+	opts      ZoomOptions
+	byOrigin  map[string]float64
+	onChanged func(float64)
+}
+
+func newZoomState(opts ZoomOptions) *zoomState {
+	if opts.Min <= 0 {
+		opts.Min = defaultMinZoom
+	}
+	if opts.Max <= 0 {
+		opts.Max = defaultMaxZoom
+	}
+	if opts.Max < opts.Min {
+		opts.Min, opts.Max = opts.Max, opts.Min
+	}
+	z := &zoomState{
+		opts:     opts,
+		byOrigin: make(map[string]float64),
+	}
+	if opts.RememberPerOrigin && opts.StoreFile != "" {
+		if data, err := ioutil.ReadFile(opts.StoreFile); err == nil {
+			if err := json.Unmarshal(data, &z.byOrigin); err != nil {
+				log.Printf("Warning: Failed to parse zoom store %s: %v", opts.StoreFile, err)
+			}
+		} else if !os.IsNotExist(err) {
+			log.Printf("Warning: Failed to read zoom store %s: %v", opts.StoreFile, err)
+		}
+	}
+	return z
+}
+
+func (z *zoomState) clamp(factor float64) float64 {
+	z.Lock()
+	defer z.Unlock()
+	return math.Max(z.opts.Min, math.Min(z.opts.Max, factor))
+}
+
+func (z *zoomState) remember(origin string, factor float64) {
+	if !z.opts.RememberPerOrigin || origin == "" {
+		return
+	}
+	z.Lock()
+	defer z.Unlock()
+	if old, ok := z.byOrigin[origin]; ok && old == factor {
+		return
+	}
+	if factor == 1 {
+		delete(z.byOrigin, origin)
+	} else {
+		z.byOrigin[origin] = factor
+	}
+	if z.opts.StoreFile == "" {
+		return
+	}
+	data, err := json.MarshalIndent(z.byOrigin, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(z.opts.StoreFile), 0755)
+	if err := ioutil.WriteFile(z.opts.StoreFile, data, 0644); err != nil {
+		log.Printf("Warning: Failed to write zoom store %s: %v", z.opts.StoreFile, err)
+	}
+}
+
+func (z *zoomState) remembered(origin string) float64 {
+	z.Lock()
+	defer z.Unlock()
+	if factor, ok := z.byOrigin[origin]; ok {
+		return factor
+	}
+	return 1
+}
+
+// zoomOrigin 返回 URL 的来源 (scheme://host)，无法确定来源时返回空字符串
+func zoomOrigin(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// SetZoom 设置缩放比例 (1.0 为 100%)，超出范围时会被限制在 [Min, Max] 内
+func (w *webview) SetZoom(factor float64) {
+	factor = w.zoom.clamp(factor)
+	w.runOnMain(func() {
+		if err := w.browser.SetZoomFactor(factor); err != nil {
+			log.Printf("Failed to set zoom factor: %v", err)
+		}
+	})
+}
+
+// Zoom 返回当前缩放比例
+func (w *webview) Zoom() float64 {
+	var factor float64
+	w.runOnMain(func() {
+		var err error
+		if factor, err = w.browser.ZoomFactor(); err != nil {
+			factor = 1
+		}
+	})
+	return factor
+}
+
+// SetZoomLimits 设置缩放比例的范围，当前缩放比例会被重新限制
+func (w *webview) SetZoomLimits(min, max float64) {
+	w.zoom.Lock()
+	if min > 0 {
+		w.zoom.opts.Min = min
+	}
+	if max > 0 {
+		w.zoom.opts.Max = max
+	}
+	if w.zoom.opts.Max < w.zoom.opts.Min {
+		w.zoom.opts.Min, w.zoom.opts.Max = w.zoom.opts.Max, w.zoom.opts.Min
+	}
+	w.zoom.Unlock()
+	w.SetZoom(w.Zoom())
+}
+
+// ZoomIn 放大到下一个缩放级别
+func (w *webview) ZoomIn() {
+	current := w.Zoom()
+	for _, step := range zoomSteps {
+		if step > current+0.001 {
+			w.SetZoom(step)
+			return
+		}
+	}
+	w.SetZoom(math.MaxFloat64)
+}
+
+// ZoomOut 缩小到上一个缩放级别
+func (w *webview) ZoomOut() {
+	current := w.Zoom()
+	for i := len(zoomSteps) - 1; i >= 0; i-- {
+		if zoomSteps[i] < current-0.001 {
+			w.SetZoom(zoomSteps[i])
+			return
+		}
+	}
+	w.SetZoom(0)
+}
+
+// ResetZoom 恢复为 100%
+func (w *webview) ResetZoom() {
+	w.SetZoom(1)
+}
+
+// OnZoomChanged 设置缩放比例变化回调
+func (w *webview) OnZoomChanged(callback func(factor float64)) {
+	w.zoom.Lock()
+	w.zoom.onChanged = callback
+	w.zoom.Unlock()
+}
+
+// zoomFactorChanged 处理 WebView2 的缩放变化事件
+func (w *webview) zoomFactorChanged(factor float64) {
+	if clamped := w.zoom.clamp(factor); clamped != factor {
+		// 超出范围时写回限制后的值，会再次触发该事件
+		_ = w.browser.SetZoomFactor(clamped)
+		return
+	}
+	w.zoom.remember(zoomOrigin(w.browser.Source()), factor)
+
+	w.zoom.Lock()
+	callback := w.zoom.onChanged
+	w.zoom.Unlock()
+	if callback != nil {
+		callback(factor)
+	}
+	w.notifyRemote(remote.EventZoomChanged, factor)
+}
+
+// restoreZoom 在导航完成后恢复当前来源记住的缩放比例
+func (w *webview) restoreZoom() {
+	if !w.zoom.opts.RememberPerOrigin {
+		return
+	}
+	origin := zoomOrigin(w.browser.Source())
+	if origin == "" {
+		return
+	}
+	factor := w.zoom.clamp(w.zoom.remembered(origin))
+	if current, err := w.browser.ZoomFactor(); err == nil && current != factor {
+		_ = w.browser.SetZoomFactor(factor)
+	}
+}
+
+// zoomHotkey 处理缩放快捷键，返回是否已处理
+func (w *webview) zoomHotkey(virtualKey uint) bool {
+	if !w.zoom.opts.Hotkeys || !w32.IsKeyDown(w32.VK_CONTROL) || w32.IsKeyDown(w32.VK_MENU) {
+		return false
+	}
+	switch virtualKey {
+	case w32.VK_OEM_PLUS, w32.VK_ADD:
+		w.ZoomIn()
+	case w32.VK_OEM_MINUS, w32.VK_SUBTRACT:
+		w.ZoomOut()
+	case '0', w32.VK_NUMPAD0:
+		w.ResetZoom()
+	default:
+		return false
+	}
+	return true
+}